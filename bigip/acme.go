@@ -0,0 +1,405 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// acmePollInterval is how often pending ACME authorizations and orders are polled.
+var acmePollInterval = 2 * time.Second
+
+// acmeClient is a minimal RFC 8555 client: it registers an account, places an
+// order and answers HTTP-01 challenges. The account key is an ECDSA P-256 key.
+type acmeClient struct {
+	http      *http.Client
+	directory acmeDirectory
+	key       *ecdsa.PrivateKey
+	kid       string
+	nonce     string
+}
+
+type acmeDirectory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type acmeIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type acmeOrder struct {
+	Status         string           `json:"status"`
+	Identifiers    []acmeIdentifier `json:"identifiers"`
+	Authorizations []string         `json:"authorizations"`
+	Finalize       string           `json:"finalize"`
+	Certificate    string           `json:"certificate,omitempty"`
+	Error          *acmeProblem     `json:"error,omitempty"`
+}
+
+type acmeAuthorization struct {
+	Status     string          `json:"status"`
+	Identifier acmeIdentifier  `json:"identifier"`
+	Challenges []acmeChallenge `json:"challenges"`
+}
+
+type acmeChallenge struct {
+	Type   string       `json:"type"`
+	URL    string       `json:"url"`
+	Token  string       `json:"token"`
+	Status string       `json:"status"`
+	Error  *acmeProblem `json:"error,omitempty"`
+}
+
+// acmeProblem is an RFC 7807 problem document returned by the ACME server.
+type acmeProblem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func (p *acmeProblem) Error() string {
+	return fmt.Sprintf("%s: %s", p.Type, p.Detail)
+}
+
+// newAcmeClient fetches the directory of the ACME server. caPEM, when not
+// empty, replaces the system roots to verify the server, e.g. for Pebble.
+func newAcmeClient(ctx context.Context, directoryURL, caPEM string, key *ecdsa.PrivateKey) (*acmeClient, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, fmt.Errorf("no certificate found in the ACME CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	c := &acmeClient{
+		http: &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		key:  key,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, directoryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ACME directory %s: %v", directoryURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error retrieving ACME directory %s: %s", directoryURL, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&c.directory); err != nil {
+		return nil, fmt.Errorf("error decoding ACME directory %s: %v", directoryURL, err)
+	}
+	return c, nil
+}
+
+// register creates the ACME account of the key, or finds it if it already exists.
+func (c *acmeClient) register(ctx context.Context, email string) error {
+	account := map[string]interface{}{"termsOfServiceAgreed": true}
+	if email != "" {
+		account["contact"] = []string{"mailto:" + email}
+	}
+	resp, err := c.post(ctx, c.directory.NewAccount, account, nil)
+	if err != nil {
+		return fmt.Errorf("error registering ACME account: %v", err)
+	}
+	c.kid = resp.Header.Get("Location")
+	if c.kid == "" {
+		return fmt.Errorf("error registering ACME account: no account URL returned")
+	}
+	return nil
+}
+
+// newOrder orders a certificate for the DNS names and returns the order and its URL.
+func (c *acmeClient) newOrder(ctx context.Context, names []string) (*acmeOrder, string, error) {
+	var identifiers []acmeIdentifier
+	for _, name := range names {
+		identifiers = append(identifiers, acmeIdentifier{Type: "dns", Value: name})
+	}
+	var order acmeOrder
+	resp, err := c.post(ctx, c.directory.NewOrder, map[string]interface{}{"identifiers": identifiers}, &order)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating ACME order: %v", err)
+	}
+	return &order, resp.Header.Get("Location"), nil
+}
+
+func (c *acmeClient) authorization(ctx context.Context, url string) (*acmeAuthorization, error) {
+	var authz acmeAuthorization
+	if _, err := c.post(ctx, url, nil, &authz); err != nil {
+		return nil, fmt.Errorf("error retrieving ACME authorization %s: %v", url, err)
+	}
+	return &authz, nil
+}
+
+// accept tells the ACME server the challenge is ready to be validated.
+func (c *acmeClient) accept(ctx context.Context, challenge acmeChallenge) error {
+	if _, err := c.post(ctx, challenge.URL, struct{}{}, nil); err != nil {
+		return fmt.Errorf("error accepting ACME challenge %s: %v", challenge.URL, err)
+	}
+	return nil
+}
+
+// waitAuthorization polls an authorization until it is no longer pending.
+func (c *acmeClient) waitAuthorization(ctx context.Context, url string) error {
+	for {
+		authz, err := c.authorization(ctx, url)
+		if err != nil {
+			return err
+		}
+		switch authz.Status {
+		case "valid":
+			return nil
+		case "pending", "processing":
+		default:
+			for _, challenge := range authz.Challenges {
+				if challenge.Error != nil {
+					return fmt.Errorf("authorization of %s is %s: %v", authz.Identifier.Value, authz.Status, challenge.Error)
+				}
+			}
+			return fmt.Errorf("authorization of %s is %s", authz.Identifier.Value, authz.Status)
+		}
+		if err := acmeSleep(ctx); err != nil {
+			return fmt.Errorf("timed out waiting for the authorization of %s", authz.Identifier.Value)
+		}
+	}
+}
+
+// finalize submits the DER encoded CSR and polls the order until the
+// certificate is issued, it returns the URL of the certificate.
+func (c *acmeClient) finalize(ctx context.Context, order *acmeOrder, orderURL string, csr []byte) (string, error) {
+	if _, err := c.post(ctx, order.Finalize, map[string]string{"csr": acmeEncode(csr)}, order); err != nil {
+		return "", fmt.Errorf("error finalizing ACME order: %v", err)
+	}
+	for {
+		switch order.Status {
+		case "valid":
+			return order.Certificate, nil
+		case "pending", "ready", "processing":
+		default:
+			if order.Error != nil {
+				return "", fmt.Errorf("ACME order is %s: %v", order.Status, order.Error)
+			}
+			return "", fmt.Errorf("ACME order is %s", order.Status)
+		}
+		if err := acmeSleep(ctx); err != nil {
+			return "", fmt.Errorf("timed out waiting for the ACME order %s, status is %s", orderURL, order.Status)
+		}
+		if _, err := c.post(ctx, orderURL, nil, order); err != nil {
+			return "", fmt.Errorf("error retrieving ACME order %s: %v", orderURL, err)
+		}
+	}
+}
+
+// certificate downloads the PEM encoded certificate chain.
+func (c *acmeClient) certificate(ctx context.Context, url string) (string, error) {
+	var chain bytes.Buffer
+	if _, err := c.post(ctx, url, nil, &chain); err != nil {
+		return "", fmt.Errorf("error downloading certificate %s: %v", url, err)
+	}
+	return chain.String(), nil
+}
+
+// keyAuthorization is the response the ACME server expects at
+// /.well-known/acme-challenge/<token> for an HTTP-01 challenge.
+func (c *acmeClient) keyAuthorization(token string) string {
+	jwk, _ := json.Marshal(acmeJwk(&c.key.PublicKey))
+	thumbprint := sha256.Sum256(jwk)
+	return token + "." + acmeEncode(thumbprint[:])
+}
+
+// post sends a JWS signed request, a nil payload is a POST-as-GET. The
+// response is decoded into out, a *bytes.Buffer gets the raw body. A badNonce
+// error is retried once with a fresh nonce.
+func (c *acmeClient) post(ctx context.Context, url string, payload interface{}, out interface{}) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, body, err := c.postOnce(ctx, url, payload)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			problem := &acmeProblem{Status: resp.StatusCode}
+			if json.Unmarshal(body, problem) != nil || problem.Type == "" {
+				return nil, fmt.Errorf("%s: %s", resp.Status, body)
+			}
+			if problem.Type == "urn:ietf:params:acme:error:badNonce" && attempt == 0 {
+				continue
+			}
+			return nil, problem
+		}
+		switch o := out.(type) {
+		case nil:
+		case *bytes.Buffer:
+			o.Write(body)
+		default:
+			if err := json.Unmarshal(body, out); err != nil {
+				return nil, fmt.Errorf("error decoding the response of %s: %v", url, err)
+			}
+		}
+		return resp, nil
+	}
+}
+
+func (c *acmeClient) postOnce(ctx context.Context, url string, payload interface{}) (*http.Response, []byte, error) {
+	if c.nonce == "" {
+		if err := c.fetchNonce(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	jws, err := c.sign(url, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.nonce = ""
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jws))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	c.nonce = resp.Header.Get("Replay-Nonce")
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+func (c *acmeClient) fetchNonce(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.directory.NewNonce, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("error retrieving ACME nonce: %v", err)
+	}
+	resp.Body.Close()
+	c.nonce = resp.Header.Get("Replay-Nonce")
+	if c.nonce == "" {
+		return fmt.Errorf("error retrieving ACME nonce: no Replay-Nonce header in the response of %s", c.directory.NewNonce)
+	}
+	return nil
+}
+
+// sign returns the flattened JWS of the payload. The account URL identifies
+// the key once registered, until then the public key is embedded.
+func (c *acmeClient) sign(url string, payload interface{}) ([]byte, error) {
+	protected := map[string]interface{}{"alg": "ES256", "nonce": c.nonce, "url": url}
+	if c.kid != "" {
+		protected["kid"] = c.kid
+	} else {
+		protected["jwk"] = acmeJwk(&c.key.PublicKey)
+	}
+	header, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	if payload != nil {
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+	}
+	signingInput := acmeEncode(header) + "." + acmeEncode(body)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, c.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return json.Marshal(map[string]string{
+		"protected": acmeEncode(header),
+		"payload":   acmeEncode(body),
+		"signature": acmeEncode(signature),
+	})
+}
+
+// acmeJwk is the JSON web key of an ECDSA P-256 public key, with its members
+// in the lexicographic order the RFC 7638 thumbprint requires.
+func acmeJwk(key *ecdsa.PublicKey) interface{} {
+	return struct {
+		Crv string `json:"crv"`
+		Kty string `json:"kty"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}{"P-256", "EC", acmeEncode(key.X.FillBytes(make([]byte, 32))), acmeEncode(key.Y.FillBytes(make([]byte, 32)))}
+}
+
+func acmeEncode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func acmeSleep(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(acmePollInterval):
+		return nil
+	}
+}
+
+// generateAcmeAccountKey returns a new ECDSA P-256 account key, PEM encoded.
+func generateAcmeAccountKey() (string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), nil
+}
+
+// parseAcmeAccountKey parses a PEM encoded ECDSA P-256 key, in SEC 1 or PKCS #8 form.
+func parseAcmeAccountKey(keyPEM string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in the ACME account key")
+	}
+	var key crypto.PrivateKey
+	var err error
+	if strings.Contains(block.Type, "EC PRIVATE KEY") {
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the ACME account key: %v", err)
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("the ACME account key must be an ECDSA P-256 key")
+	}
+	return ecKey, nil
+}
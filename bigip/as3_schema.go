@@ -0,0 +1,183 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/F5Networks/terraform-provider-bigip/schemas"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const as3SchemaURL = "https://raw.githubusercontent.com/F5Networks/terraform-provider-bigip/master/schemas/as3schema.json"
+
+var (
+	as3SchemaVersionOnce sync.Once
+	as3SchemaVersion     string
+	as3SchemaVersionErr  error
+	as3SchemaOnce        sync.Once
+	as3SchemaCompiler    *jsonschema.Compiler
+	as3SchemaErr         error
+	as3SchemaCache       sync.Map // definition -> *jsonschema.Schema
+)
+
+// bundledAs3SchemaVersion returns the newest schemaVersion of ADC declarations
+// listed by the bundled AS3 schema.
+func bundledAs3SchemaVersion() (string, error) {
+	as3SchemaVersionOnce.Do(func() {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(schemas.AS3, &doc); err != nil {
+			as3SchemaVersionErr = fmt.Errorf("unable to parse bundled AS3 schema: %v", err)
+			return
+		}
+		defs, _ := doc["definitions"].(map[string]interface{})
+		adc, _ := defs["ADC"].(map[string]interface{})
+		props, _ := adc["properties"].(map[string]interface{})
+		sv, _ := props["schemaVersion"].(map[string]interface{})
+		enum, _ := sv["enum"].([]interface{})
+		for _, v := range enum {
+			if s, ok := v.(string); ok && (as3SchemaVersion == "" || compareSchemaVersions(s, as3SchemaVersion) > 0) {
+				as3SchemaVersion = s
+			}
+		}
+		if as3SchemaVersion == "" {
+			as3SchemaVersionErr = errors.New("bundled AS3 schema does not list any schemaVersion values")
+		}
+	})
+	return as3SchemaVersion, as3SchemaVersionErr
+}
+
+// compileAs3Schema compiles the bundled AS3 schema at the given fragment, e.g. "#/definitions/ADC".
+func compileAs3Schema(fragment string) (*jsonschema.Schema, error) {
+	if s, ok := as3SchemaCache.Load(fragment); ok {
+		return s.(*jsonschema.Schema), nil
+	}
+	as3SchemaOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		compiler.Draft = jsonschema.Draft7
+		for name, f := range atcSchemaFormats {
+			compiler.Formats[name] = f
+		}
+		as3SchemaErr = compiler.AddResource(as3SchemaURL, bytes.NewReader(schemas.AS3))
+		as3SchemaCompiler = compiler
+	})
+	if as3SchemaErr != nil {
+		return nil, fmt.Errorf("unable to load bundled AS3 schema: %v", as3SchemaErr)
+	}
+	s, err := as3SchemaCompiler.Compile(as3SchemaURL + fragment)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile bundled AS3 schema: %v", err)
+	}
+	as3SchemaCache.Store(fragment, s)
+	return s, nil
+}
+
+// validateAs3Declaration validates as3Json against the bundled AS3 schema.
+// AS3 envelopes, bare ADC declarations and per-application declarations are
+// accepted. Declarations newer than the bundled schema are not validated.
+func validateAs3Declaration(as3Json string) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(as3Json), &doc); err != nil {
+		return fmt.Errorf("as3_json is not valid JSON: %v", err)
+	}
+
+	fragment := ""
+	version, _ := doc["schemaVersion"].(string)
+	switch doc["class"] {
+	case "AS3":
+		if decl, ok := doc["declaration"].(map[string]interface{}); ok {
+			version, _ = decl["schemaVersion"].(string)
+		}
+	case "ADC":
+		fragment = "#/definitions/ADC"
+	default:
+		fragment = "#/definitions/PerAppDeclaration"
+	}
+	maxVersion, err := bundledAs3SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version != "" && compareSchemaVersions(version, maxVersion) > 0 {
+		log.Printf("[WARN] AS3 schemaVersion %s is newer than the bundled schema (%s), skipping as3_json validation", version, maxVersion)
+		return nil
+	}
+
+	s, err := compileAs3Schema(fragment)
+	if err != nil {
+		return err
+	}
+	if err := s.Validate(doc); err != nil {
+		var verr *jsonschema.ValidationError
+		if !errors.As(err, &verr) {
+			return err
+		}
+		return fmt.Errorf("as3_json does not match the AS3 schema:\n%s", strings.Join(schemaErrorLines(verr, ""), "\n"))
+	}
+	return nil
+}
+
+// as3TenantAppDeclarations splits an AS3 declaration into one normalized JSON
+// document per tenant, holding the tenant level properties, and one per
+// application keyed "<tenant>/<application>". Per-application declarations
+// are keyed under perAppTenant.
+func as3TenantAppDeclarations(as3Json, perAppTenant string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if as3Json == "" {
+		return result, nil
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(as3Json), &doc); err != nil {
+		return nil, err
+	}
+	decl := doc
+	if d, ok := doc["declaration"].(map[string]interface{}); ok {
+		decl = d
+	}
+
+	for name, value := range decl {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch obj["class"] {
+		case "Tenant":
+			tenant := make(map[string]interface{})
+			for k, v := range obj {
+				if app, ok := v.(map[string]interface{}); ok && app["class"] == "Application" {
+					out, err := json.Marshal(app)
+					if err != nil {
+						return nil, err
+					}
+					result[name+"/"+k] = string(out)
+					continue
+				}
+				tenant[k] = v
+			}
+			out, err := json.Marshal(tenant)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = string(out)
+		case "Application":
+			key := name
+			if perAppTenant != "" {
+				key = perAppTenant + "/" + name
+			}
+			out, err := json.Marshal(obj)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = string(out)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for offline AS3 schema validation and the per-tenant/application view - no F5 BIG-IP connection required
+
+func TestValidateAs3Declaration(t *testing.T) {
+	cases := []struct {
+		name    string
+		as3Json string
+		wantErr []string
+	}{
+		{
+			name:    "valid envelope",
+			as3Json: `{"class":"AS3","action":"deploy","declaration":{"class":"ADC","schemaVersion":"3.20.0","T1":{"class":"Tenant","A1":{"class":"Application","vs":{"class":"Service_HTTP","virtualPort":80,"virtualAddresses":["10.0.1.10"],"pool":"p"},"p":{"class":"Pool","members":[{"servicePort":80,"serverAddresses":["192.0.2.10"]}]}}}}}`,
+		},
+		{
+			name:    "valid per-application declaration",
+			as3Json: `{"schemaVersion":"3.50.0","A1":{"class":"Application","p":{"class":"Pool"}}}`,
+		},
+		{
+			name:    "invalid json",
+			as3Json: `{"class":`,
+			wantErr: []string{"not valid JSON"},
+		},
+		{
+			name:    "misspelled class name",
+			as3Json: `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.20.0","T1":{"class":"Tenant","A1":{"class":"Application","vs":{"class":"Service_HTTPX"}}}}}`,
+			wantErr: []string{"/declaration/T1/A1/vs/class", "value must be one of"},
+		},
+		{
+			name:    "unknown schemaVersion",
+			as3Json: `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.20.7","T1":{"class":"Tenant"}}}`,
+			wantErr: []string{"/declaration/schemaVersion"},
+		},
+		{
+			name:    "tenant with wrong class",
+			as3Json: `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.20.0","T1":{"class":"Tenent"}}}`,
+			wantErr: []string{"/declaration/T1/class"},
+		},
+		{
+			name:    "application member out of range port",
+			as3Json: `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.20.0","T1":{"class":"Tenant","A1":{"class":"Application","vs":{"class":"Service_TCP","virtualPort":70000}}}}}`,
+			wantErr: []string{"/declaration/T1/A1/vs/virtualPort"},
+		},
+		{
+			name:    "bad pool member address",
+			as3Json: `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.20.0","T1":{"class":"Tenant","A1":{"class":"Application","p":{"class":"Pool","members":[{"servicePort":80,"serverAddresses":["192.0.2"]}]}}}}}`,
+			wantErr: []string{"/declaration/T1/A1/p/members/0/serverAddresses/0"},
+		},
+		{
+			name:    "invalid tenant name",
+			as3Json: `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.20.0","1bad":{"class":"Tenant"}}}`,
+			wantErr: []string{"/declaration"},
+		},
+		{
+			name:    "deploy without declaration",
+			as3Json: `{"class":"AS3","action":"deploy"}`,
+			wantErr: []string{"declaration"},
+		},
+		{
+			name:    "newer than the bundled schema is not validated",
+			as3Json: `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.99.0","T1":{"class":"Tenant","A1":{"class":"Application","x":{"class":"Future_Class"}}}}}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateAs3Declaration(c.as3Json)
+			if len(c.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				for _, want := range c.wantErr {
+					assert.Contains(t, err.Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestBundledAs3SchemaVersion(t *testing.T) {
+	version, err := bundledAs3SchemaVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "3.50.0", version)
+}
+
+func TestValidateAs3DeclarationExamples(t *testing.T) {
+	files, err := filepath.Glob("../examples/as3/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if filepath.Base(f) == "invalid.json" {
+			continue
+		}
+		as3Json, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, validateAs3Declaration(string(as3Json)), f)
+	}
+}
+
+func TestAs3TenantAppDeclarations(t *testing.T) {
+	as3Json := `{"class":"AS3","declaration":{"class":"ADC","schemaVersion":"3.20.0","id":"x",
+		"T1":{"class":"Tenant","defaultRouteDomain":2,"A1":{"class":"Application","p":{"class":"Pool"}},"A2":{"class":"Application"}},
+		"T2":{"class":"Tenant","Shared":{"class":"Application"}}}}`
+	decls, err := as3TenantAppDeclarations(as3Json, "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"T1":        `{"class":"Tenant","defaultRouteDomain":2}`,
+		"T1/A1":     `{"class":"Application","p":{"class":"Pool"}}`,
+		"T1/A2":     `{"class":"Application"}`,
+		"T2":        `{"class":"Tenant"}`,
+		"T2/Shared": `{"class":"Application"}`,
+	}, decls)
+
+	perApp := `{"schemaVersion":"3.50.0","A1":{"class":"Application"},"A2":{"class":"Application"}}`
+	decls, err = as3TenantAppDeclarations(perApp, "T9")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"T9/A1": `{"class":"Application"}`,
+		"T9/A2": `{"class":"Application"}`,
+	}, decls)
+
+	decls, err = as3TenantAppDeclarations("", "")
+	assert.NoError(t, err)
+	assert.Empty(t, decls)
+}
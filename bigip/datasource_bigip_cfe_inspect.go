@@ -0,0 +1,72 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigipCfeInspect() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipCfeInspectRead,
+		Schema: map[string]*schema.Schema{
+			"instance": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Cloud instance id of the BIG-IP",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hostname of the BIG-IP",
+			},
+			"device_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Failover status of the BIG-IP, active or standby",
+			},
+			"traffic_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Traffic groups active on the BIG-IP",
+			},
+			"inspect_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full inspect response, including the addresses and routes the BIG-IP manages, as a JSON string",
+			},
+		},
+	}
+}
+
+func dataSourceBigipCfeInspectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading Cloud Failover inspect")
+	inspect, raw, err := client.InspectCFE()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving Cloud Failover inspect: %v", err))
+	}
+	var trafficGroups []string
+	for _, tg := range inspect.TrafficGroup {
+		if name, ok := tg["name"].(string); ok {
+			trafficGroups = append(trafficGroups, name)
+		}
+	}
+	_ = d.Set("instance", inspect.Instance)
+	_ = d.Set("hostname", inspect.HostName)
+	_ = d.Set("device_status", inspect.DeviceStatus)
+	_ = d.Set("traffic_groups", trafficGroups)
+	_ = d.Set("inspect_json", string(raw))
+	d.SetId(cfeResourceID)
+	return nil
+}
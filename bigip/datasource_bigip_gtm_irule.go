@@ -0,0 +1,60 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigipGtmIRule() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipGtmIRuleRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the GTM iRule",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Partition of the GTM iRule",
+			},
+			"irule": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The iRule body",
+			},
+		},
+	}
+}
+
+func dataSourceBigipGtmIRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+	fullPath := fmt.Sprintf("/%s/%s", partition, name)
+
+	log.Printf("[DEBUG] Reading GTM iRule data source: %s", fullPath)
+
+	rule, err := client.GetGTMIRule(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving GTM iRule %s: %v", fullPath, err))
+	}
+	if rule == nil {
+		return diag.FromErr(fmt.Errorf("GTM iRule %s not found", fullPath))
+	}
+
+	d.SetId(fullPath)
+	d.Set("name", rule.Name)
+	d.Set("partition", rule.Partition)
+	d.Set("irule", strings.TrimSpace(rule.Rule))
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigipGtmServerVirtualServers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipGtmServerVirtualServersRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the GTM server",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				Description: "Partition of the GTM server",
+			},
+			"virtual_servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Virtual servers configured on or discovered for the GTM server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the virtual server",
+						},
+						"destination": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Destination IP address and port",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the virtual server is enabled",
+						},
+						"translation_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Translation IP address for NAT",
+						},
+						"translation_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Translation port for NAT",
+						},
+						"monitor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health monitor for this virtual server",
+						},
+						"availability_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Availability of the virtual server, e.g. available, offline or unknown",
+						},
+						"enabled_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Enabled state reported by the system",
+						},
+						"status_reason": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Reason given for the availability state",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBigipGtmServerVirtualServersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+	fullPath := fmt.Sprintf("/%s/%s", partition, name)
+
+	log.Printf("[DEBUG] Reading GTM Server virtual servers data source: %s", fullPath)
+
+	vsList, err := client.GetGTMServerVirtualServers(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving virtual servers of GTM Server %s: %v", fullPath, err))
+	}
+
+	status, err := client.GetGTMServerVirtualServerStatus(fullPath)
+	if err != nil {
+		log.Printf("[WARN] Unable to retrieve availability of virtual servers on GTM Server %s: %v", fullPath, err)
+		status = map[string]bigip.GTMServerVirtualServerStatus{}
+	}
+
+	virtualServers := make([]interface{}, len(vsList))
+	for i, vs := range vsList {
+		translationAddr := vs.TranslationAddress
+		if translationAddr == "none" {
+			translationAddr = ""
+		}
+		vsStatus := status[vs.Name]
+		virtualServers[i] = map[string]interface{}{
+			"name":                vs.Name,
+			"destination":         vs.Destination,
+			"enabled":             !vs.Disabled,
+			"translation_address": translationAddr,
+			"translation_port":    vs.TranslationPort,
+			"monitor":             vs.Monitor,
+			"availability_state":  vsStatus.AvailabilityState,
+			"enabled_state":       vsStatus.EnabledState,
+			"status_reason":       vsStatus.StatusReason,
+		}
+	}
+
+	d.SetId(fullPath)
+	d.Set("virtual_servers", virtualServers)
+
+	return nil
+}
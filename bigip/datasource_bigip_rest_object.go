@@ -0,0 +1,63 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBigipRestObject() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipRestObjectRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(restObjectPath, "must be an iControl REST path, e.g. /mgmt/tm/ltm/profile/http2"),
+				Description:  "iControl REST path of the object, or of its collection when name is set",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the object in the collection at path, e.g. /Common/http2",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON returned by the BIG-IP",
+			},
+		},
+	}
+}
+
+func dataSourceBigipRestObjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	id := d.Get("path").(string)
+	if name := d.Get("name").(string); name != "" {
+		id = restObjectID(id, name)
+	}
+	log.Printf("[DEBUG] Reading REST object data source: %s", id)
+	resp, err := client.RestCall("GET", id, nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving REST object %s: %v", id, err))
+	}
+	var response interface{}
+	if err := json.Unmarshal(resp, &response); err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving REST object %s: %v", id, err))
+	}
+	data, _ := json.Marshal(response)
+	d.SetId(id)
+	_ = d.Set("response", string(data))
+	return nil
+}
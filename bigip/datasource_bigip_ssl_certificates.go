@@ -0,0 +1,237 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sslCertificatesNow is the time days_to_expiry is computed from, tests override it.
+var sslCertificatesNow = time.Now
+
+func dataSourceBigipSslCertificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipSslCertificatesRead,
+		Schema: map[string]*schema.Schema{
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the certificates of this partition, by default the certificates of every partition are listed",
+			},
+			"expires_within_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Only list the certificates that expire within this many days, including the ones that have expired",
+			},
+			"certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Certificates sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Full path of the certificate, e.g. /Common/www.example.com.crt",
+						},
+						"partition": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Partition of the certificate",
+						},
+						"subject": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subject of the certificate",
+						},
+						"subject_alternative_names": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Subject alternative names of the certificate, e.g. DNS:www.example.com",
+						},
+						"issuer": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Issuer of the certificate",
+						},
+						"serial_number": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Serial number of the certificate",
+						},
+						"expiration": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expiry of the certificate in RFC 3339 format",
+						},
+						"days_to_expiry": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Whole days until the certificate expires, negative once it has expired",
+						},
+						"expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the certificate has expired",
+						},
+						"key_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the key of the certificate, e.g. rsa-public or ec-public",
+						},
+						"key_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Size of the key of the certificate in bits",
+						},
+						"curve_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Curve of an EC key, e.g. prime256v1",
+						},
+						"fingerprint": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Fingerprint of the certificate, e.g. SHA256/AB:CD:...",
+						},
+						"is_bundle": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the file holds a bundle of certificates",
+						},
+						"client_ssl_profiles": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Client SSL profiles using the certificate as certificate, chain or CA",
+						},
+						"server_ssl_profiles": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Server SSL profiles using the certificate as certificate, chain or CA",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBigipSslCertificatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	partition := d.Get("partition").(string)
+	log.Printf("[INFO] Reading SSL certificates of partition %q", partition)
+	certs, err := client.Certificates()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving SSL certificates: %v", err))
+	}
+	clientProfiles, serverProfiles, err := sslCertificateProfiles(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	withinDays, filterExpiry := d.GetOk("expires_within_days")
+	now := sslCertificatesNow()
+	var list []map[string]interface{}
+	for _, cert := range certs.Certificates {
+		if partition != "" && cert.Partition != partition {
+			continue
+		}
+		expiration := time.Unix(cert.ExpirationDate, 0).UTC()
+		days := int(math.Floor(expiration.Sub(now).Hours() / 24))
+		if filterExpiry && days > withinDays.(int) {
+			continue
+		}
+		list = append(list, map[string]interface{}{
+			"name":                      cert.FullPath,
+			"partition":                 cert.Partition,
+			"subject":                   cert.Subject,
+			"subject_alternative_names": splitSubjectAlternativeNames(cert.SubjectAlternativeName),
+			"issuer":                    cert.Issuer,
+			"serial_number":             cert.SerialNumber,
+			"expiration":                expiration.Format(time.RFC3339),
+			"days_to_expiry":            days,
+			"expired":                   !expiration.After(now),
+			"key_type":                  cert.KeyType,
+			"key_size":                  cert.CertificateKeySize,
+			"curve_name":                cert.CertificateKeyCurveName,
+			"fingerprint":               cert.Fingerprint,
+			"is_bundle":                 cert.IsBundle == "true",
+			"client_ssl_profiles":       clientProfiles[cert.FullPath],
+			"server_ssl_profiles":       serverProfiles[cert.FullPath],
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i]["name"].(string) < list[j]["name"].(string) })
+	if err := d.Set("certificates", list); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s/%d", client.Host, partition, d.Get("expires_within_days").(int)))
+	return nil
+}
+
+// sslCertificateProfiles returns the client and server SSL profiles that use
+// each certificate, keyed by the full path of the certificate.
+func sslCertificateProfiles(client *bigip.BigIP) (map[string][]string, map[string][]string, error) {
+	clientProfiles := make(map[string][]string)
+	serverProfiles := make(map[string][]string)
+	add := func(profiles map[string][]string, profile string, certs ...string) {
+		seen := make(map[string]bool)
+		for _, cert := range certs {
+			if cert == "" || cert == "none" || seen[cert] {
+				continue
+			}
+			seen[cert] = true
+			profiles[cert] = append(profiles[cert], profile)
+		}
+	}
+
+	clientSsl, err := client.ClientSSLProfiles()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving client SSL profiles: %v", err)
+	}
+	for _, p := range clientSsl.ClientSSLProfiles {
+		certs := []string{p.Cert, p.Chain, p.CaFile, p.ClientCertCa}
+		for _, ckc := range p.CertKeyChain {
+			certs = append(certs, ckc.Cert, ckc.Chain)
+		}
+		add(clientProfiles, p.FullPath, certs...)
+	}
+
+	serverSsl, err := client.ServerSSLProfiles()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving server SSL profiles: %v", err)
+	}
+	for _, p := range serverSsl.ServerSSLProfiles {
+		add(serverProfiles, p.FullPath, p.Cert, p.Chain, p.CaFile)
+	}
+	return clientProfiles, serverProfiles, nil
+}
+
+// splitSubjectAlternativeNames splits the subject alternative names as the
+// BIG-IP reports them, e.g. "DNS:www.example.com, IP Address:192.0.2.1".
+func splitSubjectAlternativeNames(names string) []string {
+	var list []string
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			list = append(list, name)
+		}
+	}
+	return list
+}
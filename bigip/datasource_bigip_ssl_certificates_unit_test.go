@@ -0,0 +1,112 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for the bigip_ssl_certificates data source against a fake BIG-IP - no F5 BIG-IP connection required
+
+var testSslCertificatesNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newFakeSslCertificatesServer(t *testing.T) *httptest.Server {
+	day := int64(24 * 60 * 60)
+	now := testSslCertificatesNow.Unix()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/sys/file/ssl-cert", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"items":[
+			{"name":"www.example.com.crt","partition":"Common","fullPath":"/Common/www.example.com.crt","subject":"CN=www.example.com","subjectAlternativeName":"DNS:www.example.com, DNS:example.com","issuer":"CN=Example CA","serialNumber":"01","expirationDate":%d,"keyType":"rsa-public","certificateKeySize":2048,"fingerprint":"SHA256/AA:BB","isBundle":"false"},
+			{"name":"ca-bundle.crt","partition":"Common","fullPath":"/Common/ca-bundle.crt","subject":"CN=Root","issuer":"CN=Root","expirationDate":%d,"keyType":"rsa-public","certificateKeySize":4096,"isBundle":"true"},
+			{"name":"api.crt","partition":"app","fullPath":"/app/api.crt","subject":"CN=api.example.com","subjectAlternativeName":"DNS:api.example.com, IP Address:192.0.2.10","issuer":"CN=Example CA","expirationDate":%d,"keyType":"ec-public","certificateKeySize":256,"certificateKeyCurveName":"prime256v1"}
+		]}`, now+20*day+3600, now+3650*day, now-2*day)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/client-ssl", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"items":[
+			{"name":"www","fullPath":"/Common/www","cert":"/Common/www.example.com.crt","chain":"/Common/ca-bundle.crt","certKeyChain":[{"name":"default","cert":"/Common/www.example.com.crt","key":"/Common/www.example.com.key","chain":"/Common/ca-bundle.crt"}]},
+			{"name":"api","fullPath":"/app/api","cert":"/app/api.crt","chain":"none"}
+		]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/server-ssl", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"items":[{"name":"backend","fullPath":"/Common/backend","caFile":"/Common/ca-bundle.crt"}]}`)
+	})
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func withSslCertificatesNow(t *testing.T) {
+	saved := sslCertificatesNow
+	sslCertificatesNow = func() time.Time { return testSslCertificatesNow }
+	t.Cleanup(func() { sslCertificatesNow = saved })
+}
+
+func TestDataSourceBigipSslCertificatesInventory(t *testing.T) {
+	withSslCertificatesNow(t)
+	client := testFakeBigipClient(newFakeSslCertificatesServer(t))
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipSslCertificates().Schema, map[string]interface{}{})
+	if diags := dataSourceBigipSslCertificatesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, 3, d.Get("certificates.#"))
+	assert.Equal(t, "/Common/ca-bundle.crt", d.Get("certificates.0.name"), "sorted by name")
+	assert.Equal(t, true, d.Get("certificates.0.is_bundle"))
+	assert.Equal(t, []interface{}{"/Common/www"}, d.Get("certificates.0.client_ssl_profiles"), "a profile is listed once")
+	assert.Equal(t, []interface{}{"/Common/backend"}, d.Get("certificates.0.server_ssl_profiles"))
+
+	www := "certificates.1."
+	assert.Equal(t, "/Common/www.example.com.crt", d.Get(www+"name"))
+	assert.Equal(t, []interface{}{"DNS:www.example.com", "DNS:example.com"}, d.Get(www+"subject_alternative_names"))
+	assert.Equal(t, 20, d.Get(www+"days_to_expiry"))
+	assert.Equal(t, false, d.Get(www+"expired"))
+	assert.Equal(t, "2026-01-21T13:00:00Z", d.Get(www+"expiration"))
+	assert.Equal(t, "SHA256/AA:BB", d.Get(www+"fingerprint"))
+	assert.Equal(t, 2048, d.Get(www+"key_size"))
+
+	api := "certificates.2."
+	assert.Equal(t, "/app/api.crt", d.Get(api+"name"))
+	assert.Equal(t, -2, d.Get(api+"days_to_expiry"))
+	assert.Equal(t, true, d.Get(api+"expired"))
+	assert.Equal(t, "prime256v1", d.Get(api+"curve_name"))
+	assert.Equal(t, []interface{}{"/app/api"}, d.Get(api+"client_ssl_profiles"))
+}
+
+func TestDataSourceBigipSslCertificatesFilters(t *testing.T) {
+	withSslCertificatesNow(t)
+	client := testFakeBigipClient(newFakeSslCertificatesServer(t))
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipSslCertificates().Schema, map[string]interface{}{
+		"expires_within_days": 30,
+	})
+	if diags := dataSourceBigipSslCertificatesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, 2, d.Get("certificates.#"), "expired certificates are included")
+	assert.Equal(t, "/Common/www.example.com.crt", d.Get("certificates.0.name"))
+	assert.Equal(t, "/app/api.crt", d.Get("certificates.1.name"))
+
+	d = schema.TestResourceDataRaw(t, dataSourceBigipSslCertificates().Schema, map[string]interface{}{
+		"partition":           "Common",
+		"expires_within_days": 30,
+	})
+	if diags := dataSourceBigipSslCertificatesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, 1, d.Get("certificates.#"))
+	assert.Equal(t, "/Common/www.example.com.crt", d.Get("certificates.0.name"))
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigipSysUcsArchives() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipSysUcsArchivesRead,
+		Schema: map[string]*schema.Schema{
+			"archives": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "UCS archives stored in /var/local/ucs",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "File name of the UCS archive",
+						},
+						"file_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Size of the UCS archive in bytes",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date the UCS archive was created",
+						},
+						"encrypted": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the UCS archive is encrypted",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "BIG-IP version the UCS archive was taken on",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hostname of the BIG-IP the UCS archive was taken on",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBigipSysUcsArchivesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading UCS archives")
+	archives, err := client.UCSes()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving UCS archives: %v", err))
+	}
+	var list []map[string]interface{}
+	for _, ucs := range archives {
+		list = append(list, map[string]interface{}{
+			"name":      ucs.Name,
+			"file_size": ucs.FileSize,
+			"created":   ucs.Created,
+			"encrypted": ucs.Encrypted,
+			"version":   ucs.Version,
+			"hostname":  ucs.Hostname,
+		})
+	}
+	if err := d.Set("archives", list); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(client.Host)
+	return nil
+}
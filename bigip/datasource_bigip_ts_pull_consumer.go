@@ -0,0 +1,57 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigipTsPullConsumer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipTsPullConsumerRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Telemetry_Pull_Consumer in the Telemetry Streaming declaration",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Telemetry_Namespace the pull consumer is declared in",
+			},
+			"output": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Output of the pull consumer, JSON for default consumers and the Prometheus exposition format for Prometheus consumers",
+			},
+		},
+	}
+}
+
+func dataSourceBigipTsPullConsumerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	namespace := d.Get("namespace").(string)
+	log.Printf("[INFO] Reading Telemetry Streaming pull consumer %s", name)
+	output, err := client.GetTelemetryPullConsumer(name, namespace)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving Telemetry Streaming pull consumer %s: %v", name, err))
+	}
+	_ = d.Set("output", string(output))
+	if namespace != "" {
+		d.SetId(namespace + "/" + name)
+	} else {
+		d.SetId(name)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/F5Networks/terraform-provider-bigip/schemas"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// maxSchemaErrors caps the number of schema violations reported for one declaration.
+const maxSchemaErrors = 10
+
+// maxSchemaErrorLength truncates long messages, such as enum violations listing every allowed value.
+const maxSchemaErrorLength = 200
+
+var (
+	doSchemaVersionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+	doSchemaCache        sync.Map // schema version -> *jsonschema.Schema
+)
+
+// atcSchemaFormats are the custom formats used by the bundled DO and AS3 schemas.
+var atcSchemaFormats = map[string]func(interface{}) bool{
+	"f5ip": func(v interface{}) bool {
+		s, ok := v.(string)
+		if !ok {
+			return true
+		}
+		return isF5IP(s)
+	},
+	"ipWithCidr": func(v interface{}) bool {
+		s, ok := v.(string)
+		if !ok {
+			return true
+		}
+		return isF5IP(s)
+	},
+	"f5base64": func(v interface{}) bool {
+		s, ok := v.(string)
+		if !ok {
+			return true
+		}
+		_, err := base64.StdEncoding.DecodeString(s)
+		return err == nil
+	},
+}
+
+// isF5IP accepts an IPv4 or IPv6 address with an optional %route-domain and /prefix.
+func isF5IP(s string) bool {
+	if i := strings.Index(s, "/"); i >= 0 {
+		prefix, err := strconv.Atoi(s[i+1:])
+		if err != nil || prefix < 0 || prefix > 128 {
+			return false
+		}
+		s = s[:i]
+	}
+	if i := strings.Index(s, "%"); i >= 0 {
+		if _, err := strconv.Atoi(s[i+1:]); err != nil {
+			return false
+		}
+		s = s[:i]
+	}
+	return net.ParseIP(s) != nil
+}
+
+// compareSchemaVersions compares two x.y.z versions and returns -1, 0 or 1.
+func compareSchemaVersions(a, b string) int {
+	pa := strings.Split(a, ".")
+	pb := strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// bundledDoSchema returns the bundled DO schema as a generic JSON document.
+func bundledDoSchema() (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(schemas.DO, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse bundled DO schema: %v", err)
+	}
+	return doc, nil
+}
+
+// bundledDoSchemaVersions lists the schemaVersion values the bundled DO schema accepts, newest first.
+func bundledDoSchemaVersions() ([]string, error) {
+	doc, err := bundledDoSchema()
+	if err != nil {
+		return nil, err
+	}
+	props, _ := doc["properties"].(map[string]interface{})
+	sv, _ := props["schemaVersion"].(map[string]interface{})
+	enum, _ := sv["enum"].([]interface{})
+	versions := make([]string, 0, len(enum))
+	for _, v := range enum {
+		if s, ok := v.(string); ok {
+			versions = append(versions, s)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, errors.New("bundled DO schema does not list any schemaVersion values")
+	}
+	sort.Slice(versions, func(i, j int) bool { return compareSchemaVersions(versions[i], versions[j]) > 0 })
+	return versions, nil
+}
+
+// compileDoSchema compiles the bundled DO schema restricted to the
+// schemaVersion values supported by the given DO version.
+func compileDoSchema(version string) (*jsonschema.Schema, error) {
+	if s, ok := doSchemaCache.Load(version); ok {
+		return s.(*jsonschema.Schema), nil
+	}
+	doc, err := bundledDoSchema()
+	if err != nil {
+		return nil, err
+	}
+	versions, err := bundledDoSchemaVersions()
+	if err != nil {
+		return nil, err
+	}
+	var allowed []interface{}
+	for _, v := range versions {
+		if compareSchemaVersions(v, version) <= 0 {
+			allowed = append(allowed, v)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("DO schema version %s is older than any version in the bundled schema (oldest %s)", version, versions[len(versions)-1])
+	}
+	doc["properties"].(map[string]interface{})["schemaVersion"].(map[string]interface{})["enum"] = allowed
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	url, _ := doc["$id"].(string)
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	for name, f := range atcSchemaFormats {
+		compiler.Formats[name] = f
+	}
+	if err := compiler.AddResource(url, bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("unable to load bundled DO schema: %v", err)
+	}
+	s, err := compiler.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile bundled DO schema: %v", err)
+	}
+	doSchemaCache.Store(version, s)
+	return s, nil
+}
+
+// validateDoDeclaration validates doJson against the bundled DO schema.
+// schemaVersion is the DO version installed on the target; when empty the
+// declaration's own schemaVersion is used. Declarations for DO versions newer
+// than the bundled schema are not validated.
+func validateDoDeclaration(doJson, schemaVersion string) error {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(doJson), &doc); err != nil {
+		return fmt.Errorf("do_json is not valid JSON: %v", err)
+	}
+	declaration, prefix := doDeclarationBody(doc)
+
+	version := schemaVersion
+	if version == "" {
+		if m, ok := declaration.(map[string]interface{}); ok {
+			version, _ = m["schemaVersion"].(string)
+		}
+	}
+	versions, err := bundledDoSchemaVersions()
+	if err != nil {
+		return err
+	}
+	if version == "" || !doSchemaVersionRegex.MatchString(version) {
+		version = versions[0]
+	}
+	if compareSchemaVersions(version, versions[0]) > 0 {
+		log.Printf("[WARN] DO schema version %s is newer than the bundled schema (%s), skipping do_json validation", version, versions[0])
+		return nil
+	}
+
+	s, err := compileDoSchema(version)
+	if err != nil {
+		return err
+	}
+	if err := s.Validate(declaration); err != nil {
+		var verr *jsonschema.ValidationError
+		if !errors.As(err, &verr) {
+			return err
+		}
+		return fmt.Errorf("do_json does not match the DO %s schema:\n%s", version, strings.Join(schemaErrorLines(verr, prefix), "\n"))
+	}
+	return nil
+}
+
+// doDeclarationBody returns the Device declaration, unwrapping the remote
+// "class": "DO" envelope, along with the JSON pointer prefix of the body.
+func doDeclarationBody(doc interface{}) (interface{}, string) {
+	m, ok := doc.(map[string]interface{})
+	if !ok || m["class"] != "DO" {
+		return doc, ""
+	}
+	if body, ok := m["declaration"]; ok {
+		return body, "/declaration"
+	}
+	return doc, ""
+}
+
+// schemaErrorLines flattens a validation error into "pointer: message" lines,
+// one per failing leaf.
+func schemaErrorLines(verr *jsonschema.ValidationError, prefix string) []string {
+	seen := make(map[string]bool)
+	var leaves []string
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, c := range e.Causes {
+				walk(c)
+			}
+			return
+		}
+		pointer := prefix + e.InstanceLocation
+		if pointer == "" {
+			pointer = "/"
+		}
+		msg := e.Message
+		if len(msg) > maxSchemaErrorLength {
+			msg = msg[:maxSchemaErrorLength] + "..."
+		}
+		line := fmt.Sprintf("  %s: %s", pointer, msg)
+		if !seen[line] {
+			seen[line] = true
+			leaves = append(leaves, line)
+		}
+	}
+	walk(verr)
+	sort.Strings(leaves)
+	if len(leaves) > maxSchemaErrors {
+		more := len(leaves) - maxSchemaErrors
+		leaves = append(leaves[:maxSchemaErrors], fmt.Sprintf("  ... and %d more", more))
+	}
+	return leaves
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for offline DO schema validation - no F5 BIG-IP connection required
+
+func TestCompareSchemaVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.12.0", "1.12.0", 0},
+		{"1.9.0", "1.12.0", -1},
+		{"1.12.0", "1.9.0", 1},
+		{"1.11.1", "1.11.0", 1},
+		{"2.0", "1.40.1", 1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, compareSchemaVersions(c.a, c.b), "%s vs %s", c.a, c.b)
+	}
+}
+
+func TestBundledDoSchemaVersions(t *testing.T) {
+	versions, err := bundledDoSchemaVersions()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.12.0", versions[0])
+	assert.Equal(t, "1.0.0", versions[len(versions)-1])
+}
+
+func TestValidateDoDeclaration(t *testing.T) {
+	cases := []struct {
+		name          string
+		doJson        string
+		schemaVersion string
+		wantErr       []string
+	}{
+		{
+			name:   "valid",
+			doJson: `{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant","hostname":"bigip1.example.com"}}`,
+		},
+		{
+			name:    "invalid json",
+			doJson:  `{"schemaVersion":`,
+			wantErr: []string{"not valid JSON"},
+		},
+		{
+			name:    "missing class",
+			doJson:  `{"schemaVersion":"1.5.0"}`,
+			wantErr: []string{"  /: ", "class"},
+		},
+		{
+			name:    "bad vlan tag points at the property",
+			doJson:  `{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant","external":{"class":"VLAN","tag":5000,"interfaces":[{"name":"1.1"}]}}}`,
+			wantErr: []string{"/Common/external/tag"},
+		},
+		{
+			name:    "bad self ip address uses the f5ip format",
+			doJson:  `{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant","self":{"class":"SelfIp","address":"10.1.1.300/24","vlan":"external"}}}`,
+			wantErr: []string{"/Common/self/address"},
+		},
+		{
+			name:   "route domain and prefix on self ip",
+			doJson: `{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant","self":{"class":"SelfIp","address":"10.1.1.10%2/24","vlan":"external"}}}`,
+		},
+		{
+			name:    "remote envelope prefixes the pointer",
+			doJson:  `{"class":"DO","declaration":{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant","external":{"class":"VLAN","tag":5000,"interfaces":[{"name":"1.1"}]}}}}`,
+			wantErr: []string{"/declaration/Common/external/tag"},
+		},
+		{
+			name:          "declaration newer than the installed DO",
+			doJson:        `{"schemaVersion":"1.12.0","class":"Device","Common":{"class":"Tenant"}}`,
+			schemaVersion: "1.9.0",
+			wantErr:       []string{"DO 1.9.0 schema", "/schemaVersion"},
+		},
+		{
+			name:          "declaration older than the installed DO",
+			doJson:        `{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant"}}`,
+			schemaVersion: "1.9.0",
+		},
+		{
+			name:   "newer than the bundled schema is not validated",
+			doJson: `{"schemaVersion":"1.40.0","class":"Device","Common":{"class":"Tenant","newThing":{"class":"Unknown"}}}`,
+		},
+		{
+			name:          "installed DO newer than the bundled schema is not validated",
+			doJson:        `{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant","newThing":{"class":"Unknown"}}}`,
+			schemaVersion: "1.40.0",
+		},
+		{
+			name:          "installed DO older than the bundled schema",
+			doJson:        `{"schemaVersion":"1.0.0","class":"Device"}`,
+			schemaVersion: "0.9.0",
+			wantErr:       []string{"older than any version"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateDoDeclaration(c.doJson, c.schemaVersion)
+			if len(c.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				for _, want := range c.wantErr {
+					assert.Contains(t, err.Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDoDeclarationExample(t *testing.T) {
+	raw, err := os.ReadFile("../examples/do/example1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fill in the placeholders the example ships with
+	doJson := strings.NewReplacer(`"xxxxx"`, `"AAAAA-BBBBB-CCCCC-DDDDD-EEEEEEE"`, `"xxxx"`, `"10.1.1.10/24"`).Replace(string(raw))
+	assert.NoError(t, validateDoDeclaration(doJson, ""))
+}
+
+func TestValidateDoDeclarationCapsErrors(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`{"schemaVersion":"1.5.0","class":"Device","Common":{"class":"Tenant"`)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		b.WriteString(`,"self_` + name + `":{"class":"SelfIp","address":"bad","vlan":"external"}`)
+	}
+	b.WriteString(`}}`)
+	err := validateDoDeclaration(b.String(), "")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "more")
+		assert.LessOrEqual(t, strings.Count(err.Error(), "\n"), maxSchemaErrors+1)
+	}
+}
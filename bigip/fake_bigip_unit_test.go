@@ -0,0 +1,132 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Helpers shared by the unit tests running against a fake BIG-IP
+
+// fakeBigipNotFound answers like the BIG-IP for a missing object.
+func fakeBigipNotFound(w http.ResponseWriter, name string) {
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested object (%s) was not found."}`, name)
+}
+
+// testShellWords splits a bash command line into its words, the way the
+// BIG-IP util bash reads the commands quoted by go-bigip.
+func testShellWords(line string) []string {
+	var words []string
+	var word strings.Builder
+	inWord, quoted := false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted && c == '\'':
+			quoted = false
+		case quoted:
+			word.WriteByte(c)
+		case c == '\'':
+			quoted, inWord = true, true
+		case c == '\\' && i+1 < len(line):
+			i++
+			word.WriteByte(line[i])
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// testBashScript returns the words of the script run by util bash arguments
+// of the form -c 'script'.
+func testBashScript(utilCmdArgs string) []string {
+	args := testShellWords(utilCmdArgs)
+	if len(args) != 2 || args[0] != "-c" {
+		return nil
+	}
+	return testShellWords(args[1])
+}
+
+// testFakeBigipClient returns a provider client for a fake BIG-IP started with
+// httptest.NewTLSServer, trusting its certificate and not sending telemetry.
+func testFakeBigipClient(fake *httptest.Server) *bigip.BigIP {
+	client := bigip.NewSession(&bigip.Config{
+		Address:  fake.URL,
+		Username: "admin",
+		Password: "secret",
+		ConfigOptions: &bigip.ConfigOptions{
+			APICallTimeout: 5 * time.Second,
+			APICallRetries: 1,
+		},
+	})
+	client.Teem = true
+	pool := x509.NewCertPool()
+	pool.AddCert(fake.Certificate())
+	client.Transport.TLSClientConfig.RootCAs = pool
+	return client
+}
+
+// withFastPolling shortens the poll interval of a resource for the test.
+func withFastPolling(t *testing.T, interval *time.Duration) {
+	saved := *interval
+	*interval = 10 * time.Millisecond
+	t.Cleanup(func() { *interval = saved })
+}
+
+// testResourceApply plans config against state the way Terraform does and runs
+// create or update, it returns the new resource data.
+func testResourceApply(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}, client *bigip.BigIP) *schema.ResourceData {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != nil {
+		// the resources reading the raw configuration see the attributes left out as null
+		raw, _ := json.Marshal(config)
+		diff.RawConfig, err = ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	apply := r.CreateContext
+	if state != nil {
+		apply = schema.CreateContextFunc(r.UpdateContext)
+	}
+	if diags := apply(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	return d
+}
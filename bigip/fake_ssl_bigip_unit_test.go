@@ -0,0 +1,200 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSslBigipServer keeps keys, CSRs, data groups, iRules, virtual server
+// iRules, uploads and certificates by tilde path, e.g. "~Common~www", for the
+// bigip_ssl_acme_certificate, bigip_ssl_csr and bigip_ssl_crl unit tests.
+type fakeSslBigipServer struct {
+	*httptest.Server
+	mux        *http.ServeMux
+	mu         sync.Mutex
+	keys       map[string]*ecdsa.PrivateKey
+	keyConfigs map[string]map[string]interface{}
+	csrs       map[string]string
+	dataGroups map[string]map[string]string
+	rules      map[string]string
+	vsRules    map[string][]string
+	uploads    map[string][]byte
+	certs      map[string][]byte
+	requests   []string
+}
+
+func newFakeSslBigipServer(t *testing.T) *fakeSslBigipServer {
+	f := &fakeSslBigipServer{
+		keys:       make(map[string]*ecdsa.PrivateKey),
+		keyConfigs: make(map[string]map[string]interface{}),
+		csrs:       make(map[string]string),
+		dataGroups: make(map[string]map[string]string),
+		rules:      make(map[string]string),
+		vsRules:    map[string][]string{"~Common~www_http": {"/Common/redirect"}},
+		uploads:    make(map[string][]byte),
+		certs:      make(map[string][]byte),
+		mux:        http.NewServeMux(),
+	}
+	tilde := func(name string) string { return strings.ReplaceAll(name, "/", "~") }
+	f.handle("/mgmt/tm/sys/crypto/key", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		name := tilde(body["name"].(string))
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		f.keys[name] = key
+		f.keyConfigs[name] = body
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	f.handle("/mgmt/tm/sys/crypto/csr", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		key, ok := f.keys[tilde(body["key"].(string))]
+		if !ok {
+			fakeBigipNotFound(w, body["key"].(string))
+			return
+		}
+		template := &x509.CertificateRequest{Subject: pkix.Name{CommonName: body["commonName"].(string)}}
+		if org, ok := body["organization"].(string); ok {
+			template.Subject.Organization = []string{org}
+		}
+		if country, ok := body["country"].(string); ok {
+			template.Subject.Country = []string{country}
+		}
+		if sans, ok := body["subjectAlternativeName"].(string); ok {
+			for _, san := range strings.Split(sans, ",") {
+				template.DNSNames = append(template.DNSNames, strings.TrimPrefix(strings.TrimSpace(san), "DNS:"))
+			}
+		}
+		der, _ := x509.CreateCertificateRequest(rand.Reader, template, key)
+		f.csrs[tilde(body["name"].(string))] = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	f.handle("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		args := body["utilCmdArgs"].(string)
+		script := testBashScript(args)
+		name := script[len(script)-1]
+		result := fmt.Sprintf("sys crypto csr %s {\n%s}\n", name, f.csrs[tilde(name)])
+		_ = json.NewEncoder(w).Encode(map[string]string{"command": "run", "utilCmdArgs": args, "commandResult": result})
+	})
+	f.handle("/mgmt/tm/sys/file/ssl-csr/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/file/ssl-csr/")
+		if _, ok := f.csrs[name]; !ok {
+			fakeBigipNotFound(w, name)
+			return
+		}
+		if r.Method == "DELETE" {
+			delete(f.csrs, name)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"name": name})
+	})
+	f.handle("/mgmt/tm/sys/file/ssl-key/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/file/ssl-key/")
+		if _, ok := f.keys[name]; !ok {
+			fakeBigipNotFound(w, name)
+			return
+		}
+		if r.Method == "DELETE" {
+			delete(f.keys, name)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"name": name})
+	})
+	f.handle("/mgmt/tm/ltm/data-group/internal", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		records := make(map[string]string)
+		for _, record := range body["records"].([]interface{}) {
+			record := record.(map[string]interface{})
+			records[record["name"].(string)] = record["data"].(string)
+		}
+		f.dataGroups[tilde(body["name"].(string))] = records
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	f.handle("/mgmt/tm/ltm/data-group/internal/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		delete(f.dataGroups, strings.TrimPrefix(r.URL.Path, "/mgmt/tm/ltm/data-group/internal/"))
+	})
+	f.handle("/mgmt/tm/ltm/rule", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		f.rules[tilde(body["name"].(string))] = body["apiAnonymous"].(string)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	f.handle("/mgmt/tm/ltm/rule/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		delete(f.rules, strings.TrimPrefix(r.URL.Path, "/mgmt/tm/ltm/rule/"))
+	})
+	f.handle("/mgmt/tm/ltm/virtual/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/ltm/virtual/")
+		if r.Method == "PATCH" {
+			f.vsRules[name] = nil
+			for _, rule := range body["rules"].([]interface{}) {
+				f.vsRules[name] = append(f.vsRules[name], rule.(string))
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": name, "rules": f.vsRules[name]})
+	})
+	f.handle("/mgmt/shared/file-transfer/uploads/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		data, _ := io.ReadAll(r.Body)
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/shared/file-transfer/uploads/")
+		var start, end, size int
+		_, _ = fmt.Sscanf(r.Header.Get("Content-Range"), "%d-%d/%d", &start, &end, &size)
+		if start != len(f.uploads[name]) {
+			// a new upload of the file, or a chunk out of order
+			f.uploads[name] = nil
+		}
+		f.uploads[name] = append(f.uploads[name], data...)
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	install := func(name string, body map[string]interface{}) {
+		upload := strings.TrimPrefix(body["sourcePath"].(string), "file:///var/config/rest/downloads/")
+		f.certs[name] = f.uploads[upload]
+	}
+	f.handle("/mgmt/tm/sys/file/ssl-cert", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		install(fmt.Sprintf("~%s~%s", body["partition"], body["name"]), body)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	f.handle("/mgmt/tm/sys/file/ssl-cert/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/file/ssl-cert/"), "/")
+		chain, ok := f.certs[name]
+		if !ok {
+			fakeBigipNotFound(w, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			install(name, body)
+			chain = f.certs[name]
+		case "DELETE":
+			delete(f.certs, name)
+		}
+		block, _ := pem.Decode(chain)
+		cert, _ := x509.ParseCertificate(block.Bytes)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": name, "expirationDate": cert.NotAfter.Unix()})
+	})
+	f.Server = httptest.NewTLSServer(f.mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// handle registers handler for pattern, with the JSON body of the request
+// decoded, except for the file transfers.
+func (f *fakeSslBigipServer) handle(pattern string, handler func(w http.ResponseWriter, r *http.Request, body map[string]interface{})) {
+	f.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if !strings.HasPrefix(r.URL.Path, "/mgmt/shared/") {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r, body)
+	})
+}
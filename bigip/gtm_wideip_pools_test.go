@@ -75,4 +75,25 @@ func TestResourceBigipGtmWideipPoolsSchemaExists(t *testing.T) {
 	if ratioField.Default != 1 {
 		t.Errorf("Expected 'pools.ratio' default to be 1, got %v", ratioField.Default)
 	}
-}
\ No newline at end of file
+}
+
+// TestResourceBigipGtmWideipIRulesSchema verifies that iRules are attached
+// through an ordered list, since GTM evaluates them in the configured order.
+func TestResourceBigipGtmWideipIRulesSchema(t *testing.T) {
+	resource := resourceBigipGtmWideip()
+
+	irulesSchema, ok := resource.Schema["irules"]
+	if !ok {
+		t.Fatal("Expected 'irules' field to exist in schema")
+	}
+	if irulesSchema.Type != schema.TypeList {
+		t.Errorf("Expected 'irules' to be TypeList, got %v", irulesSchema.Type)
+	}
+	if !irulesSchema.Optional {
+		t.Error("Expected 'irules' to be optional")
+	}
+	elem, ok := irulesSchema.Elem.(*schema.Schema)
+	if !ok || elem.Type != schema.TypeString {
+		t.Error("Expected 'irules' elements to be TypeString")
+	}
+}
@@ -113,6 +113,10 @@ func Provider() *schema.Provider {
 			"bigip_ltm_monitor":                   dataSourceBigipLtmMonitor(),
 			"bigip_ltm_irule":                     dataSourceBigipLtmIrule(),
 			"bigip_ssl_certificate":               dataSourceBigipSslCertificate(),
+			"bigip_ssl_certificates":              dataSourceBigipSslCertificates(),
+			"bigip_ts_pull_consumer":              dataSourceBigipTsPullConsumer(),
+			"bigip_cfe_inspect":                   dataSourceBigipCfeInspect(),
+			"bigip_sys_ucs_archives":              dataSourceBigipSysUcsArchives(),
 			"bigip_ltm_pool":                      dataSourceBigipLtmPool(),
 			"bigip_ltm_policy":                    dataSourceBigipLtmPolicy(),
 			"bigip_ltm_node":                      dataSourceBigipLtmNode(),
@@ -129,10 +133,16 @@ func Provider() *schema.Provider {
 			"bigip_as3_device_information":        dataSourceBigipAs3(),
 			"bigip_gtm_datacenter":                dataSourceBigipGtmDatacenter(),
 			"bigip_gtm_server":                    dataSourceBigipGtmServer(),
+			"bigip_gtm_irule":                     dataSourceBigipGtmIRule(),
+			"bigip_gtm_server_virtual_servers":    dataSourceBigipGtmServerVirtualServers(),
+			"bigip_rest_object":                   dataSourceBigipRestObject(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"bigip_cm_device":                       resourceBigipCmDevice(),
 			"bigip_cm_devicegroup":                  resourceBigipCmDevicegroup(),
+			"bigip_cm_trust":                        resourceBigipCmTrust(),
+			"bigip_cm_traffic_group":                resourceBigipCmTrafficGroup(),
+			"bigip_cm_config_sync":                  resourceBigipCmConfigSync(),
 			"bigip_net_route":                       resourceBigipNetRoute(),
 			"bigip_net_selfip":                      resourceBigipNetSelfIP(),
 			"bigip_net_vlan":                        resourceBigipNetVlan(),
@@ -158,6 +168,7 @@ func Provider() *schema.Provider {
 			"bigip_ltm_persistence_profile_cookie":  resourceBigipLtmPersistenceProfileCookie(),
 			"bigip_ltm_profile_server_ssl":          resourceBigipLtmProfileServerSsl(),
 			"bigip_ltm_profile_client_ssl":          resourceBigipLtmProfileClientSsl(),
+			"bigip_ltm_sni_bundle":                  resourceBigipLtmSniBundle(),
 			"bigip_ltm_snat":                        resourceBigipLtmSnat(),
 			"bigip_ltm_snatpool":                    resourceBigipLtmSnatpool(),
 			"bigip_ltm_virtual_address":             resourceBigipLtmVirtualAddress(),
@@ -165,6 +176,9 @@ func Provider() *schema.Provider {
 			"bigip_ltm_ifile":                       resourceBigipLtmIfile(),
 			"bigip_sys_dns":                         resourceBigipSysDns(),
 			"bigip_sys_iapp":                        resourceBigipSysIapp(),
+			"bigip_sys_icall_script":                resourceBigipSysIcallScript(),
+			"bigip_sys_icall_handler":               resourceBigipSysIcallHandler(),
+			"bigip_sys_icall_event_trigger":         resourceBigipSysIcallEventTrigger(),
 			"bigip_sys_ntp":                         resourceBigipSysNtp(),
 			"bigip_sys_ocsp":                        resourceBigipSysOcsp(),
 			"bigip_sys_provision":                   resourceBigipSysProvision(),
@@ -172,8 +186,25 @@ func Provider() *schema.Provider {
 			"bigip_sys_snmp":                        resourceBigipSysSnmp(),
 			"bigip_sys_snmp_traps":                  resourceBigipSysSnmpTraps(),
 			"bigip_sys_bigiplicense":                resourceBigipSysBigiplicense(),
+			"bigip_sys_ucs":                         resourceBigipSysUcs(),
+			"bigip_sys_software_image":              resourceBigipSysSoftwareImage(),
+			"bigip_sys_software_install":            resourceBigipSysSoftwareInstall(),
+			"bigip_sys_db":                          resourceBigipSysDb(),
+			"bigip_sys_global_settings":             resourceBigipSysGlobalSettings(),
+			"bigip_sys_management_route":            resourceBigipSysManagementRoute(),
+			"bigip_sys_management_ip":               resourceBigipSysManagementIp(),
+			"bigip_sys_sshd":                        resourceBigipSysSshd(),
+			"bigip_sys_httpd":                       resourceBigipSysHttpd(),
+			"bigip_sys_auth_ldap":                   resourceBigipSysAuthLdap(),
+			"bigip_sys_auth_radius":                 resourceBigipSysAuthRadius(),
+			"bigip_sys_auth_tacacs":                 resourceBigipSysAuthTacacs(),
+			"bigip_sys_auth_source":                 resourceBigipSysAuthSource(),
+			"bigip_sys_auth_remote_role":            resourceBigipSysAuthRemoteRole(),
 			"bigip_as3":                             resourceBigipAs3(),
+			"bigip_atc_package":                     resourceBigipAtcPackage(),
+			"bigip_cfe":                             resourceBigipCfe(),
 			"bigip_do":                              resourceBigipDo(),
+			"bigip_ts":                              resourceBigipTs(),
 			"bigip_fast_template":                   resourceBigipFastTemplate(),
 			"bigip_fast_application":                resourceBigipFastApp(),
 			"bigip_fast_http_app":                   resourceBigipHttpFastApp(),
@@ -183,7 +214,12 @@ func Provider() *schema.Provider {
 			"bigip_ssl_certificate":                 resourceBigipSslCertificate(),
 			"bigip_ssl_key":                         resourceBigipSslKey(),
 			"bigip_ssl_key_cert":                    resourceBigipSSLKeyCert(),
+			"bigip_ssl_acme_certificate":            resourceBigipSslAcmeCertificate(),
+			"bigip_ssl_csr":                         resourceBigipSslCsr(),
+			"bigip_ssl_csr_certificate":             resourceBigipSslCsrCertificate(),
+			"bigip_ssl_crl":                         resourceBigipSslCrl(),
 			"bigip_command":                         resourceBigipCommand(),
+			"bigip_rest_object":                     resourceBigipRestObject(),
 			"bigip_common_license_manage_bigiq":     resourceBigiqLicenseManage(),
 			"bigip_bigiq_as3":                       resourceBigiqAs3(),
 			"bigip_event_service_discovery":         resourceServiceDiscovery(),
@@ -213,6 +249,22 @@ func Provider() *schema.Provider {
 			"bigip_gtm_monitor_tcp":                 resourceBigipGtmMonitorTcp(),
 			"bigip_gtm_monitor_postgresql":          resourceBigipGtmMonitorPostgresql(),
 			"bigip_gtm_monitor_bigip":               resourceBigipGtmMonitorBigip(),
+			"bigip_gtm_monitor_gateway_icmp":        resourceBigipGtmMonitorGatewayIcmp(),
+			"bigip_gtm_monitor_udp":                 resourceBigipGtmMonitorUdp(),
+			"bigip_gtm_monitor_dns":                 resourceBigipGtmMonitorDns(),
+			"bigip_gtm_monitor_ldap":                resourceBigipGtmMonitorLdap(),
+			"bigip_gtm_monitor_external":            resourceBigipGtmMonitorExternal(),
+			"bigip_gtm_irule":                       resourceBigipGtmIRule(),
+			"bigip_gtm_link":                        resourceBigipGtmLink(),
+			"bigip_gtm_prober_pool":                 resourceBigipGtmProberPool(),
+			"bigip_gtm_global_settings":             resourceBigipGtmGlobalSettings(),
+			"bigip_gtm_server_virtual_server":       resourceBigipGtmServerVirtualServer(),
+			"bigip_dns_tsig_key":                    resourceBigipDnsTsigKey(),
+			"bigip_dns_nameserver":                  resourceBigipDnsNameserver(),
+			"bigip_dns_zone":                        resourceBigipDnsZone(),
+			"bigip_dns_cache":                       resourceBigipDnsCache(),
+			"bigip_dnssec_key":                      resourceBigipDnssecKey(),
+			"bigip_dnssec_zone":                     resourceBigipDnssecZone(),
 		},
 	}
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -283,6 +335,15 @@ func makeStringSet(list *[]string) *schema.Set {
 	return schema.NewSet(schema.HashString, ilist)
 }
 
+// yesNo converts a boolean into the "yes"/"no" strings used by many tmsh
+// properties.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
 // Convert schema.TypeList to a slice of strings
 func listToStringSlice(s []interface{}) []string {
 	list := make([]string, len(s))
@@ -65,6 +65,7 @@ func resourceBigipAs3() *schema.Resource {
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+		CustomizeDiff: resourceBigipAs3CustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"as3_json": {
 				Type:          schema.TypeString,
@@ -163,6 +164,14 @@ func resourceBigipAs3() *schema.Resource {
 				Optional:    true,
 				Description: "Application deployed through AS3 Declaration",
 			},
+			"tenant_app_declarations": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "as3_json split into one JSON document per tenant and per tenant/application, so plans show which tenants and applications change",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"task_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -202,6 +211,29 @@ func resourceBigipAs3() *schema.Resource {
 	}
 }
 
+// resourceBigipAs3CustomizeDiff validates as3_json against the bundled AS3
+// schema and plans the per-tenant and per-application view of the change.
+func resourceBigipAs3CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("as3_json") {
+		return nil
+	}
+	if !d.NewValueKnown("as3_json") {
+		return d.SetNewComputed("tenant_app_declarations")
+	}
+	as3Json := d.Get("as3_json").(string)
+	if as3Json == "" {
+		return nil
+	}
+	if err := validateAs3Declaration(as3Json); err != nil {
+		return err
+	}
+	decls, err := as3TenantAppDeclarations(as3Json, d.Get("tenant_name").(string))
+	if err != nil {
+		return err
+	}
+	return d.SetNew("tenant_app_declarations", decls)
+}
+
 func validateControlsParam(val interface{}, path cty.Path) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -486,6 +518,9 @@ func resourceBigipAs3Read(ctx context.Context, d *schema.ResourceData, meta inte
 		_ = d.Set("as3_json", taskResponse)
 		_ = d.Set("tenant_list", name)
 	}
+	if decls, err := as3TenantAppDeclarations(d.Get("as3_json").(string), d.Get("tenant_name").(string)); err == nil {
+		_ = d.Set("tenant_app_declarations", decls)
+	}
 	return nil
 }
 
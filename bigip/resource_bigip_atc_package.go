@@ -0,0 +1,240 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// atcPackagePollInterval is how often package management tasks and the
+// installed service are polled.
+var atcPackagePollInterval = 3 * time.Second
+
+// rpmFileNameRegex matches <name>-<version>-<release>.<arch>.rpm, e.g. f5-appsvcs-3.50.0-5.noarch.rpm
+var rpmFileNameRegex = regexp.MustCompile(`^(.+)-([^-]+)-([^-]+)\.([^.]+)\.rpm$`)
+
+func resourceBigipAtcPackage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipAtcPackageCreate,
+		ReadContext:   resourceBigipAtcPackageRead,
+		UpdateContext: resourceBigipAtcPackageUpdate,
+		DeleteContext: resourceBigipAtcPackageDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"package_path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Path of the local RPM to install, e.g. f5-appsvcs-3.50.0-5.noarch.rpm",
+				ValidateFunc: validation.StringMatch(rpmFileNameRegex, "must be the path of an RPM named <name>-<version>-<release>.<arch>.rpm"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// imported packages have no package_path, keep them if the RPM is already installed
+					return old == "" && strings.TrimSuffix(filepath.Base(new), ".rpm") == d.Get("package_name").(string)
+				},
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				Description:  "Minutes to wait for the package to install and its service to become ready",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the installed package, e.g. f5-appsvcs",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Installed version of the package",
+			},
+			"release": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Installed release of the package",
+			},
+			"package_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full name of the installed package, e.g. f5-appsvcs-3.50.0-5.noarch",
+			},
+		},
+	}
+}
+
+// atcPackage is the name, version, release and arch parsed from an RPM file name.
+type atcPackage struct {
+	Name, Version, Release, Arch string
+}
+
+func (p atcPackage) packageName() string {
+	return fmt.Sprintf("%s-%s-%s.%s", p.Name, p.Version, p.Release, p.Arch)
+}
+
+func parseRpmFileName(path string) (atcPackage, error) {
+	m := rpmFileNameRegex.FindStringSubmatch(filepath.Base(path))
+	if m == nil {
+		return atcPackage{}, fmt.Errorf("%s is not named <name>-<version>-<release>.<arch>.rpm", filepath.Base(path))
+	}
+	return atcPackage{Name: m[1], Version: m[2], Release: m[3], Arch: m[4]}, nil
+}
+
+func resourceBigipAtcPackageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	path := d.Get("package_path").(string)
+	pkg, err := parseRpmFileName(path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Installing ATC package %s", pkg.packageName())
+	if err := installAtcPackage(client, d, path, pkg); err != nil {
+		return diag.FromErr(fmt.Errorf("error installing ATC package %s: %v", pkg.packageName(), err))
+	}
+	d.SetId(pkg.Name)
+	return resourceBigipAtcPackageRead(ctx, d, meta)
+}
+
+func resourceBigipAtcPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading ATC package %s", name)
+	installed, err := findAtcPackage(client, d, name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving ATC package %s: %v", name, err))
+	}
+	if installed == nil {
+		log.Printf("[WARN] ATC package %s not installed, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("name", installed.Name)
+	_ = d.Set("version", installed.Version)
+	_ = d.Set("release", installed.Release)
+	_ = d.Set("package_name", installed.PackageName)
+	return nil
+}
+
+func resourceBigipAtcPackageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	if d.HasChange("package_path") {
+		path := d.Get("package_path").(string)
+		pkg, err := parseRpmFileName(path)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if pkg.Name != d.Id() {
+			return diag.FromErr(fmt.Errorf("package_path %s installs %s, not %s", path, pkg.Name, d.Id()))
+		}
+		log.Printf("[INFO] Upgrading ATC package %s to %s", d.Id(), pkg.packageName())
+		if err := installAtcPackage(client, d, path, pkg); err != nil {
+			return diag.FromErr(fmt.Errorf("error upgrading ATC package %s: %v", d.Id(), err))
+		}
+	}
+	return resourceBigipAtcPackageRead(ctx, d, meta)
+}
+
+func resourceBigipAtcPackageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Uninstalling ATC package %s", name)
+	installed, err := findAtcPackage(client, d, name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving ATC package %s: %v", name, err))
+	}
+	if installed != nil {
+		if err := client.UninstallPackage(installed.PackageName, atcPackageTimeout(d), atcPackagePollInterval); err != nil {
+			return diag.FromErr(fmt.Errorf("error uninstalling ATC package %s: %v", installed.PackageName, err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func atcPackageTimeout(d *schema.ResourceData) time.Duration {
+	timeout := d.Get("timeout").(int)
+	if timeout <= 0 {
+		// not set when reading a package during import
+		timeout = 10
+	}
+	return time.Duration(timeout) * time.Minute
+}
+
+// findAtcPackage returns the installed package with the given name, or nil.
+func findAtcPackage(client *bigip.BigIP, d *schema.ResourceData, name string) (*bigip.IappPackage, error) {
+	packages, err := client.GetInstalledPackages(atcPackageTimeout(d), atcPackagePollInterval)
+	if err != nil {
+		return nil, err
+	}
+	for i := range packages {
+		if packages[i].Name == name {
+			return &packages[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// installAtcPackage installs the RPM at path over any other installed version
+// of the package, and waits for its service to become ready. The RPM is
+// uploaded before anything is changed, so a failed upload leaves the installed
+// version alone. When the new version cannot be installed over the old one
+// (e.g. a downgrade) the old version is removed first, and reinstalled from
+// its RPM on the BIG-IP should the new one still fail.
+func installAtcPackage(client *bigip.BigIP, d *schema.ResourceData, path string, pkg atcPackage) error {
+	timeout := atcPackageTimeout(d)
+	installed, err := findAtcPackage(client, d, pkg.Name)
+	if err != nil {
+		return err
+	}
+	if installed != nil && installed.PackageName == pkg.packageName() {
+		log.Printf("[INFO] ATC package %s is already installed", pkg.packageName())
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	remotePath, err := client.UploadPackage(path)
+	if err != nil {
+		return fmt.Errorf("uploading %s failed: %v", path, err)
+	}
+	if err := client.InstallPackage(remotePath, timeout, atcPackagePollInterval); err != nil {
+		if installed == nil {
+			return err
+		}
+		log.Printf("[WARN] Installing ATC package %s over %s failed, removing %s first: %v", pkg.packageName(), installed.PackageName, installed.PackageName, err)
+		if err := client.UninstallPackage(installed.PackageName, timeout, atcPackagePollInterval); err != nil {
+			return err
+		}
+		if err := client.InstallPackage(remotePath, timeout, atcPackagePollInterval); err != nil {
+			previous := strings.TrimSuffix(remotePath, filepath.Base(path)) + installed.PackageName + ".rpm"
+			log.Printf("[WARN] Installing ATC package %s failed, reinstalling %s", pkg.packageName(), installed.PackageName)
+			if rerr := client.InstallPackage(previous, timeout, atcPackagePollInterval); rerr != nil {
+				return fmt.Errorf("%v; reinstalling %s failed: %v", err, installed.PackageName, rerr)
+			}
+			return err
+		}
+	}
+	version, err := client.WaitForATCService(pkg.Name, timeout, atcPackagePollInterval)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] ATC package %s is ready (service version %s)", pkg.packageName(), version)
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccBigipAtcPackageCreate installs the RPM named by ATC_PACKAGE_PATH, e.g. f5-telemetry-1.33.0-1.noarch.rpm
+func TestAccBigipAtcPackageCreate(t *testing.T) {
+	path := os.Getenv("ATC_PACKAGE_PATH")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+			if path == "" {
+				t.Skip("ATC_PACKAGE_PATH must be set to run this test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAtcPackageDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipAtcPackageConfig(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bigip_atc_package.test", "version"),
+					resource.TestCheckResourceAttrSet("bigip_atc_package.test", "package_name"),
+				),
+			},
+			{
+				ResourceName:            "bigip_atc_package.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"package_path", "timeout"},
+			},
+		},
+	})
+}
+
+func testAccBigipAtcPackageConfig(path string) string {
+	return fmt.Sprintf(`
+resource "bigip_atc_package" "test" {
+  package_path = "%s"
+}
+`, path)
+}
+
+func testCheckAtcPackageDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_atc_package" {
+			continue
+		}
+		packages, err := client.GetInstalledPackages(atcPackagePollInterval*100, atcPackagePollInterval)
+		if err != nil {
+			return err
+		}
+		for _, p := range packages {
+			if p.Name == rs.Primary.ID {
+				return fmt.Errorf("ATC package %s still installed", p.PackageName)
+			}
+		}
+	}
+	return nil
+}
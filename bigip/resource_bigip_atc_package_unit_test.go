@@ -0,0 +1,340 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_atc_package against a fake iControl LX package management endpoint - no F5 BIG-IP connection required
+
+// fakePackageServer keeps the installed packages and runs every package
+// management task to completion after one STARTED poll.
+type fakePackageServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	installed  map[string]bigip.IappPackage
+	tasks      map[string]*bigip.PackageManagementTask
+	operations []string
+	uploads    map[string][]byte
+	notReady   int
+	failTask   string
+	failUpload bool
+}
+
+func newFakePackageServer(t *testing.T) *fakePackageServer {
+	f := &fakePackageServer{
+		installed: make(map[string]bigip.IappPackage),
+		tasks:     make(map[string]*bigip.PackageManagementTask),
+		uploads:   make(map[string][]byte),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/shared/file-transfer/uploads/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/shared/file-transfer/uploads/")
+		if f.failUpload {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprint(w, `{"code":500,"message":"Upload failed"}`)
+			return
+		}
+		f.mu.Lock()
+		f.uploads[name] = append(f.uploads[name], body...)
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"remainingByteCount":0}`)
+	})
+	mux.HandleFunc("/mgmt/shared/iapp/package-management-tasks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		var task bigip.PackageManagementTask
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&task))
+		f.mu.Lock()
+		task.ID = fmt.Sprintf("task-%d", len(f.tasks)+1)
+		task.Status = bigip.PackageTaskCreated
+		f.tasks[task.ID] = &task
+		op := task.Operation
+		if task.PackageFilePath != "" {
+			op += " " + filepath.Base(task.PackageFilePath)
+		}
+		if task.PackageName != "" {
+			op += " " + task.PackageName
+		}
+		f.operations = append(f.operations, op)
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(task)
+	})
+	mux.HandleFunc("/mgmt/shared/iapp/package-management-tasks/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/mgmt/shared/iapp/package-management-tasks/")
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		task, ok := f.tasks[id]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"code":404,"message":"Task not found"}`)
+			return
+		}
+		if task.Status == bigip.PackageTaskCreated {
+			task.Status = bigip.PackageTaskStarted
+		} else if task.Status == bigip.PackageTaskStarted {
+			f.finish(task)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(task)
+	})
+	mux.HandleFunc("/mgmt/shared/telemetry/info", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		p, ok := f.installed["f5-telemetry"]
+		if !ok || f.notReady > 0 {
+			f.notReady--
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"code":400,"message":"Public URI path not registered: /shared/telemetry/info"}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"nodeVersion":"v8.11.1","version":"%s","release":"%s"}`, p.Version, p.Release)
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `[{"id":0,"selfLink":"https://localhost/mgmt/shared/declarative-onboarding/info","result":{"class":"Result","code":200,"status":"OK"},"version":"1.40.0","release":"5"}]`)
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// finish completes a task, applying INSTALL and UNINSTALL to the installed
+// packages. failTask fails an operation, or an INSTALL of a single RPM when
+// it names the file as well.
+func (f *fakePackageServer) finish(task *bigip.PackageManagementTask) {
+	task.Status = bigip.PackageTaskFinished
+	if f.failTask == task.Operation || f.failTask == task.Operation+" "+filepath.Base(task.PackageFilePath) {
+		task.Status = bigip.PackageTaskFailed
+		task.ErrorMessage = "Package " + task.Operation + " failed"
+		return
+	}
+	switch task.Operation {
+	case "INSTALL":
+		pkg, _ := parseRpmFileName(task.PackageFilePath)
+		f.installed[pkg.Name] = bigip.IappPackage{Name: pkg.Name, Version: pkg.Version, Release: pkg.Release, Arch: pkg.Arch, PackageName: pkg.packageName()}
+	case "UNINSTALL":
+		for name, p := range f.installed {
+			if p.PackageName == task.PackageName {
+				delete(f.installed, name)
+			}
+		}
+	case "QUERY":
+		task.QueryResponse = []bigip.IappPackage{}
+		for _, p := range f.installed {
+			task.QueryResponse = append(task.QueryResponse, p)
+		}
+	}
+}
+
+// changes returns the operations other than QUERY run so far.
+func (f *fakePackageServer) changes() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ops []string
+	for _, op := range f.operations {
+		if op != "QUERY" {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// testRpm writes a fake RPM with the given file name to a temporary directory.
+func testRpm(t *testing.T, name string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte("rpm "+name), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testAtcPackageResourceData(t *testing.T, path string) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, resourceBigipAtcPackage().Schema, map[string]interface{}{
+		"package_path": path,
+		"timeout":      1,
+	})
+}
+
+func TestParseRpmFileName(t *testing.T) {
+	pkg, err := parseRpmFileName("/tmp/rpms/f5-appsvcs-templates-1.25.0-1.noarch.rpm")
+	assert.NoError(t, err)
+	assert.Equal(t, atcPackage{Name: "f5-appsvcs-templates", Version: "1.25.0", Release: "1", Arch: "noarch"}, pkg)
+	assert.Equal(t, "f5-appsvcs-templates-1.25.0-1.noarch", pkg.packageName())
+
+	_, err = parseRpmFileName("f5-appsvcs.rpm")
+	assert.Error(t, err)
+	_, err = parseRpmFileName("f5-appsvcs-3.50.0-5.noarch.tar.gz")
+	assert.Error(t, err)
+}
+
+func TestResourceBigipAtcPackageLifecycle(t *testing.T) {
+	withFastPolling(t, &atcPackagePollInterval)
+	f := newFakePackageServer(t)
+	f.notReady = 2
+	client := testFakeBigipClient(f.Server)
+
+	path := testRpm(t, "f5-telemetry-1.32.0-2.noarch.rpm")
+	d := testAtcPackageResourceData(t, path)
+	if diags := resourceBigipAtcPackageCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "f5-telemetry", d.Id())
+	assert.Equal(t, "1.32.0", d.Get("version"))
+	assert.Equal(t, "2", d.Get("release"))
+	assert.Equal(t, "f5-telemetry-1.32.0-2.noarch", d.Get("package_name"))
+	assert.Equal(t, "rpm f5-telemetry-1.32.0-2.noarch.rpm", string(f.uploads["f5-telemetry-1.32.0-2.noarch.rpm"]))
+	assert.Equal(t, []string{"INSTALL f5-telemetry-1.32.0-2.noarch.rpm"}, f.changes())
+	assert.Equal(t, 0, f.notReady, "the service should have been polled until ready")
+
+	// upgrading installs over the old version
+	upgrade := testRpm(t, "f5-telemetry-1.33.0-1.noarch.rpm")
+	d2 := testAtcPackageResourceData(t, upgrade)
+	d2.SetId("f5-telemetry")
+	if diags := resourceBigipAtcPackageUpdate(context.Background(), d2, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "1.33.0", d2.Get("version"))
+	assert.Equal(t, []string{
+		"INSTALL f5-telemetry-1.32.0-2.noarch.rpm",
+		"INSTALL f5-telemetry-1.33.0-1.noarch.rpm",
+	}, f.changes())
+
+	if diags := resourceBigipAtcPackageDelete(context.Background(), d2, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "UNINSTALL f5-telemetry-1.33.0-1.noarch", f.changes()[2])
+	assert.Empty(t, f.installed)
+
+	// a package removed outside of terraform drops out of state
+	if diags := resourceBigipAtcPackageRead(context.Background(), d2, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d2.Id())
+}
+
+func TestResourceBigipAtcPackageAlreadyInstalled(t *testing.T) {
+	withFastPolling(t, &atcPackagePollInterval)
+	f := newFakePackageServer(t)
+	f.installed["f5-declarative-onboarding"] = bigip.IappPackage{Name: "f5-declarative-onboarding", Version: "1.40.0", Release: "5", Arch: "noarch", PackageName: "f5-declarative-onboarding-1.40.0-5.noarch"}
+	client := testFakeBigipClient(f.Server)
+
+	// the RPM does not need to exist locally when that version is installed
+	d := testAtcPackageResourceData(t, "/nonexistent/f5-declarative-onboarding-1.40.0-5.noarch.rpm")
+	if diags := resourceBigipAtcPackageCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "f5-declarative-onboarding", d.Id())
+	assert.Equal(t, "1.40.0", d.Get("version"))
+	assert.Empty(t, f.changes())
+	assert.Empty(t, f.uploads)
+}
+
+func TestResourceBigipAtcPackageImport(t *testing.T) {
+	withFastPolling(t, &atcPackagePollInterval)
+	f := newFakePackageServer(t)
+	f.installed["f5-declarative-onboarding"] = bigip.IappPackage{Name: "f5-declarative-onboarding", Version: "1.40.0", Release: "5", Arch: "noarch", PackageName: "f5-declarative-onboarding-1.40.0-5.noarch"}
+	client := testFakeBigipClient(f.Server)
+
+	d := resourceBigipAtcPackage().TestResourceData()
+	d.SetId("f5-declarative-onboarding")
+	if diags := resourceBigipAtcPackageRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "f5-declarative-onboarding-1.40.0-5.noarch", d.Get("package_name"))
+
+	// the matching RPM in configuration is not a change after import
+	suppress := resourceBigipAtcPackage().Schema["package_path"].DiffSuppressFunc
+	assert.True(t, suppress("package_path", "", "rpms/f5-declarative-onboarding-1.40.0-5.noarch.rpm", d))
+	assert.False(t, suppress("package_path", "", "rpms/f5-declarative-onboarding-1.41.0-1.noarch.rpm", d))
+}
+
+func TestResourceBigipAtcPackageInstallFails(t *testing.T) {
+	withFastPolling(t, &atcPackagePollInterval)
+	f := newFakePackageServer(t)
+	f.failTask = "INSTALL"
+	client := testFakeBigipClient(f.Server)
+
+	d := testAtcPackageResourceData(t, testRpm(t, "f5-telemetry-1.33.0-1.noarch.rpm"))
+	diags := resourceBigipAtcPackageCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a failed install task")
+	}
+	assert.Contains(t, diags[0].Summary, "Package INSTALL failed")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipAtcPackageUpgradeUploadFails(t *testing.T) {
+	withFastPolling(t, &atcPackagePollInterval)
+	f := newFakePackageServer(t)
+	f.installed["f5-telemetry"] = bigip.IappPackage{Name: "f5-telemetry", Version: "1.32.0", Release: "2", Arch: "noarch", PackageName: "f5-telemetry-1.32.0-2.noarch"}
+	f.failUpload = true
+	client := testFakeBigipClient(f.Server)
+
+	d := testAtcPackageResourceData(t, testRpm(t, "f5-telemetry-1.33.0-1.noarch.rpm"))
+	d.SetId("f5-telemetry")
+	if diags := resourceBigipAtcPackageUpdate(context.Background(), d, client); !diags.HasError() {
+		t.Fatal("expected an error for a failed upload")
+	}
+	assert.Empty(t, f.changes())
+	assert.Equal(t, "f5-telemetry-1.32.0-2.noarch", f.installed["f5-telemetry"].PackageName)
+}
+
+func TestResourceBigipAtcPackageUpgradeInstallFails(t *testing.T) {
+	withFastPolling(t, &atcPackagePollInterval)
+	f := newFakePackageServer(t)
+	f.installed["f5-telemetry"] = bigip.IappPackage{Name: "f5-telemetry", Version: "1.33.0", Release: "1", Arch: "noarch", PackageName: "f5-telemetry-1.33.0-1.noarch"}
+	f.failTask = "INSTALL f5-telemetry-1.32.0-2.noarch.rpm"
+	client := testFakeBigipClient(f.Server)
+
+	d := testAtcPackageResourceData(t, testRpm(t, "f5-telemetry-1.32.0-2.noarch.rpm"))
+	d.SetId("f5-telemetry")
+	diags := resourceBigipAtcPackageUpdate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a failed install task")
+	}
+	assert.Contains(t, diags[0].Summary, "Package INSTALL failed")
+	assert.Equal(t, []string{
+		"INSTALL f5-telemetry-1.32.0-2.noarch.rpm",
+		"UNINSTALL f5-telemetry-1.33.0-1.noarch",
+		"INSTALL f5-telemetry-1.32.0-2.noarch.rpm",
+		"INSTALL f5-telemetry-1.33.0-1.noarch.rpm",
+	}, f.changes())
+	assert.Equal(t, "f5-telemetry-1.33.0-1.noarch", f.installed["f5-telemetry"].PackageName)
+}
+
+func TestBigipATCServiceNotInstalled(t *testing.T) {
+	f := newFakePackageServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	_, err := client.GetATCServiceVersion("f5-telemetry")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "f5-telemetry is not installed")
+	}
+	version, err := client.GetATCServiceVersion("f5-declarative-onboarding")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.40.0", version)
+}
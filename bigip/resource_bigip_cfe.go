@@ -0,0 +1,164 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// cfeResourceID is the id of bigip_cfe, the Cloud Failover Extension holds a single declaration per device.
+const cfeResourceID = "cloud-failover"
+
+// cfePollInterval is how often a triggered failover is polled.
+var cfePollInterval = 5 * time.Second
+
+func resourceBigipCfe() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipCfeCreate,
+		ReadContext:   resourceBigipCfeRead,
+		UpdateContext: resourceBigipCfeUpdate,
+		DeleteContext: resourceBigipCfeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cfe_json": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cloud Failover Extension declaration as a JSON string",
+				StateFunc: func(v interface{}) string {
+					jsonString, _ := structure.NormalizeJsonString(v)
+					return jsonString
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					oldJsonref := make(map[string]interface{})
+					newJsonref := make(map[string]interface{})
+					_ = json.Unmarshal([]byte(old), &oldJsonref)
+					_ = json.Unmarshal([]byte(new), &newJsonref)
+					delete(oldJsonref, "$schema")
+					delete(newJsonref, "$schema")
+					return reflect.DeepEqual(oldJsonref, newJsonref)
+				},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					jsonRef := make(map[string]interface{})
+					if err := json.Unmarshal([]byte(v.(string)), &jsonRef); err != nil {
+						errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+						return
+					}
+					if jsonRef["class"] != "Cloud_Failover" {
+						errors = append(errors, fmt.Errorf("JSON must have Cloud_Failover class"))
+					}
+					return
+				},
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set to true to trigger a dry-run failover after the declaration is applied and fail the apply if it does not succeed",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				Description:  "Minutes to wait for the dry-run failover to finish",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"dry_run_result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Failover operations reported by the last dry-run failover, as a JSON string",
+			},
+		},
+	}
+}
+
+func resourceBigipCfeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Creating Cloud Failover declaration")
+	if _, err := client.PostCFE(d.Get("cfe_json").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Cloud Failover declaration: %v", err))
+	}
+	d.SetId(cfeResourceID)
+	if err := cfeDryRun(client, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceBigipCfeRead(ctx, d, meta)
+}
+
+func resourceBigipCfeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading Cloud Failover declaration")
+	declaration, err := client.GetCFE()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving Cloud Failover declaration: %v", err))
+	}
+	if len(declaration) == 0 {
+		log.Printf("[WARN] Cloud Failover declaration not found, removing from state")
+		d.SetId("")
+		return nil
+	}
+	out, err := json.Marshal(declaration)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("cfe_json", string(out))
+	return nil
+}
+
+func resourceBigipCfeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating Cloud Failover declaration")
+	if d.HasChange("cfe_json") {
+		if _, err := client.PostCFE(d.Get("cfe_json").(string)); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating Cloud Failover declaration: %v", err))
+		}
+	}
+	if err := cfeDryRun(client, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceBigipCfeRead(ctx, d, meta)
+}
+
+// resourceBigipCfeDelete only removes the declaration from state, the Cloud
+// Failover Extension has no endpoint to remove a declaration.
+func resourceBigipCfeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Removing Cloud Failover declaration from state, the declaration is left on the BIG-IP")
+	d.SetId("")
+	return nil
+}
+
+// cfeDryRun triggers a dry-run failover when dry_run is set and records the
+// failover operations it reports.
+func cfeDryRun(client *bigip.BigIP, d *schema.ResourceData) error {
+	if !d.Get("dry_run").(bool) {
+		_ = d.Set("dry_run_result", "")
+		return nil
+	}
+	timeout := time.Duration(d.Get("timeout").(int)) * time.Minute
+	task, err := client.RunCFETrigger("dry-run", timeout, cfePollInterval)
+	if err != nil {
+		return fmt.Errorf("error verifying Cloud Failover declaration with a dry-run failover: %v", err)
+	}
+	out, err := json.Marshal(task.FailoverOperations)
+	if err != nil {
+		return err
+	}
+	_ = d.Set("dry_run_result", string(out))
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccBigipCfeConfig = `
+resource "bigip_cfe" "test" {
+  cfe_json = jsonencode({
+    class       = "Cloud_Failover"
+    environment = "aws"
+    externalStorage = {
+      scopingTags = {
+        f5_cloud_failover_label = "tf-acc-test"
+      }
+    }
+    failoverAddresses = {
+      enabled = true
+      scopingTags = {
+        f5_cloud_failover_label = "tf-acc-test"
+      }
+    }
+  })
+  dry_run = true
+}
+
+data "bigip_cfe_inspect" "test" {
+  depends_on = [bigip_cfe.test]
+}
+`
+
+func TestAccBigipCfeCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipCfeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("bigip_cfe.test", "cfe_json", regexp.MustCompile(`"environment":"aws"`)),
+					resource.TestCheckResourceAttrSet("bigip_cfe.test", "dry_run_result"),
+					resource.TestCheckResourceAttrSet("data.bigip_cfe_inspect.test", "device_status"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_cfe and bigip_cfe_inspect against a fake Cloud Failover Extension endpoint - no F5 BIG-IP connection required
+
+const testCfeDeclaration = `{"class":"Cloud_Failover","environment":"aws","externalStorage":{"scopingTags":{"f5_cloud_failover_label":"mydeployment"}},"failoverAddresses":{"enabled":true,"scopingTags":{"f5_cloud_failover_label":"mydeployment"}}}`
+
+// fakeCfeServer stores the last declaration and runs dry-run failovers,
+// which report RUNNING for the given number of polls.
+type fakeCfeServer struct {
+	*httptest.Server
+	mu           sync.Mutex
+	declaration  map[string]interface{}
+	triggers     []string
+	runningPolls int
+	failTrigger  bool
+}
+
+func newFakeCfeServer(t *testing.T) *fakeCfeServer {
+	f := &fakeCfeServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/shared/cloud-failover/declare", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			var decl map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&decl)
+			if decl["environment"] == "" || decl["environment"] == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `{"code":400,"message":"Invalid declaration","errors":["should have required property 'environment'"]}`)
+				return
+			}
+			f.declaration = decl
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"message": "success", "declaration": f.declaration})
+	})
+	mux.HandleFunc("/mgmt/shared/cloud-failover/inspect", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"instance":"i-0123456789","hostName":"bigip1.example.com","deviceStatus":"active","trafficGroup":[{"name":"traffic-group-1"}],"addresses":[{"privateIpAddress":"10.0.1.10","networkInterfaceId":"eni-1"}],"routes":[]}`)
+	})
+	mux.HandleFunc("/mgmt/shared/cloud-failover/trigger", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		state := "SUCCEEDED"
+		if r.Method == "POST" {
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.triggers = append(f.triggers, body["action"])
+		}
+		if f.runningPolls > 0 {
+			if r.Method == "GET" {
+				f.runningPolls--
+			}
+			state = "RUNNING"
+		} else if f.failTrigger {
+			state = "FAILED"
+		}
+		_, _ = fmt.Fprintf(w, `{"taskState":"%s","message":"Dry run %s","instance":"i-0123456789","failoverOperations":{"addresses":{"operations":["move 10.0.1.10"]},"routes":{}}}`, state, state)
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestResourceBigipCfeLifecycle(t *testing.T) {
+	f := newFakeCfeServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCfe().Schema, map[string]interface{}{"cfe_json": testCfeDeclaration})
+	if diags := resourceBigipCfeCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "cloud-failover", d.Id())
+	assert.JSONEq(t, testCfeDeclaration, d.Get("cfe_json").(string))
+	assert.Empty(t, f.triggers, "no dry run unless requested")
+	assert.Equal(t, "", d.Get("dry_run_result"))
+
+	// drift on the device shows up on refresh
+	f.mu.Lock()
+	f.declaration["environment"] = "azure"
+	f.mu.Unlock()
+	if diags := resourceBigipCfeRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Contains(t, d.Get("cfe_json").(string), `"environment":"azure"`)
+
+	if diags := resourceBigipCfeDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipCfeDryRun(t *testing.T) {
+	withFastPolling(t, &cfePollInterval)
+	f := newFakeCfeServer(t)
+	f.runningPolls = 2
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCfe().Schema, map[string]interface{}{"cfe_json": testCfeDeclaration, "dry_run": true})
+	if diags := resourceBigipCfeCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []string{"dry-run"}, f.triggers)
+	assert.Equal(t, 0, f.runningPolls)
+	assert.JSONEq(t, `{"addresses":{"operations":["move 10.0.1.10"]},"routes":{}}`, d.Get("dry_run_result").(string))
+}
+
+func TestResourceBigipCfeDryRunFails(t *testing.T) {
+	withFastPolling(t, &cfePollInterval)
+	f := newFakeCfeServer(t)
+	f.failTrigger = true
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCfe().Schema, map[string]interface{}{"cfe_json": testCfeDeclaration, "dry_run": true})
+	d.SetId("cloud-failover")
+	diags := resourceBigipCfeUpdate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a failed dry run")
+	}
+	assert.Contains(t, diags[0].Summary, "Dry run FAILED")
+}
+
+func TestResourceBigipCfeInvalidDeclaration(t *testing.T) {
+	f := newFakeCfeServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCfe().Schema, map[string]interface{}{"cfe_json": `{"class":"Cloud_Failover"}`})
+	diags := resourceBigipCfeCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for an invalid declaration")
+	}
+	assert.Contains(t, diags[0].Summary, "required property 'environment'")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestDataSourceBigipCfeInspect(t *testing.T) {
+	f := newFakeCfeServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipCfeInspect().Schema, map[string]interface{}{})
+	if diags := dataSourceBigipCfeInspectRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "i-0123456789", d.Get("instance"))
+	assert.Equal(t, "bigip1.example.com", d.Get("hostname"))
+	assert.Equal(t, "active", d.Get("device_status"))
+	assert.Equal(t, []interface{}{"traffic-group-1"}, d.Get("traffic_groups"))
+	assert.Contains(t, d.Get("inspect_json").(string), "eni-1")
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// configSyncPollInterval is how often the sync status is polled after a config sync.
+var configSyncPollInterval = 5 * time.Second
+
+// configSyncConflictPolls is how many polls in a row must report a change
+// conflict before the sync is considered failed, the status is only updated
+// a few seconds after the sync is started.
+var configSyncConflictPolls = 3
+
+// syncStatusDetailRegex matches a device group line of the sync status details,
+// e.g. "failover-group (In Sync): All devices in the device group are in sync".
+var syncStatusDetailRegex = regexp.MustCompile(`^(\S+) \(([^)]+)\): (.*)$`)
+
+func resourceBigipCmConfigSync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipCmConfigSyncCreate,
+		ReadContext:   resourceBigipCmConfigSyncRead,
+		UpdateContext: resourceBigipCmConfigSyncUpdate,
+		DeleteContext: resourceBigipCmConfigSyncDelete,
+
+		Schema: map[string]*schema.Schema{
+			"device_group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Device group to sync, e.g. failover-group",
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "to-group",
+				ValidateFunc: validation.StringInSlice([]string{"to-group", "from-group"}, false),
+				Description:  "to-group pushes the configuration of this device to the group, from-group pulls it from the group",
+			},
+			"force_full_load_push": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Replace the configuration of the peers instead of sending the incremental changes, resolves change conflicts in favour of this device",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the sync again when they change",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Minutes to wait for the device group to be in sync",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Sync status of the device group, e.g. In Sync",
+			},
+			"summary": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Sync status message of the device group",
+			},
+		},
+	}
+}
+
+func resourceBigipCmConfigSyncCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	group := strings.TrimPrefix(d.Get("device_group").(string), "/Common/")
+	direction := d.Get("direction").(string)
+	forceFullLoadPush := d.Get("force_full_load_push").(bool)
+	if forceFullLoadPush && direction != "to-group" {
+		return diag.FromErr(fmt.Errorf("force_full_load_push can only be used with direction to-group"))
+	}
+	log.Printf("[INFO] Running config sync %s %s", direction, group)
+	if err := client.ConfigSync(group, direction, forceFullLoadPush); err != nil {
+		return diag.FromErr(fmt.Errorf("error running config sync %s %s: %v", direction, group, err))
+	}
+	d.SetId(group)
+	timeout := time.Duration(d.Get("timeout").(int)) * time.Minute
+	if err := waitForConfigSync(client, group, timeout); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceBigipCmConfigSyncRead(ctx, d, meta)
+}
+
+func resourceBigipCmConfigSyncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	group := d.Id()
+	log.Printf("[INFO] Reading sync status of device group %s", group)
+	status, err := client.GetSyncStatus()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving sync status: %v", err))
+	}
+	groupStatus, summary := deviceGroupSyncStatus(status, group)
+	_ = d.Set("status", groupStatus)
+	_ = d.Set("summary", summary)
+	return nil
+}
+
+func resourceBigipCmConfigSyncUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only timeout can change without running the sync again
+	return resourceBigipCmConfigSyncRead(ctx, d, meta)
+}
+
+func resourceBigipCmConfigSyncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// a sync cannot be undone, destroying the resource only removes it from state
+	log.Printf("[INFO] Removing config sync of device group %s from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// waitForConfigSync polls the sync status until the device group is in sync.
+// A sync failure, or a change conflict that is still reported after the sync
+// was started, ends the wait with an error listing the sync status details.
+func waitForConfigSync(client *bigip.BigIP, group string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	conflicts := 0
+	for {
+		status, err := client.GetSyncStatus()
+		if err != nil {
+			return fmt.Errorf("error retrieving sync status: %v", err)
+		}
+		groupStatus, summary := deviceGroupSyncStatus(status, group)
+		log.Printf("[DEBUG] sync status of device group %s: %s: %s", group, groupStatus, summary)
+		switch {
+		case groupStatus == "In Sync":
+			return nil
+		case groupStatus == "Sync Failure":
+			return fmt.Errorf("config sync of device group %s failed: %s%s", group, summary, syncStatusDetails(status))
+		case strings.Contains(strings.ToLower(summary), "conflict"):
+			conflicts++
+			if conflicts >= configSyncConflictPolls {
+				return fmt.Errorf("config sync of device group %s has a change conflict: %s. Sync from the device whose configuration should be kept, or set force_full_load_push to overwrite the peers%s", group, summary, syncStatusDetails(status))
+			}
+		default:
+			conflicts = 0
+		}
+		if time.Now().Add(configSyncPollInterval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for device group %s to be in sync, status is %s: %s%s", timeout, group, groupStatus, summary, syncStatusDetails(status))
+		}
+		time.Sleep(configSyncPollInterval)
+	}
+}
+
+// deviceGroupSyncStatus returns the status and message of a device group from
+// the sync status details, or the overall status if the group is not listed.
+func deviceGroupSyncStatus(status *bigip.SyncStatus, group string) (string, string) {
+	for _, line := range status.Details {
+		match := syncStatusDetailRegex.FindStringSubmatch(line)
+		if match != nil && match[1] == group {
+			return match[2], match[3]
+		}
+	}
+	return status.Status, status.Summary
+}
+
+func syncStatusDetails(status *bigip.SyncStatus) string {
+	if len(status.Details) == 0 {
+		return ""
+	}
+	return "\n  " + strings.Join(status.Details, "\n  ")
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipCmTrafficGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipCmTrafficGroupCreate,
+		ReadContext:   resourceBigipCmTrafficGroupRead,
+		UpdateContext: resourceBigipCmTrafficGroupUpdate,
+		DeleteContext: resourceBigipCmTrafficGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+				Description:  "Name of the traffic group, e.g. /Common/traffic-group-2",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the traffic group",
+			},
+			"failover_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ha-order", "ha-score", "ha-group"}, false),
+				Description:  "How the next active device is chosen: ha-order follows ha_order, ha-score uses the HA load factor and ha-group the HA group score",
+			},
+			"ha_order": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Devices in the order they take over the traffic group, e.g. /Common/bigip1.example.com",
+			},
+			"ha_group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "HA group whose score decides failover, used with the ha-group failover method",
+			},
+			"ha_load_factor": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "Relative load of the traffic group, used with the ha-score failover method",
+			},
+			"auto_failback": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail back to the first device of ha_order when it becomes available again",
+			},
+			"auto_failback_time": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 300),
+				Description:  "Seconds to wait before failing back",
+			},
+			"mac": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "MAC masquerade address shared by the floating addresses of the traffic group, none disables it",
+			},
+		},
+	}
+}
+
+func resourceBigipCmTrafficGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating traffic group %s", name)
+	config := &bigip.TrafficGroup{
+		Name:                name,
+		Description:         d.Get("description").(string),
+		AutoFailbackEnabled: fmt.Sprintf("%t", d.Get("auto_failback").(bool)),
+		AutoFailbackTime:    d.Get("auto_failback_time").(int),
+		FailoverMethod:      d.Get("failover_method").(string),
+		HaGroup:             d.Get("ha_group").(string),
+		HaLoadFactor:        d.Get("ha_load_factor").(int),
+		HaOrder:             listToStringSlice(d.Get("ha_order").([]interface{})),
+		Mac:                 d.Get("mac").(string),
+	}
+	if err := client.CreateTrafficGroup(config); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating traffic group %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipCmTrafficGroupRead(ctx, d, meta)
+}
+
+func resourceBigipCmTrafficGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading traffic group %s", name)
+	group, err := client.GetTrafficGroup(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] Traffic group %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving traffic group %s: %v", name, err))
+	}
+	_ = d.Set("name", group.FullPath)
+	_ = d.Set("description", group.Description)
+	_ = d.Set("failover_method", group.FailoverMethod)
+	_ = d.Set("ha_order", group.HaOrder)
+	haGroup := group.HaGroup
+	if haGroup == "none" {
+		haGroup = ""
+	}
+	_ = d.Set("ha_group", haGroup)
+	_ = d.Set("ha_load_factor", group.HaLoadFactor)
+	_ = d.Set("auto_failback", group.AutoFailbackEnabled == "true")
+	_ = d.Set("auto_failback_time", group.AutoFailbackTime)
+	_ = d.Set("mac", group.Mac)
+	return nil
+}
+
+func resourceBigipCmTrafficGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Updating traffic group %s", name)
+	// patch with a map so that ha_order and ha_group can be cleared
+	haOrder := listToStringSlice(d.Get("ha_order").([]interface{}))
+	if haOrder == nil {
+		haOrder = []string{}
+	}
+	haGroup := d.Get("ha_group").(string)
+	if haGroup == "" {
+		haGroup = "none"
+	}
+	config := map[string]interface{}{
+		"description":         d.Get("description").(string),
+		"autoFailbackEnabled": fmt.Sprintf("%t", d.Get("auto_failback").(bool)),
+		"autoFailbackTime":    d.Get("auto_failback_time").(int),
+		"failoverMethod":      d.Get("failover_method").(string),
+		"haGroup":             haGroup,
+		"haLoadFactor":        d.Get("ha_load_factor").(int),
+		"haOrder":             haOrder,
+		"mac":                 d.Get("mac").(string),
+	}
+	if err := client.ModifyTrafficGroup(name, config); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating traffic group %s: %v", name, err))
+	}
+	return resourceBigipCmTrafficGroupRead(ctx, d, meta)
+}
+
+func resourceBigipCmTrafficGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting traffic group %s", name)
+	if err := client.DeleteTrafficGroup(name); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting traffic group %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
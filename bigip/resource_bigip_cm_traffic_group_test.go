@@ -0,0 +1,64 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipCmTrafficGroupConfig = `
+resource "bigip_cm_traffic_group" "test" {
+  name               = "/Common/tf-acc-traffic-group"
+  description        = "tf-acc-test"
+  failover_method    = "ha-order"
+  auto_failback      = true
+  auto_failback_time = 60
+}
+`
+
+func TestAccBigipCmTrafficGroupCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckCmTrafficGroupDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipCmTrafficGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_cm_traffic_group.test", "failover_method", "ha-order"),
+					resource.TestCheckResourceAttr("bigip_cm_traffic_group.test", "auto_failback", "true"),
+					resource.TestCheckResourceAttr("bigip_cm_traffic_group.test", "auto_failback_time", "60"),
+				),
+			},
+			{
+				ResourceName:      "bigip_cm_traffic_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckCmTrafficGroupDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_cm_traffic_group" {
+			continue
+		}
+		if _, err := client.GetTrafficGroup(rs.Primary.ID); err == nil {
+			return fmt.Errorf("traffic group %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipCmTrust() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipCmTrustCreate,
+		ReadContext:   resourceBigipCmTrustRead,
+		UpdateContext: resourceBigipCmTrustUpdate,
+		DeleteContext: resourceBigipCmTrustDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "Management address of the peer",
+			},
+			"device_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the peer's self device, usually its hostname, e.g. bigip2.example.com",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Administrator of the peer, only used to add the peer",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the administrator of the peer, only used to add the peer",
+			},
+			"ca_device": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Add the peer as a certificate authority, which can add further devices to the trust",
+			},
+		},
+	}
+}
+
+func resourceBigipCmTrustCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	deviceName := d.Get("device_name").(string)
+	address := d.Get("address").(string)
+	log.Printf("[INFO] Adding device %s (%s) to the device trust", deviceName, address)
+	err := client.AddToTrust(address, deviceName, d.Get("username").(string), d.Get("password").(string), d.Get("ca_device").(bool))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error adding device %s (%s) to the device trust: %v", deviceName, address, err))
+	}
+	d.SetId(deviceName)
+	return resourceBigipCmTrustRead(ctx, d, meta)
+}
+
+func resourceBigipCmTrustRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	deviceName := d.Id()
+	log.Printf("[INFO] Reading device %s in the device trust", deviceName)
+	domain, err := client.GetTrustDomain()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving the device trust: %v", err))
+	}
+	var caDevice bool
+	switch {
+	case trustContains(domain.CaDevices, deviceName):
+		caDevice = true
+	case trustContains(domain.NonCaDevices, deviceName):
+		caDevice = false
+	default:
+		log.Printf("[WARN] Device %s not found in the device trust, removing from state", deviceName)
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("device_name", deviceName)
+	_ = d.Set("ca_device", caDevice)
+	// the address is only known from the configuration, except on import
+	if _, ok := d.GetOk("address"); !ok {
+		device, err := client.Devices(deviceName)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving device %s: %v", deviceName, err))
+		}
+		_ = d.Set("address", device.ManagementIP)
+	}
+	return nil
+}
+
+func resourceBigipCmTrustUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the credentials are only used to add the peer, a change does not
+	// need to be applied to the device
+	return resourceBigipCmTrustRead(ctx, d, meta)
+}
+
+func resourceBigipCmTrustDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	deviceName := d.Id()
+	log.Printf("[INFO] Removing device %s from the device trust", deviceName)
+	if err := client.RemoveFromTrust(deviceName); err != nil {
+		return diag.FromErr(fmt.Errorf("error removing device %s from the device trust: %v", deviceName, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// trustContains reports whether a device is in a list of the trust domain,
+// which holds full paths such as /Common/bigip2.example.com.
+func trustContains(devices []string, deviceName string) bool {
+	name := strings.TrimPrefix(deviceName, "/Common/")
+	for _, device := range devices {
+		if strings.TrimPrefix(device, "/Common/") == name {
+			return true
+		}
+	}
+	return false
+}
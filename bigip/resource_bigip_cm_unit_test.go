@@ -0,0 +1,329 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_cm_trust, bigip_cm_traffic_group and bigip_cm_config_sync
+// against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeCmServer stores traffic groups and the device trust, and reports the
+// sync statuses in syncStatuses one poll at a time, repeating the last one.
+type fakeCmServer struct {
+	*httptest.Server
+	mu            sync.Mutex
+	trafficGroups map[string]map[string]interface{}
+	caDevices     []string
+	nonCaDevices  []string
+	syncStatuses  [][]string
+	syncPolls     int
+	commands      []map[string]interface{}
+}
+
+func newFakeCmServer(t *testing.T) *fakeCmServer {
+	f := &fakeCmServer{
+		trafficGroups: make(map[string]map[string]interface{}),
+		caDevices:     []string{"/Common/bigip1.example.com"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/cm/traffic-group", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		name := body["name"].(string)
+		body["fullPath"] = name
+		f.trafficGroups[strings.ReplaceAll(name, "/", "~")] = body
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/traffic-group/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/cm/traffic-group/")
+		group, ok := f.trafficGroups[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested traffic group (%s) was not found."}`, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			for k, v := range body {
+				group[k] = v
+			}
+		case "DELETE":
+			delete(f.trafficGroups, name)
+		}
+		_ = json.NewEncoder(w).Encode(group)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/trust-domain/Root", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "Root", "caDevices": f.caDevices, "nonCaDevices": f.nonCaDevices})
+	})
+	mux.HandleFunc("/mgmt/tm/cm/add-to-trust", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.commands = append(f.commands, body)
+		device := "/Common/" + body["deviceName"].(string)
+		if body["caDevice"] == true {
+			f.caDevices = append(f.caDevices, device)
+		} else {
+			f.nonCaDevices = append(f.nonCaDevices, device)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/remove-from-trust", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.commands = append(f.commands, body)
+		remove := func(devices []string) []string {
+			var kept []string
+			for _, device := range devices {
+				if device != "/Common/"+body["deviceName"].(string) {
+					kept = append(kept, device)
+				}
+			}
+			return kept
+		}
+		f.caDevices = remove(f.caDevices)
+		f.nonCaDevices = remove(f.nonCaDevices)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/device/bigip2.example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"name":"bigip2.example.com","managementIp":"192.0.2.12"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/cm", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.commands = append(f.commands, body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/sync-status", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		current := f.syncStatuses[len(f.syncStatuses)-1]
+		if f.syncPolls < len(f.syncStatuses) {
+			current = f.syncStatuses[f.syncPolls]
+		}
+		f.syncPolls++
+		details := make(map[string]interface{})
+		for i, line := range current[2:] {
+			details[fmt.Sprintf("https://localhost/mgmt/tm/cm/syncStatus/0/details/%d", i)] = map[string]interface{}{
+				"nestedStats": map[string]interface{}{"entries": map[string]interface{}{"details": map[string]string{"description": line}}},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"entries": map[string]interface{}{
+				"https://localhost/mgmt/tm/cm/sync-status/0": map[string]interface{}{
+					"nestedStats": map[string]interface{}{"entries": map[string]interface{}{
+						"color":   map[string]string{"description": "blue"},
+						"mode":    map[string]string{"description": "high-availability"},
+						"status":  map[string]string{"description": current[0]},
+						"summary": map[string]string{"description": current[1]},
+						"https://localhost/mgmt/tm/cm/syncStatus/0/details": map[string]interface{}{
+							"nestedStats": map[string]interface{}{"entries": details},
+						},
+					}},
+				},
+			},
+		})
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestResourceBigipCmTrafficGroupLifecycle(t *testing.T) {
+	f := newFakeCmServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCmTrafficGroup().Schema, map[string]interface{}{
+		"name":            "/Common/traffic-group-2",
+		"failover_method": "ha-order",
+		"ha_order":        []interface{}{"/Common/bigip2.example.com", "/Common/bigip1.example.com"},
+		"auto_failback":   true,
+	})
+	if diags := resourceBigipCmTrafficGroupCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "/Common/traffic-group-2", d.Id())
+	group := f.trafficGroups["~Common~traffic-group-2"]
+	assert.Equal(t, "true", group["autoFailbackEnabled"])
+	assert.Equal(t, []interface{}{"/Common/bigip2.example.com", "/Common/bigip1.example.com"}, group["haOrder"])
+	assert.Equal(t, true, d.Get("auto_failback"))
+
+	// drift on the device shows up on refresh
+	f.mu.Lock()
+	group["haOrder"] = []interface{}{"/Common/bigip1.example.com"}
+	group["haGroup"] = "none"
+	f.mu.Unlock()
+	if diags := resourceBigipCmTrafficGroupRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []interface{}{"/Common/bigip1.example.com"}, d.Get("ha_order"))
+	assert.Equal(t, "", d.Get("ha_group"))
+
+	if diags := resourceBigipCmTrafficGroupDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.trafficGroups)
+
+	d.SetId("/Common/traffic-group-2")
+	if diags := resourceBigipCmTrafficGroupRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipCmTrustLifecycle(t *testing.T) {
+	f := newFakeCmServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCmTrust().Schema, map[string]interface{}{
+		"address":     "192.0.2.12",
+		"device_name": "bigip2.example.com",
+		"username":    "admin",
+		"password":    "peer-secret",
+	})
+	if diags := resourceBigipCmTrustCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "bigip2.example.com", d.Id())
+	assert.Equal(t, map[string]interface{}{
+		"command":    "run",
+		"name":       "Root",
+		"caDevice":   true,
+		"device":     "192.0.2.12",
+		"deviceName": "bigip2.example.com",
+		"username":   "admin",
+		"password":   "peer-secret",
+	}, f.commands[0])
+	assert.Equal(t, true, d.Get("ca_device"))
+
+	// import only knows the device name, the address is looked up
+	imported := schema.TestResourceDataRaw(t, resourceBigipCmTrust().Schema, map[string]interface{}{})
+	imported.SetId("bigip2.example.com")
+	if diags := resourceBigipCmTrustRead(context.Background(), imported, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "192.0.2.12", imported.Get("address"))
+	assert.Equal(t, "bigip2.example.com", imported.Get("device_name"))
+
+	if diags := resourceBigipCmTrustDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []string{"/Common/bigip1.example.com"}, f.caDevices)
+
+	// a device removed from the trust outside Terraform is removed from state
+	d.SetId("bigip2.example.com")
+	if diags := resourceBigipCmTrustRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipCmConfigSyncWaitsForInSync(t *testing.T) {
+	withFastPolling(t, &configSyncPollInterval)
+	f := newFakeCmServer(t)
+	client := testFakeBigipClient(f.Server)
+	f.syncStatuses = [][]string{
+		{"Changes Pending", "There is a pending change", "bigip2.example.com: connected", "failover-group (Changes Pending): Recommended action: Synchronize bigip1.example.com to group failover-group"},
+		{"Syncing", "", "bigip2.example.com: connected", "failover-group (Syncing): Syncing"},
+		{"In Sync", "All devices in the device group are in sync", "bigip2.example.com: connected", "failover-group (In Sync): All devices in the device group are in sync"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCmConfigSync().Schema, map[string]interface{}{
+		"device_group": "/Common/failover-group",
+	})
+	if diags := resourceBigipCmConfigSyncCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "failover-group", d.Id())
+	assert.Equal(t, map[string]interface{}{"command": "run", "utilCmdArgs": "config-sync to-group failover-group"}, f.commands[0])
+	assert.Equal(t, "In Sync", d.Get("status"))
+	assert.Equal(t, "All devices in the device group are in sync", d.Get("summary"))
+	assert.Equal(t, 4, f.syncPolls)
+}
+
+func TestResourceBigipCmConfigSyncConflict(t *testing.T) {
+	withFastPolling(t, &configSyncPollInterval)
+	f := newFakeCmServer(t)
+	client := testFakeBigipClient(f.Server)
+	f.syncStatuses = [][]string{
+		{"Changes Pending", "There is a possible change conflict between bigip1.example.com and bigip2.example.com.", "failover-group (Changes Pending): There is a possible change conflict between bigip1.example.com and bigip2.example.com."},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCmConfigSync().Schema, map[string]interface{}{
+		"device_group": "failover-group",
+	})
+	diags := resourceBigipCmConfigSyncCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatalf("expected a change conflict error")
+	}
+	assert.Contains(t, diags[0].Summary, "config sync of device group failover-group has a change conflict")
+	assert.Contains(t, diags[0].Summary, "force_full_load_push")
+	assert.Equal(t, configSyncConflictPolls, f.syncPolls)
+}
+
+func TestResourceBigipCmConfigSyncFailure(t *testing.T) {
+	withFastPolling(t, &configSyncPollInterval)
+	f := newFakeCmServer(t)
+	client := testFakeBigipClient(f.Server)
+	f.syncStatuses = [][]string{
+		{"Sync Failure", "A validation error occurred while syncing to a remote device", "bigip2.example.com: connected", "failover-group (Sync Failure): A validation error occurred while syncing to a remote device"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceBigipCmConfigSync().Schema, map[string]interface{}{
+		"device_group":         "failover-group",
+		"force_full_load_push": true,
+	})
+	diags := resourceBigipCmConfigSyncCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatalf("expected a sync failure error")
+	}
+	assert.Equal(t, "config-sync force-full-load-push to-group failover-group", f.commands[0]["utilCmdArgs"])
+	assert.Contains(t, diags[0].Summary, "config sync of device group failover-group failed: A validation error occurred")
+	assert.Contains(t, diags[0].Summary, "bigip2.example.com: connected")
+
+	// a full load push cannot pull from the group
+	d = schema.TestResourceDataRaw(t, resourceBigipCmConfigSync().Schema, map[string]interface{}{
+		"device_group":         "failover-group",
+		"direction":            "from-group",
+		"force_full_load_push": true,
+	})
+	diags = resourceBigipCmConfigSyncCreate(context.Background(), d, client)
+	assert.True(t, diags.HasError())
+	assert.Len(t, f.commands, 1)
+}
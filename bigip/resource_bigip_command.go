@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strings"
 
 	bigip "github.com/f5devcentral/go-bigip"
@@ -17,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBigipCommand() *schema.Resource {
@@ -42,6 +44,53 @@ func resourceBigipCommand() *schema.Resource {
 				},
 				Description: "The commands to send to the remote BIG-IP device over the configured provider",
 			},
+			"shell": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tmsh", "bash"}, false),
+				Description:  "Shell running all the commands of the resource, tmsh or bash, default is tmsh",
+			},
+			"check_commands": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Commands reading back the configuration made by commands, the commands run again when their output changes",
+			},
+			"check_output": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				RequiredWith: []string{"check_commands"},
+				Description:  "Regular expression the output of check_commands matches when the commands do not need to run",
+			},
+			"check_result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Output of check_commands after the last run of the commands",
+			},
+			"expected_output": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Regular expression the output of commands must match, the apply fails otherwise",
+			},
+			"destroy_commands": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Commands run when the resource is destroyed",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values, changing any of them runs the destroy commands and the commands again",
+			},
 			"command_result": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -49,7 +98,7 @@ func resourceBigipCommand() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "Partition of ssl certificate",
+				Description: "Output of the commands",
 			},
 		},
 	}
@@ -57,30 +106,25 @@ func resourceBigipCommand() *schema.Resource {
 
 func resourceBigipCommandCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	var commandList []string
 	if d.Get("when").(string) == "apply" {
-		if m, ok := d.GetOk("commands"); ok {
-			for _, cmd := range m.([]interface{}) {
-				// Handle edge case where command contains our quote character
-				escapedCmd := strings.ReplaceAll(cmd.(string), "'", "'\\''")
-				commandList = append(commandList, fmt.Sprintf("-c 'tmsh %s'", escapedCmd))
+		run := true
+		// a resource with triggers is only created again to run the commands again
+		if _, ok := d.GetOk("check_output"); ok && len(d.Get("triggers").(map[string]interface{})) == 0 {
+			checkResult, err := bigipCommandCheck(client, d)
+			if err != nil {
+				return diag.FromErr(err)
 			}
-		}
-		log.Printf("[INFO] Running TMSH Command : %v ", commandList)
-		var resultList []string
-		for _, str := range commandList {
-			log.Printf("[INFO] Command to run:%v", str)
-			commandConfig := &bigip.BigipCommand{
-				Command:     "run",
-				UtilCmdArgs: str,
+			if regexp.MustCompile(d.Get("check_output").(string)).MatchString(checkResult) {
+				log.Printf("[INFO] Output of the check commands matches %s, skipping the commands", d.Get("check_output").(string))
+				_ = d.Set("check_result", checkResult)
+				run = false
 			}
-			resultCmd, err := client.RunCommand(commandConfig)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("error retrieving Command Result: %v", err))
+		}
+		if run {
+			if err := bigipCommandApply(client, d); err != nil {
+				return diag.FromErr(err)
 			}
-			resultList = append(resultList, resultCmd.CommandResult)
 		}
-		_ = d.Set("command_result", resultList)
 	}
 	d.SetId(d.Get("when").(string))
 	if !client.Teem {
@@ -105,64 +149,123 @@ func resourceBigipCommandCreate(ctx context.Context, d *schema.ResourceData, met
 	return nil
 }
 
+// resourceBigipCommandRead runs the check commands, the resource is removed
+// from the state for the commands to run again when their output no longer
+// matches check_output, or without check_output when it changed since the
+// last run.
 func resourceBigipCommandRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Println("[INFO]:Read Operation is not supported for this resource")
+	client := meta.(*bigip.BigIP)
+	if d.Get("when").(string) != "apply" || len(d.Get("check_commands").([]interface{})) == 0 {
+		log.Println("[INFO]:Read Operation is not supported for this resource without check_commands")
+		return nil
+	}
+	checkResult, err := bigipCommandCheck(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if checkOutput := d.Get("check_output").(string); checkOutput != "" {
+		if !regexp.MustCompile(checkOutput).MatchString(checkResult) {
+			log.Printf("[WARN] Output of the check commands no longer matches %s, removing from state", checkOutput)
+			d.SetId("")
+		}
+		return nil
+	}
+	if checkResult != d.Get("check_result").(string) {
+		log.Printf("[WARN] Output of the check commands changed, removing from state")
+		d.SetId("")
+	}
 	return nil
 }
+
 func resourceBigipCommandUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	var commandList []string
-	if d.Get("when").(string) == "apply" {
-		if m, ok := d.GetOk("commands"); ok {
-			for _, cmd := range m.([]interface{}) {
-				commandList = append(commandList, fmt.Sprintf("-c 'tmsh %s'", cmd.(string)))
-			}
+	if d.Get("when").(string) != "apply" {
+		return nil
+	}
+	// only a change of the commands runs them again, the check, expected output and destroy commands are only recorded
+	if d.HasChanges("commands", "when", "shell") {
+		if err := bigipCommandApply(client, d); err != nil {
+			return diag.FromErr(err)
 		}
-		log.Printf("[INFO] Running TMSH Command : %v ", commandList)
-		var resultList []string
-		for _, str := range commandList {
-			commandConfig := &bigip.BigipCommand{
-				Command:     "run",
-				UtilCmdArgs: str,
-			}
-			resultCmd, err := client.RunCommand(commandConfig)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("error retrieving Command Result: %v", err))
-			}
-			resultList = append(resultList, resultCmd.CommandResult)
+		return nil
+	}
+	if d.HasChanges("check_commands") {
+		checkResult, err := bigipCommandCheck(client, d)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		_ = d.Set("command_result", resultList)
+		_ = d.Set("check_result", checkResult)
 	}
 	return nil
 }
 
 func resourceBigipCommandDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	var commandList []string
+	var commands []interface{}
 	if d.Get("when").(string) == "destroy" {
-		if m, ok := d.GetOk("commands"); ok {
-			for _, cmd := range m.([]interface{}) {
-				commandList = append(commandList, fmt.Sprintf("-c 'tmsh %s'", cmd.(string)))
-			}
+		commands = d.Get("commands").([]interface{})
+	}
+	commands = append(commands, d.Get("destroy_commands").([]interface{})...)
+	log.Printf("[INFO] Running Delete Commands: %v ", commands)
+	if _, err := runBigipCommands(client, d.Get("shell").(string), commands); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// bigipCommandApply runs the commands, checks their output against
+// expected_output and records the output of the check commands.
+func bigipCommandApply(client *bigip.BigIP, d *schema.ResourceData) error {
+	resultList, err := runBigipCommands(client, d.Get("shell").(string), d.Get("commands").([]interface{}))
+	if err != nil {
+		return err
+	}
+	_ = d.Set("command_result", resultList)
+	if expected := d.Get("expected_output").(string); expected != "" {
+		if output := strings.Join(resultList, "\n"); !regexp.MustCompile(expected).MatchString(output) {
+			return fmt.Errorf("output of the commands does not match %s: %s", expected, output)
 		}
-		log.Printf("[INFO] Running Delete TMSH Command: %v ", commandList)
+	}
+	checkResult, err := bigipCommandCheck(client, d)
+	if err != nil {
+		return err
+	}
+	if checkOutput := d.Get("check_output").(string); checkOutput != "" && !regexp.MustCompile(checkOutput).MatchString(checkResult) {
+		return fmt.Errorf("output of the check commands does not match %s after running the commands: %s", checkOutput, checkResult)
+	}
+	_ = d.Set("check_result", checkResult)
+	return nil
+}
 
-		for _, str := range commandList {
-			log.Printf("[INFO] Command to run:%v", str)
-			commandConfig := &bigip.BigipCommand{
-				Command:     "run",
-				UtilCmdArgs: str,
-			}
-			log.Printf("[INFO] Command struct:%+v", commandConfig)
-			resultCmd, err := client.RunCommand(commandConfig)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("error retrieving Command Result: %v", err))
-			}
-			log.Printf("[INFO] Result Command struct:%+v", resultCmd)
+// bigipCommandCheck returns the output of the check commands, one line per command.
+func bigipCommandCheck(client *bigip.BigIP, d *schema.ResourceData) (string, error) {
+	resultList, err := runBigipCommands(client, d.Get("shell").(string), d.Get("check_commands").([]interface{}))
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(resultList, "\n"), nil
+}
 
+// runBigipCommands runs the commands in shell, tmsh when empty, through util bash and returns their output.
+func runBigipCommands(client *bigip.BigIP, shell string, commands []interface{}) ([]string, error) {
+	var resultList []string
+	for _, cmd := range commands {
+		// Handle edge case where command contains our quote character
+		escapedCmd := strings.ReplaceAll(cmd.(string), "'", "'\\''")
+		if shell != "bash" {
+			escapedCmd = "tmsh " + escapedCmd
+		}
+		commandConfig := &bigip.BigipCommand{
+			Command:     "run",
+			UtilCmdArgs: fmt.Sprintf("-c '%s'", escapedCmd),
+		}
+		log.Printf("[INFO] Command to run:%v", commandConfig.UtilCmdArgs)
+		resultCmd, err := client.RunCommand(commandConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Command Result: %v", err)
 		}
+		resultList = append(resultList, resultCmd.CommandResult)
 	}
-
-	d.SetId("")
-	return nil
+	return resultList, nil
 }
@@ -6,10 +6,13 @@ If a copy of the MPL was not distributed with this file, You can obtain one at h
 package bigip
 
 import (
+	"fmt"
 	"regexp"
 	"testing"
 
+	bigip "github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var TestCommandResource = `
@@ -36,3 +39,47 @@ func TestAccBigipCommand_run(t *testing.T) {
 		},
 	})
 }
+
+var testCommandCheckResource = `
+resource "bigip_command" "test-check" {
+  commands         = ["create ltm node 10.10.10.71"]
+  check_commands   = ["list ltm node 10.10.10.71"]
+  check_output     = "address 10\\.10\\.10\\.71"
+  destroy_commands = ["delete ltm node 10.10.10.71"]
+}
+`
+
+func TestAccBigipCommand_check(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckCommandNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testCommandCheckResource,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeExists("/Common/10.10.10.71"),
+					resource.TestMatchResourceAttr("bigip_command.test-check", "check_result", regexp.MustCompile("address 10.10.10.71")),
+				),
+			},
+			{
+				Config:   testCommandCheckResource,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testCheckCommandNodeDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	node, err := client.GetNode("/Common/10.10.10.71")
+	if err != nil {
+		return err
+	}
+	if node != nil {
+		return fmt.Errorf("Node /Common/10.10.10.71 not deleted by the destroy commands")
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_command against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeCommandServer answers the util bash calls for the LTM nodes it keeps, and
+// the content of the files written with echo.
+type fakeCommandServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	nodes    map[string]bool
+	files    map[string]string
+	commands []string
+}
+
+var (
+	fakeCommandNode  = regexp.MustCompile(`^-c 'tmsh (create|delete|list) ltm node (\S+)'$`)
+	fakeCommandWrite = regexp.MustCompile(`^-c 'echo '\\''(.*)'\\'' > (\S+)'$`)
+	fakeCommandCat   = regexp.MustCompile(`^-c 'cat (\S+)'$`)
+)
+
+func newFakeCommandServer(t *testing.T) *fakeCommandServer {
+	f := &fakeCommandServer{nodes: make(map[string]bool), files: make(map[string]string)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		args := body["utilCmdArgs"]
+		f.commands = append(f.commands, args)
+		result := ""
+		switch {
+		case args == "-c 'tmsh show sys version'":
+			result = "\nSys::Version\nMain Package\n  Product     BIG-IP\n  Version     17.1.0\n"
+		case fakeCommandNode.MatchString(args):
+			m := fakeCommandNode.FindStringSubmatch(args)
+			switch {
+			case m[1] == "create" && f.nodes[m[2]]:
+				result = "01020066:3: The requested Node (/Common/" + m[2] + ") already exists in partition Common.\n"
+			case m[1] == "create":
+				f.nodes[m[2]] = true
+			case !f.nodes[m[2]]:
+				result = "01020036:3: The requested Node (/Common/" + m[2] + ") was not found.\n"
+			case m[1] == "delete":
+				delete(f.nodes, m[2])
+			default:
+				result = "ltm node " + m[2] + " {\n    address " + m[2] + "\n}\n"
+			}
+		case fakeCommandWrite.MatchString(args):
+			m := fakeCommandWrite.FindStringSubmatch(args)
+			f.files[m[2]] = m[1] + "\n"
+		case fakeCommandCat.MatchString(args):
+			result = f.files[fakeCommandCat.FindStringSubmatch(args)[1]]
+		default:
+			t.Errorf("unexpected command %s", args)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"command": "run", "utilCmdArgs": args, "commandResult": result})
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeCommandServer) ran(command string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, c := range f.commands {
+		if c == command {
+			n++
+		}
+	}
+	return n
+}
+
+func TestResourceBigipCommandRun(t *testing.T) {
+	f := newFakeCommandServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipCommand()
+
+	d := testResourceApply(t, r, nil, map[string]interface{}{
+		"commands":        []interface{}{"show sys version"},
+		"expected_output": "Product\\s+BIG-IP",
+	}, client)
+	assert.Equal(t, "apply", d.Id())
+	assert.Contains(t, d.Get("command_result.0"), "Version     17.1.0")
+
+	// without check commands the read keeps the resource
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "apply", d.Id())
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"commands":        []interface{}{"show sys version"},
+		"expected_output": "Product\\s+BIG-IQ",
+	}), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	failed, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diags := r.CreateContext(context.Background(), failed, client)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "does not match Product\\s+BIG-IQ")
+	}
+}
+
+func TestResourceBigipCommandCheckOutput(t *testing.T) {
+	f := newFakeCommandServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipCommand()
+	config := map[string]interface{}{
+		"commands":         []interface{}{"create ltm node 10.1.1.1"},
+		"check_commands":   []interface{}{"list ltm node 10.1.1.1"},
+		"check_output":     "address 10\\.1\\.1\\.1",
+		"destroy_commands": []interface{}{"delete ltm node 10.1.1.1"},
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.True(t, f.nodes["10.1.1.1"])
+	assert.Equal(t, 1, f.ran("-c 'tmsh create ltm node 10.1.1.1'"))
+	assert.Contains(t, d.Get("check_result"), "address 10.1.1.1")
+
+	// the node is there, the commands do not run again
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "apply", d.Id())
+	again := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, 1, f.ran("-c 'tmsh create ltm node 10.1.1.1'"), "a node in place is not created again")
+	assert.Empty(t, again.Get("command_result"))
+
+	// the node was deleted out of band, the read removes the resource to run the commands again
+	delete(f.nodes, "10.1.1.1")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+
+	d = testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, 2, f.ran("-c 'tmsh create ltm node 10.1.1.1'"))
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.nodes)
+}
+
+func TestResourceBigipCommandTriggersSkipCheck(t *testing.T) {
+	f := newFakeCommandServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipCommand()
+	config := map[string]interface{}{
+		"commands":       []interface{}{"create ltm node 10.1.1.2"},
+		"check_commands": []interface{}{"list ltm node 10.1.1.2"},
+		"check_output":   "address 10\\.1\\.1\\.2",
+		"triggers":       map[string]interface{}{"version": "1"},
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, 1, f.ran("-c 'tmsh create ltm node 10.1.1.2'"))
+
+	// replacing the resource for new triggers runs the commands although the check matches
+	config["triggers"] = map[string]interface{}{"version": "2"}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.True(t, diff.RequiresNew())
+	d = testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, 2, f.ran("-c 'tmsh create ltm node 10.1.1.2'"))
+	assert.Contains(t, d.Get("check_result"), "address 10.1.1.2")
+}
+
+func TestResourceBigipCommandBash(t *testing.T) {
+	f := newFakeCommandServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipCommand()
+	config := map[string]interface{}{
+		"shell":          "bash",
+		"commands":       []interface{}{"echo 'banner v1' > /config/banner"},
+		"check_commands": []interface{}{"cat /config/banner"},
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, "banner v1\n", f.files["/config/banner"])
+	assert.Equal(t, "banner v1\n", d.Get("check_result"))
+
+	// without check_output a change of the output since the last run is a drift
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "apply", d.Id())
+	f.files["/config/banner"] = "edited\n"
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+
+	// only a change of the commands runs them on update
+	d = testResourceApply(t, r, nil, config, client)
+	config["check_commands"] = []interface{}{"cat /config/banner", "cat /config/motd"}
+	d = testResourceApply(t, r, d.State(), config, client)
+	assert.Equal(t, 2, f.ran("-c 'echo '\\''banner v1'\\'' > /config/banner'"))
+	assert.Equal(t, "banner v1\n\n", d.Get("check_result"))
+	config["commands"] = []interface{}{"echo 'banner v2' > /config/banner"}
+	d = testResourceApply(t, r, d.State(), config, client)
+	assert.Equal(t, "banner v2\n", f.files["/config/banner"])
+	assert.Equal(t, []interface{}{""}, d.Get("command_result"))
+
+	// a change of the triggers replaces the resource
+	config["triggers"] = map[string]interface{}{"version": "2"}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.True(t, diff.RequiresNew())
+}
@@ -0,0 +1,337 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var dnsCacheTypes = []string{"transparent", "resolver", "validating-resolver"}
+
+// dnsCacheResolverFields only apply to resolver and validating-resolver caches
+var dnsCacheResolverFields = []string{"route_domain", "use_ipv4", "use_ipv6", "use_tcp", "use_udp", "forward_zones"}
+
+func resourceBigipDnsCache() *schema.Resource {
+	yesNoValues := validation.StringInSlice([]string{"yes", "no"}, false)
+	return &schema.Resource{
+		CreateContext: resourceBigipDnsCacheCreate,
+		ReadContext:   resourceBigipDnsCacheRead,
+		UpdateContext: resourceBigipDnsCacheUpdate,
+		DeleteContext: resourceBigipDnsCacheDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBigipDnsCacheImport,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			var configured []string
+			raw := d.GetRawConfig()
+			fields := append([]string{"key_cache_size"}, dnsCacheResolverFields...)
+			for _, field := range fields {
+				if v := raw.GetAttr(field); ctyValIsSet(v) && !(v.Type().IsListType() && v.LengthInt() == 0) {
+					configured = append(configured, field)
+				}
+			}
+			return validateDnsCacheFields(d.Get("type").(string), configured)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the DNS cache",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the DNS cache",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(dnsCacheTypes, false),
+				Description:  "Type of the DNS cache: transparent, resolver or validating-resolver",
+			},
+			"answer_default_zones": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: yesNoValues,
+				Description:  "Answer queries for default zones such as localhost and reverse 127.0.0.1",
+			},
+			"max_concurrent_queries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum number of concurrent distinct queries",
+			},
+			"max_concurrent_tcp": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum number of concurrent TCP flows",
+			},
+			"max_concurrent_udp": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum number of concurrent UDP flows",
+			},
+			"msg_cache_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum size in bytes of the DNS message cache",
+			},
+			"rrset_cache_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum size in bytes of the resource record set cache",
+			},
+			"nameserver_cache_count": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum number of nameservers cached",
+			},
+			"key_cache_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum size in bytes of the DNSSEC key cache, validating-resolver only",
+			},
+			"route_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Route domain used for outbound resolution, resolver types only",
+			},
+			"use_ipv4": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: yesNoValues,
+				Description:  "Resolve over IPv4, resolver types only",
+			},
+			"use_ipv6": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: yesNoValues,
+				Description:  "Resolve over IPv6, resolver types only",
+			},
+			"use_tcp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: yesNoValues,
+				Description:  "Resolve over TCP, resolver types only",
+			},
+			"use_udp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: yesNoValues,
+				Description:  "Resolve over UDP, resolver types only",
+			},
+			"forward_zones": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Zones whose queries are forwarded to specific nameservers, resolver types only",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Domain name of the forward zone",
+						},
+						"nameservers": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Nameservers to forward to, in address:port form, e.g. 10.1.1.1:53",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateDnsCacheFields rejects configuration that does not apply to the
+// chosen cache type, so the mistake is reported at plan time instead of as an
+// opaque error from BIG-IP.
+func validateDnsCacheFields(cacheType string, configured []string) error {
+	var invalid []string
+	for _, field := range configured {
+		switch {
+		case field == "key_cache_size" && cacheType != "validating-resolver":
+			invalid = append(invalid, field)
+		case cacheType == "transparent" && contains(dnsCacheResolverFields, field):
+			invalid = append(invalid, field)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%s not supported by a %s DNS cache", strings.Join(invalid, ", "), cacheType)
+	}
+	return nil
+}
+
+func resourceBigipDnsCacheCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+	cacheType := d.Get("type").(string)
+
+	log.Printf("[INFO] Creating %s DNS cache: %s in partition %s", cacheType, name, partition)
+
+	cache := getDnsCacheConfig(d)
+	cache.Name = name
+	cache.Partition = partition
+
+	err := client.CreateDNSCache(cacheType, cache)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating DNS cache %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipDnsCacheRead(ctx, d, meta)
+}
+
+func resourceBigipDnsCacheRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	cacheType := d.Get("type").(string)
+	log.Printf("[INFO] Reading %s DNS cache: %s", cacheType, fullPath)
+
+	cache, err := client.GetDNSCache(cacheType, fullPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "01020036") {
+			log.Printf("[WARN] DNS cache %s not found, removing from state", fullPath)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error retrieving DNS cache %s: %v", fullPath, err))
+	}
+	if cache == nil {
+		log.Printf("[WARN] DNS cache %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	}
+	d.Set("answer_default_zones", cache.AnswerDefaultZones)
+	d.Set("max_concurrent_queries", cache.MaxConcurrentQueries)
+	d.Set("max_concurrent_tcp", cache.MaxConcurrentTcp)
+	d.Set("max_concurrent_udp", cache.MaxConcurrentUdp)
+	d.Set("msg_cache_size", cache.MsgCacheSize)
+	d.Set("rrset_cache_size", cache.RrsetCacheSize)
+	d.Set("nameserver_cache_count", cache.NameserverCacheCount)
+	d.Set("key_cache_size", cache.KeyCacheSize)
+	d.Set("route_domain", cache.RouteDomain)
+	d.Set("use_ipv4", cache.UseIpv4)
+	d.Set("use_ipv6", cache.UseIpv6)
+	d.Set("use_tcp", cache.UseTcp)
+	d.Set("use_udp", cache.UseUdp)
+
+	var forwardZones []interface{}
+	for _, zone := range cache.ForwardZones {
+		var nameservers []string
+		for _, ns := range zone.Nameservers {
+			nameservers = append(nameservers, ns.Name)
+		}
+		forwardZones = append(forwardZones, map[string]interface{}{
+			"name":        zone.Name,
+			"nameservers": nameservers,
+		})
+	}
+	d.Set("forward_zones", forwardZones)
+
+	return nil
+}
+
+func resourceBigipDnsCacheUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	cacheType := d.Get("type").(string)
+	log.Printf("[INFO] Updating %s DNS cache: %s", cacheType, fullPath)
+
+	err := client.ModifyDNSCache(cacheType, fullPath, getDnsCacheConfig(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating DNS cache %s: %v", fullPath, err))
+	}
+
+	return resourceBigipDnsCacheRead(ctx, d, meta)
+}
+
+func resourceBigipDnsCacheDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	cacheType := d.Get("type").(string)
+	log.Printf("[INFO] Deleting %s DNS cache: %s", cacheType, fullPath)
+
+	err := client.DeleteDNSCache(cacheType, fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting DNS cache %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipDnsCacheImport accepts an id of the form <type>:/<partition>/<name>,
+// since the cache type is part of the REST path.
+func resourceBigipDnsCacheImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || !contains(dnsCacheTypes, parts[0]) {
+		return nil, fmt.Errorf("invalid DNS cache import id %q, expected <type>:/<partition>/<name> where type is one of %v", d.Id(), dnsCacheTypes)
+	}
+	d.Set("type", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func getDnsCacheConfig(d *schema.ResourceData) *bigip.DNSCache {
+	cache := &bigip.DNSCache{
+		AnswerDefaultZones:   d.Get("answer_default_zones").(string),
+		MaxConcurrentQueries: d.Get("max_concurrent_queries").(int),
+		MaxConcurrentTcp:     d.Get("max_concurrent_tcp").(int),
+		MaxConcurrentUdp:     d.Get("max_concurrent_udp").(int),
+		MsgCacheSize:         d.Get("msg_cache_size").(int),
+		RrsetCacheSize:       d.Get("rrset_cache_size").(int),
+		NameserverCacheCount: d.Get("nameserver_cache_count").(int),
+		KeyCacheSize:         d.Get("key_cache_size").(int),
+		RouteDomain:          d.Get("route_domain").(string),
+		UseIpv4:              d.Get("use_ipv4").(string),
+		UseIpv6:              d.Get("use_ipv6").(string),
+		UseTcp:               d.Get("use_tcp").(string),
+		UseUdp:               d.Get("use_udp").(string),
+	}
+	for _, z := range d.Get("forward_zones").([]interface{}) {
+		zone := z.(map[string]interface{})
+		forwardZone := bigip.DNSCacheForwardZone{Name: zone["name"].(string)}
+		for _, ns := range listToStringSlice(zone["nameservers"].([]interface{})) {
+			forwardZone.Nameservers = append(forwardZone.Nameservers, bigip.DNSCacheForwardZoneNameserver{Name: ns})
+		}
+		cache.ForwardZones = append(cache.ForwardZones, forwardZone)
+	}
+	return cache
+}
@@ -0,0 +1,160 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipDnsNameserver() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipDnsNameserverCreate,
+		ReadContext:   resourceBigipDnsNameserverRead,
+		UpdateContext: resourceBigipDnsNameserverUpdate,
+		DeleteContext: resourceBigipDnsNameserverDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the DNS nameserver",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the DNS nameserver",
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "IP address of the nameserver",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      53,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Service port of the nameserver",
+			},
+			"route_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Route domain used to reach the nameserver, e.g. /Common/0",
+			},
+			"tsig_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Full path of the TSIG key used to authenticate zone transfers with this nameserver",
+			},
+		},
+	}
+}
+
+func resourceBigipDnsNameserverCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating DNS nameserver: %s in partition %s", name, partition)
+
+	ns := getDnsNameserverConfig(d)
+	ns.Name = name
+	ns.Partition = partition
+
+	err := client.CreateDNSNameserver(ns)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating DNS nameserver %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipDnsNameserverRead(ctx, d, meta)
+}
+
+func resourceBigipDnsNameserverRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading DNS nameserver: %s", fullPath)
+
+	ns, err := client.GetDNSNameserver(fullPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "01020036") {
+			log.Printf("[WARN] DNS nameserver %s not found, removing from state", fullPath)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error retrieving DNS nameserver %s: %v", fullPath, err))
+	}
+	if ns == nil {
+		log.Printf("[WARN] DNS nameserver %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	}
+	d.Set("address", ns.Address)
+	d.Set("port", ns.Port)
+	d.Set("route_domain", ns.RouteDomain)
+	d.Set("tsig_key", ns.TsigKey)
+
+	return nil
+}
+
+func resourceBigipDnsNameserverUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating DNS nameserver: %s", fullPath)
+
+	err := client.ModifyDNSNameserver(fullPath, getDnsNameserverConfig(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating DNS nameserver %s: %v", fullPath, err))
+	}
+
+	return resourceBigipDnsNameserverRead(ctx, d, meta)
+}
+
+func resourceBigipDnsNameserverDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting DNS nameserver: %s", fullPath)
+
+	err := client.DeleteDNSNameserver(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting DNS nameserver %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getDnsNameserverConfig(d *schema.ResourceData) *bigip.DNSNameserver {
+	return &bigip.DNSNameserver{
+		Address:     d.Get("address").(string),
+		Port:        d.Get("port").(int),
+		RouteDomain: d.Get("route_domain").(string),
+		TsigKey:     d.Get("tsig_key").(string),
+	}
+}
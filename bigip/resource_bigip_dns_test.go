@@ -0,0 +1,283 @@
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var TEST_DNS_ZONE_NAME = "tfexample.com"
+
+func TestAccBigipDnsZone_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckDnsZoneDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipDnsZoneConfig("consume"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckDnsZoneExists(TEST_DNS_ZONE_NAME),
+					resource.TestCheckResourceAttr("bigip_dns_zone.test-zone", "name", TEST_DNS_ZONE_NAME),
+					resource.TestCheckResourceAttr("bigip_dns_zone.test-zone", "dns_express_server", "/Common/tf_primary_ns"),
+					resource.TestCheckResourceAttr("bigip_dns_zone.test-zone", "server_tsig_key", "/Common/tf_tsig"),
+					resource.TestCheckResourceAttr("bigip_dns_zone.test-zone", "dns_express_notify_action", "consume"),
+					resource.TestCheckResourceAttr("bigip_dns_tsig_key.test-tsig", "algorithm", "hmacsha256"),
+				),
+			},
+			{
+				Config: testAccBigipDnsZoneConfig("repeat"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_dns_zone.test-zone", "dns_express_notify_action", "repeat"),
+				),
+			},
+			{
+				ResourceName:      "bigip_dns_zone.test-zone",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("/Common/%s", TEST_DNS_ZONE_NAME),
+			},
+		},
+	})
+}
+
+func TestAccBigipDnsTsigKey_invalidAlgorithm(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "bigip_dns_tsig_key" "bad" {
+  name      = "tf_bad_tsig"
+  algorithm = "hmac-sha256"
+  secret    = "c2VjcmV0"
+}
+`,
+				ExpectError: regexp.MustCompile(`BIG-IP names this algorithm "hmacsha256"`),
+			},
+		},
+	})
+}
+
+func TestAccBigipDnsCache_resolver(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckDnsCacheDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipDnsCacheConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_dns_cache.test-cache", "type", "resolver"),
+					resource.TestCheckResourceAttr("bigip_dns_cache.test-cache", "forward_zones.#", "1"),
+					resource.TestCheckResourceAttr("bigip_dns_cache.test-cache", "forward_zones.0.nameservers.0", "10.10.10.53:53"),
+				),
+			},
+			{
+				ResourceName:      "bigip_dns_cache.test-cache",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "resolver:/Common/tf_resolver",
+			},
+		},
+	})
+}
+
+func TestAccBigipDnsCache_transparentRejectsResolverFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "bigip_dns_cache" "bad" {
+  name         = "tf_transparent"
+  type         = "transparent"
+  route_domain = "/Common/0"
+}
+`,
+				ExpectError: regexp.MustCompile(`route_domain not supported by a transparent DNS cache`),
+			},
+		},
+	})
+}
+
+func TestAccBigipDnssec_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckDnssecDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipDnssecConfig(86400),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_dnssec_key.zsk", "key_type", "zsk"),
+					resource.TestCheckResourceAttr("bigip_dnssec_key.zsk", "rollover_period", "86400"),
+					resource.TestCheckResourceAttr("bigip_dnssec_zone.test-zone", "keys.#", "2"),
+				),
+			},
+			{
+				Config: testAccBigipDnssecConfig(172800),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_dnssec_key.zsk", "rollover_period", "172800"),
+				),
+			},
+			{
+				ResourceName:      "bigip_dnssec_key.zsk",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "/Common/tf_zsk",
+			},
+			{
+				ResourceName:      "bigip_dnssec_zone.test-zone",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "/Common/tfsigned.com",
+			},
+		},
+	})
+}
+
+func testCheckDnsZoneExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+		fullPath := fmt.Sprintf("/Common/%s", name)
+
+		zone, err := client.GetDNSZone(fullPath)
+		if err != nil {
+			return err
+		}
+		if zone == nil {
+			return fmt.Errorf("DNS Express zone %s does not exist", fullPath)
+		}
+		return nil
+	}
+}
+
+func testCheckDnsZoneDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_dns_zone" {
+			continue
+		}
+		zone, err := client.GetDNSZone(rs.Primary.ID)
+		if err == nil && zone != nil {
+			return fmt.Errorf("DNS Express zone %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testCheckDnsCacheDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_dns_cache" {
+			continue
+		}
+		cache, err := client.GetDNSCache(rs.Primary.Attributes["type"], rs.Primary.ID)
+		if err == nil && cache != nil {
+			return fmt.Errorf("DNS cache %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testCheckDnssecDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "bigip_dnssec_zone":
+			zone, err := client.GetDNSSECZone(rs.Primary.ID)
+			if err == nil && zone != nil {
+				return fmt.Errorf("DNSSEC zone %s still exists", rs.Primary.ID)
+			}
+		case "bigip_dnssec_key":
+			key, err := client.GetDNSSECKey(rs.Primary.ID)
+			if err == nil && key != nil {
+				return fmt.Errorf("DNSSEC key %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccBigipDnsZoneConfig(notifyAction string) string {
+	return fmt.Sprintf(`
+resource "bigip_dns_tsig_key" "test-tsig" {
+  name      = "tf_tsig"
+  algorithm = "hmacsha256"
+  secret    = "dGVycmFmb3JtLXRzaWctc2VjcmV0"
+}
+
+resource "bigip_dns_nameserver" "primary" {
+  name     = "tf_primary_ns"
+  address  = "10.10.10.53"
+  tsig_key = bigip_dns_tsig_key.test-tsig.id
+}
+
+resource "bigip_dns_zone" "test-zone" {
+  name                      = "%s"
+  dns_express_server        = bigip_dns_nameserver.primary.id
+  server_tsig_key           = bigip_dns_tsig_key.test-tsig.id
+  dns_express_notify_action = "%s"
+  dns_express_allow_notify  = ["10.10.10.54"]
+}
+`, TEST_DNS_ZONE_NAME, notifyAction)
+}
+
+func testAccBigipDnsCacheConfig() string {
+	return `
+resource "bigip_dns_cache" "test-cache" {
+  name     = "tf_resolver"
+  type     = "resolver"
+  use_ipv6 = "no"
+
+  forward_zones {
+    name        = "corp.example.com"
+    nameservers = ["10.10.10.53:53"]
+  }
+}
+`
+}
+
+func testAccBigipDnssecConfig(rollover int) string {
+	return fmt.Sprintf(`
+resource "bigip_dnssec_key" "zsk" {
+  name                         = "tf_zsk"
+  key_type                     = "zsk"
+  rollover_period              = %d
+  expiration_period            = 604800
+  signature_validity_period    = 604800
+  signature_publication_period = 345600
+}
+
+resource "bigip_dnssec_key" "ksk" {
+  name      = "tf_ksk"
+  key_type  = "ksk"
+  bit_width = 2048
+}
+
+resource "bigip_dnssec_zone" "test-zone" {
+  name = "tfsigned.com"
+  keys = [bigip_dnssec_key.zsk.id, bigip_dnssec_key.ksk.id]
+}
+`, rollover)
+}
@@ -0,0 +1,144 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipDnsTsigKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipDnsTsigKeyCreate,
+		ReadContext:   resourceBigipDnsTsigKeyRead,
+		UpdateContext: resourceBigipDnsTsigKeyUpdate,
+		DeleteContext: resourceBigipDnsTsigKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the TSIG key",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the TSIG key",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "hmacmd5",
+				ValidateFunc: validateTsigAlgorithm,
+				Description:  "Algorithm of the TSIG key: hmacmd5, hmacsha1 or hmacsha256",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Base64 encoded shared secret of the TSIG key",
+			},
+		},
+	}
+}
+
+func resourceBigipDnsTsigKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating DNS TSIG key: %s in partition %s", name, partition)
+
+	key := &bigip.DNSTsigKey{
+		Name:      name,
+		Partition: partition,
+		Algorithm: d.Get("algorithm").(string),
+		Secret:    d.Get("secret").(string),
+	}
+
+	err := client.CreateDNSTsigKey(key)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating DNS TSIG key %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipDnsTsigKeyRead(ctx, d, meta)
+}
+
+func resourceBigipDnsTsigKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading DNS TSIG key: %s", fullPath)
+
+	key, err := client.GetDNSTsigKey(fullPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "01020036") {
+			log.Printf("[WARN] DNS TSIG key %s not found, removing from state", fullPath)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error retrieving DNS TSIG key %s: %v", fullPath, err))
+	}
+	if key == nil {
+		log.Printf("[WARN] DNS TSIG key %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	}
+	d.Set("algorithm", key.Algorithm)
+	// The secret is returned encrypted; keep the configured value in state
+
+	return nil
+}
+
+func resourceBigipDnsTsigKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating DNS TSIG key: %s", fullPath)
+
+	key := &bigip.DNSTsigKey{
+		Algorithm: d.Get("algorithm").(string),
+		Secret:    d.Get("secret").(string),
+	}
+
+	err := client.ModifyDNSTsigKey(fullPath, key)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating DNS TSIG key %s: %v", fullPath, err))
+	}
+
+	return resourceBigipDnsTsigKeyRead(ctx, d, meta)
+}
+
+func resourceBigipDnsTsigKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting DNS TSIG key: %s", fullPath)
+
+	err := client.DeleteDNSTsigKey(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting DNS TSIG key %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Unit tests for DNS Express, DNS cache and DNSSEC resources - no F5 BIG-IP connection required
+
+func TestValidateDnsCacheFields(t *testing.T) {
+	cases := []struct {
+		cacheType  string
+		configured []string
+		wantErr    bool
+	}{
+		{"transparent", nil, false},
+		{"transparent", []string{"route_domain"}, true},
+		{"transparent", []string{"forward_zones", "use_tcp"}, true},
+		{"transparent", []string{"key_cache_size"}, true},
+		{"resolver", []string{"route_domain", "forward_zones"}, false},
+		{"resolver", []string{"key_cache_size"}, true},
+		{"validating-resolver", []string{"key_cache_size", "use_ipv6"}, false},
+	}
+	for _, c := range cases {
+		err := validateDnsCacheFields(c.cacheType, c.configured)
+		if (err != nil) != c.wantErr {
+			t.Errorf("validateDnsCacheFields(%s, %v): expected error %v, got %v", c.cacheType, c.configured, c.wantErr, err)
+		}
+	}
+}
+
+func TestValidateDnssecKeyPeriods(t *testing.T) {
+	if err := validateDnssecKeyPeriods(0, 0, 0, 0); err != nil {
+		t.Errorf("unset periods should be accepted, got %v", err)
+	}
+	if err := validateDnssecKeyPeriods(86400, 172800, 604800, 345600); err != nil {
+		t.Errorf("consistent periods should be accepted, got %v", err)
+	}
+	if err := validateDnssecKeyPeriods(172800, 86400, 0, 0); err == nil {
+		t.Error("expected an error when rollover_period is not less than expiration_period")
+	}
+	if err := validateDnssecKeyPeriods(0, 0, 345600, 604800); err == nil {
+		t.Error("expected an error when signature_publication_period is not less than signature_validity_period")
+	}
+}
+
+func TestResourceBigipDnsCacheImport(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceBigipDnsCache().Schema, map[string]interface{}{})
+	d.SetId("validating-resolver:/Common/cache1")
+
+	result, err := resourceBigipDnsCacheImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if result[0].Id() != "/Common/cache1" {
+		t.Errorf("expected id /Common/cache1, got %s", result[0].Id())
+	}
+	if result[0].Get("type").(string) != "validating-resolver" {
+		t.Errorf("expected type validating-resolver, got %s", result[0].Get("type"))
+	}
+
+	for _, id := range []string{"/Common/cache1", "forwarder:/Common/cache1"} {
+		d.SetId(id)
+		if _, err := resourceBigipDnsCacheImport(context.Background(), d, nil); err == nil {
+			t.Errorf("expected an error importing %q", id)
+		}
+	}
+}
+
+func TestResourceBigipDnsTsigKeySecretIsSensitive(t *testing.T) {
+	s := resourceBigipDnsTsigKey().Schema
+	if !s["secret"].Sensitive {
+		t.Error("secret should be marked sensitive")
+	}
+	if s["algorithm"].ValidateFunc == nil {
+		t.Error("algorithm should be validated")
+	}
+}
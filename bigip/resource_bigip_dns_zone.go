@@ -0,0 +1,192 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipDnsZone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipDnsZoneCreate,
+		ReadContext:   resourceBigipDnsZoneRead,
+		UpdateContext: resourceBigipDnsZoneUpdate,
+		DeleteContext: resourceBigipDnsZoneDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the DNS Express zone, which is the zone's domain name, e.g. example.com",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the DNS Express zone",
+			},
+			"dns_express_server": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Full path of the DNS nameserver the zone is transferred from",
+			},
+			"dns_express_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Answer queries for the zone from DNS Express",
+			},
+			"dns_express_notify_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "consume",
+				ValidateFunc: validation.StringInSlice([]string{"consume", "bypass", "repeat"}, false),
+				Description:  "Action taken when a NOTIFY message is received for the zone",
+			},
+			"dns_express_allow_notify": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsIPAddress},
+				Description: "Additional IP addresses, besides the transfer source, allowed to send NOTIFY messages",
+			},
+			"dns_express_notify_tsig_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Verify the TSIG signature of NOTIFY messages",
+			},
+			"server_tsig_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Full path of the TSIG key used to authenticate zone transfers requested by BIG-IP",
+			},
+			"transfer_clients": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Full paths of DNS nameservers allowed to transfer the zone from BIG-IP",
+			},
+			"response_policy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Treat the zone as a response policy zone (RPZ)",
+			},
+		},
+	}
+}
+
+func resourceBigipDnsZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating DNS Express zone: %s in partition %s", name, partition)
+
+	zone := getDnsZoneConfig(d)
+	zone.Name = name
+	zone.Partition = partition
+
+	err := client.CreateDNSZone(zone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating DNS Express zone %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipDnsZoneRead(ctx, d, meta)
+}
+
+func resourceBigipDnsZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading DNS Express zone: %s", fullPath)
+
+	zone, err := client.GetDNSZone(fullPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "01020036") {
+			log.Printf("[WARN] DNS Express zone %s not found, removing from state", fullPath)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error retrieving DNS Express zone %s: %v", fullPath, err))
+	}
+	if zone == nil {
+		log.Printf("[WARN] DNS Express zone %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	}
+	d.Set("dns_express_server", zone.DNSExpressServer)
+	d.Set("dns_express_enabled", zone.DNSExpressEnabled == "yes")
+	d.Set("dns_express_notify_action", zone.DNSExpressNotifyAction)
+	d.Set("dns_express_allow_notify", zone.DNSExpressAllowNotify)
+	d.Set("dns_express_notify_tsig_verify", zone.DNSExpressNotifyTsigVerify == "yes")
+	d.Set("server_tsig_key", zone.ServerTsigKey)
+	d.Set("transfer_clients", zone.TransferClients)
+	d.Set("response_policy", zone.ResponsePolicy == "yes")
+
+	return nil
+}
+
+func resourceBigipDnsZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating DNS Express zone: %s", fullPath)
+
+	err := client.ModifyDNSZone(fullPath, getDnsZoneConfig(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating DNS Express zone %s: %v", fullPath, err))
+	}
+
+	return resourceBigipDnsZoneRead(ctx, d, meta)
+}
+
+func resourceBigipDnsZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting DNS Express zone: %s", fullPath)
+
+	err := client.DeleteDNSZone(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting DNS Express zone %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getDnsZoneConfig(d *schema.ResourceData) *bigip.DNSZone {
+	return &bigip.DNSZone{
+		DNSExpressServer:           d.Get("dns_express_server").(string),
+		DNSExpressEnabled:          yesNo(d.Get("dns_express_enabled").(bool)),
+		DNSExpressNotifyAction:     d.Get("dns_express_notify_action").(string),
+		DNSExpressAllowNotify:      listToStringSlice(d.Get("dns_express_allow_notify").([]interface{})),
+		DNSExpressNotifyTsigVerify: yesNo(d.Get("dns_express_notify_tsig_verify").(bool)),
+		ServerTsigKey:              d.Get("server_tsig_key").(string),
+		TransferClients:            listToStringSlice(d.Get("transfer_clients").([]interface{})),
+		ResponsePolicy:             yesNo(d.Get("response_policy").(bool)),
+	}
+}
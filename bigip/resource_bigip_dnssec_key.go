@@ -0,0 +1,271 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipDnssecKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipDnssecKeyCreate,
+		ReadContext:   resourceBigipDnssecKeyRead,
+		UpdateContext: resourceBigipDnssecKeyUpdate,
+		DeleteContext: resourceBigipDnssecKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return validateDnssecKeyPeriods(
+				d.Get("rollover_period").(int),
+				d.Get("expiration_period").(int),
+				d.Get("signature_validity_period").(int),
+				d.Get("signature_publication_period").(int),
+			)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the DNSSEC key",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the DNSSEC key",
+			},
+			"key_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"zsk", "ksk"}, false),
+				Description:  "Type of the key: zsk (zone signing key) or ksk (key signing key)",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "rsasha256",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"rsasha1", "rsasha256", "rsasha512"}, false),
+				Description:  "Signing algorithm of the key",
+			},
+			"bit_width": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1024,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{1024, 2048, 4096}),
+				Description:  "Length of the key in bits",
+			},
+			"key_management": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "automatic",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"automatic", "manual"}, false),
+				Description:  "Whether BIG-IP generates and rolls over key generations automatically",
+			},
+			"certificate_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Full path of the certificate, required when key_management is manual",
+			},
+			"key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Full path of the private key, required when key_management is manual",
+			},
+			"rollover_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds after creation at which a new key generation is created",
+			},
+			"expiration_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds after creation at which a key generation expires",
+			},
+			"signature_validity_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds for which a signature is valid",
+			},
+			"signature_publication_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds after which a signature is regenerated",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "TTL in seconds of the DNSKEY records",
+			},
+			"use_fips": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"disabled", "internal", "external"}, false),
+				Description:  "Generate the key in a FIPS device: disabled, internal (FIPS card) or external (HSM)",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable or disable the key",
+			},
+		},
+	}
+}
+
+// validateDnssecKeyPeriods checks the rollover settings against each other.
+// Zero means the value is left to BIG-IP and is not checked.
+func validateDnssecKeyPeriods(rollover, expiration, sigValidity, sigPublication int) error {
+	if rollover > 0 && expiration > 0 && rollover >= expiration {
+		return fmt.Errorf("rollover_period (%d) must be less than expiration_period (%d) so a new key generation exists before the old one expires", rollover, expiration)
+	}
+	if sigPublication > 0 && sigValidity > 0 && sigPublication >= sigValidity {
+		return fmt.Errorf("signature_publication_period (%d) must be less than signature_validity_period (%d)", sigPublication, sigValidity)
+	}
+	return nil
+}
+
+func resourceBigipDnssecKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating DNSSEC key: %s in partition %s", name, partition)
+
+	key := getDnssecKeyConfig(d)
+	key.Name = name
+	key.Partition = partition
+	key.KeyType = d.Get("key_type").(string)
+	key.Algorithm = d.Get("algorithm").(string)
+	key.BitWidth = d.Get("bit_width").(int)
+	key.KeyManagement = d.Get("key_management").(string)
+	key.UseFips = d.Get("use_fips").(string)
+
+	err := client.CreateDNSSECKey(key)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating DNSSEC key %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipDnssecKeyRead(ctx, d, meta)
+}
+
+func resourceBigipDnssecKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading DNSSEC key: %s", fullPath)
+
+	key, err := client.GetDNSSECKey(fullPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "01020036") {
+			log.Printf("[WARN] DNSSEC key %s not found, removing from state", fullPath)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error retrieving DNSSEC key %s: %v", fullPath, err))
+	}
+	if key == nil {
+		log.Printf("[WARN] DNSSEC key %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	}
+	d.Set("key_type", key.KeyType)
+	d.Set("algorithm", key.Algorithm)
+	d.Set("bit_width", key.BitWidth)
+	d.Set("key_management", key.KeyManagement)
+	d.Set("certificate_file", key.CertificateFile)
+	d.Set("key_file", key.KeyFile)
+	d.Set("rollover_period", key.RolloverPeriod)
+	d.Set("expiration_period", key.ExpirationPeriod)
+	d.Set("signature_validity_period", key.SignatureValidityPeriod)
+	d.Set("signature_publication_period", key.SignaturePublicationPeriod)
+	d.Set("ttl", key.TTL)
+	if key.UseFips != "" {
+		d.Set("use_fips", key.UseFips)
+	}
+	d.Set("enabled", !key.Disabled)
+
+	return nil
+}
+
+func resourceBigipDnssecKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating DNSSEC key: %s", fullPath)
+
+	err := client.ModifyDNSSECKey(fullPath, getDnssecKeyConfig(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating DNSSEC key %s: %v", fullPath, err))
+	}
+
+	return resourceBigipDnssecKeyRead(ctx, d, meta)
+}
+
+func resourceBigipDnssecKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting DNSSEC key: %s", fullPath)
+
+	err := client.DeleteDNSSECKey(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting DNSSEC key %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getDnssecKeyConfig returns the properties that can be modified after the
+// key has been created.
+func getDnssecKeyConfig(d *schema.ResourceData) *bigip.DNSSECKey {
+	enabled := d.Get("enabled").(bool)
+	return &bigip.DNSSECKey{
+		CertificateFile:            d.Get("certificate_file").(string),
+		KeyFile:                    d.Get("key_file").(string),
+		RolloverPeriod:             d.Get("rollover_period").(int),
+		ExpirationPeriod:           d.Get("expiration_period").(int),
+		SignatureValidityPeriod:    d.Get("signature_validity_period").(int),
+		SignaturePublicationPeriod: d.Get("signature_publication_period").(int),
+		TTL:                        d.Get("ttl").(int),
+		Enabled:                    enabled,
+		Disabled:                   !enabled,
+	}
+}
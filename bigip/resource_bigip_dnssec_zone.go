@@ -0,0 +1,145 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipDnssecZone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipDnssecZoneCreate,
+		ReadContext:   resourceBigipDnssecZoneRead,
+		UpdateContext: resourceBigipDnssecZoneUpdate,
+		DeleteContext: resourceBigipDnssecZoneDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the DNSSEC zone, which is the zone's domain name, e.g. example.com",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the DNSSEC zone",
+			},
+			"keys": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateF5NameWithDirectory},
+				Description: "Full paths of the DNSSEC keys (at least one zsk and one ksk) used to sign the zone",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable or disable signing of the zone",
+			},
+		},
+	}
+}
+
+func resourceBigipDnssecZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating DNSSEC zone: %s in partition %s", name, partition)
+
+	zone := getDnssecZoneConfig(d)
+	zone.Name = name
+	zone.Partition = partition
+
+	err := client.CreateDNSSECZone(zone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating DNSSEC zone %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipDnssecZoneRead(ctx, d, meta)
+}
+
+func resourceBigipDnssecZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading DNSSEC zone: %s", fullPath)
+
+	zone, err := client.GetDNSSECZone(fullPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "01020036") {
+			log.Printf("[WARN] DNSSEC zone %s not found, removing from state", fullPath)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error retrieving DNSSEC zone %s: %v", fullPath, err))
+	}
+	if zone == nil {
+		log.Printf("[WARN] DNSSEC zone %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	}
+	d.Set("keys", zone.Keys)
+	d.Set("enabled", !zone.Disabled)
+
+	return nil
+}
+
+func resourceBigipDnssecZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating DNSSEC zone: %s", fullPath)
+
+	err := client.ModifyDNSSECZone(fullPath, getDnssecZoneConfig(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating DNSSEC zone %s: %v", fullPath, err))
+	}
+
+	return resourceBigipDnssecZoneRead(ctx, d, meta)
+}
+
+func resourceBigipDnssecZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting DNSSEC zone: %s", fullPath)
+
+	err := client.DeleteDNSSECZone(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting DNSSEC zone %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getDnssecZoneConfig(d *schema.ResourceData) *bigip.DNSSECZone {
+	enabled := d.Get("enabled").(bool)
+	return &bigip.DNSSECZone{
+		Keys:     setToStringSlice(d.Get("keys").(*schema.Set)),
+		Enabled:  enabled,
+		Disabled: !enabled,
+	}
+}
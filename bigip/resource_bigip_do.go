@@ -6,15 +6,10 @@ If a copy of the MPL was not distributed with this file, You can obtain one at h
 package bigip
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"math"
-	"net/http"
 	"strings"
 	"time"
 
@@ -24,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBigipDo() *schema.Resource {
@@ -35,6 +31,7 @@ func resourceBigipDo() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceBigipDoCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"do_json": {
@@ -46,6 +43,12 @@ func resourceBigipDo() *schema.Resource {
 					return jsonString
 				},
 			},
+			"schema_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Version of DO installed on the target, used to select the schema do_json is validated against at plan time",
+				ValidateFunc: validation.StringMatch(doSchemaVersionRegex, "must be a DO version such as 1.12.0"),
+			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -90,18 +93,22 @@ func resourceBigipDo() *schema.Resource {
 	}
 }
 
-func resourceBigipDoCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clientBigip := meta.(*bigip.BigIP)
+// resourceBigipDoCustomizeDiff validates do_json against the bundled DO schema at plan time.
+func resourceBigipDoCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("do_json") || !d.NewValueKnown("schema_version") {
+		return nil
+	}
+	return validateDoDeclaration(d.Get("do_json").(string), d.Get("schema_version").(string))
+}
 
-	if d.Get("bigip_address").(string) != "" && d.Get("bigip_user").(string) != "" && d.Get("bigip_password").(string) != "" || d.Get("bigip_port").(string) != "" {
-		clientBigip2, err := connectBigIP(d)
-		if err != nil {
-			log.Printf("Connection to BIGIP Failed with :%v", err)
-			return diag.FromErr(err)
-		}
-		clientBigip = clientBigip2
+// doPollInterval is how often a running Declarative Onboarding task is polled.
+var doPollInterval = 1 * time.Second
+
+func resourceBigipDoCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clientBigip, err := doClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	doJson := d.Get("do_json").(string)
 	if !clientBigip.Teem {
 		id := uuid.New()
 		uniqueID := id.String()
@@ -120,402 +127,69 @@ func resourceBigipDoCreate(ctx context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
-	timeout := d.Get("timeout").(int)
-	timeoutSec := timeout * 60
-	log.Printf("[DEBUG]timeout_sec is :%d", timeoutSec)
-	log.Printf("[INFO] Creating do config in bigip:%s", doJson)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	client := &http.Client{Transport: tr}
-	url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/"
-	req, err := http.NewRequest("POST", url, strings.NewReader(doJson))
+	log.Printf("[INFO] Creating do config in bigip:%s", clientBigip.Host)
+	task, err := deployDo(clientBigip, d)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while creating http request with DO json:%v", err))
-	}
-	if clientBigip.Token != "" {
-		req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-	} else {
-		req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-
-	log.Printf("[INFO] URL:%s", clientBigip.Host)
-
-	resp, err := client.Do(req)
-
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("[DEBUG] Could not close the request to %s", url)
-		}
-	}()
-
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while receiving  http response with DO json:%v", err))
-	}
-	// body, err := os.ReadAll(resp.Body)
-	var body bytes.Buffer
-	_, err = io.Copy(&body, resp.Body)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while reading http response with DO json:%v", err))
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode > 202 {
-		return diag.FromErr(fmt.Errorf("Error while Sending/Posting http request with DO json :%s  %v", body.String(), err))
-	}
-	respRef := make(map[string]interface{})
-	if err := json.Unmarshal(body.Bytes(), &respRef); err != nil {
-		return diag.FromErr(err)
-	}
-	respID := respRef["id"].(string)
-
-	var doSuccess = false
-
-	if resp.StatusCode == 200 {
-		log.Printf("[DEBUG] response status is 200 ok and no aysnc flag in declaration")
-		doSuccess = true
-		d.SetId(respID)
-	}
-
-	if resp.StatusCode == http.StatusAccepted {
-		start := time.Now()
-	forLoop:
-		for time.Since(start).Seconds() < float64(timeoutSec) {
-			log.Printf("[DEBUG]Value of Timeout counter in seconds :%v", math.Ceil(time.Since(start).Seconds()))
-			url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-			req, _ := http.NewRequest("GET", url, nil)
-			if clientBigip.Token != "" {
-				req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-			} else {
-				req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-			}
-			req.Header.Set("Accept", "application/json")
-			req.Header.Set("Content-Type", "application/json")
-			taskResp, err := client.Do(req)
-			if taskResp == nil {
-				log.Printf("[DEBUG]taskResp of DO is empty,but continue the loop until timeout \n")
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			defer taskResp.Body.Close()
-			if err != nil {
-				log.Printf("[DEBUG]Polling the task id until the timeout")
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			switch {
-			case taskResp.StatusCode == 200:
-				var body bytes.Buffer
-				_, err = io.Copy(&body, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(body.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				log.Printf("[DEBUG] Got success and setting state id")
-				doSuccess = true
-				d.SetId(respID)
-				break forLoop
-			case taskResp.StatusCode == 202:
-				var respBody bytes.Buffer
-				_, err = io.Copy(&respBody, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				resultMap := respRef1["result"]
-				if resultMap.(map[string]interface{})["status"] != "RUNNING" {
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", resultMap))
-				}
-			default:
-				log.Printf("StatusCode:%+v", taskResp.StatusCode)
-			}
-			time.Sleep(1 * time.Second)
-		}
-	}
-
-	if !doSuccess {
-		log.Printf("[DEBUG] Didn't get successful response within timeout")
-		url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-		req, _ := http.NewRequest("GET", url, nil)
-		if clientBigip.Token != "" {
-			req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-		} else {
-			req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-		}
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Content-Type", "application/json")
-		taskResp, err := client.Do(req)
-		if taskResp == nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("timedout while polling the DO task id with error :%v", err))
-		}
-		defer taskResp.Body.Close()
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("timedout while polling the DO task id with error :%v", err))
-		}
-		var respBody bytes.Buffer
-		_, err = io.Copy(&respBody, taskResp.Body)
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("timedout while polling the DO task id with error :%v", err))
-		}
-		respRef2 := make(map[string]interface{})
-		if err := json.Unmarshal(respBody.Bytes(), &respRef2); err != nil {
-			return diag.FromErr(err)
-		}
-		log.Printf("[DEBUG] timeout resp_body is :%v", respRef2)
-		resultMap := respRef2["result"]
 		d.SetId("")
-		return diag.FromErr(fmt.Errorf("timeout while polling the DO task id with result:%v", resultMap))
+		return diag.FromErr(err)
 	}
+	d.SetId(task.ID)
 
 	return resourceBigipDoRead(ctx, d, meta)
 }
 
 func resourceBigipDoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clientBigip := meta.(*bigip.BigIP)
-	if d.Get("bigip_address").(string) != "" && d.Get("bigip_user").(string) != "" && d.Get("bigip_password").(string) != "" || d.Get("bigip_port").(string) != "" {
-		clientBigip2, err := connectBigIP(d)
-		if err != nil {
-			log.Printf("Connection to BIGIP Failed with :%v", err)
-			return diag.FromErr(err)
-		}
-		clientBigip = clientBigip2
-	}
-	log.Printf("[INFO] Reading Do config")
-	ID := d.Id()
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	client := &http.Client{Transport: tr}
-	url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + ID
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while creating http request for reading Do config:%v", err))
-	}
-	if clientBigip.Token != "" {
-		req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-	} else {
-		req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
-
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("[DEBUG] Could not close the request to %s", url)
-		}
-	}()
-
+	clientBigip, err := doClient(d, meta)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while receiving http response body in read call :%v ", err))
+		return diag.FromErr(err)
 	}
-	var respBody bytes.Buffer
-	_, err = io.Copy(&respBody, resp.Body)
-	// respBody, err := ioutil.ReadAll(resp.Body)
+	log.Printf("[INFO] Reading Do config for task:%s", d.Id())
+	task, err := clientBigip.GetDOTask(d.Id())
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while reading http response body in read call :%v ", err))
+		return diag.FromErr(fmt.Errorf("error reading DO task %s: %v", d.Id(), err))
 	}
-	bodyString := respBody.String()
-	if resp.Status != "200 OK" {
-		return diag.FromErr(fmt.Errorf("error while Sending/fetching http request :%s ", bodyString))
+	if err := task.Err(); err != nil {
+		return diag.FromErr(err)
 	}
-	respRef1 := make(map[string]interface{})
-	if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
+	byteData, err := json.Marshal(task.Declaration)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] in read resp_body is :%v", respRef1)
-	byteData, _ := json.Marshal(respRef1["declaration"])
 	_ = d.Set("do_json", string(byteData))
 
 	return nil
-
 }
 
 func resourceBigipDoUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clientBigip := meta.(*bigip.BigIP)
-	if d.Get("bigip_address").(string) != "" && d.Get("bigip_user").(string) != "" && d.Get("bigip_password").(string) != "" || d.Get("bigip_port").(string) != "" {
-		clientBigip2, err := connectBigIP(d)
-		if err != nil {
-			log.Printf("Connection to BIGIP Failed with :%v", err)
-			return diag.FromErr(err)
-		}
-		clientBigip = clientBigip2
-	}
-
-	doJson := d.Get("do_json").(string)
-	timeout := d.Get("timeout").(int)
-	timeoutSec := timeout * 60
-	log.Printf("[DEBUG]timeout_sec is :%d", timeoutSec)
-	log.Printf("[INFO] Updating do config in bigip:%s", doJson)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	client := &http.Client{Transport: tr}
-	url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/"
-	req, err := http.NewRequest("POST", url, strings.NewReader(doJson))
+	clientBigip, err := doClient(d, meta)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while creating http request with DO json:%v ", err))
-	}
-	if clientBigip.Token != "" {
-		req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-	} else {
-		req.SetBasicAuth(clientBigip.User, clientBigip.Password)
+		return diag.FromErr(err)
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
 
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("[DEBUG] Could not close the request to %s", url)
-		}
-	}()
-
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while receiving  http response with DO json:%v", err))
-	}
-	var body bytes.Buffer
-	_, err = io.Copy(&body, resp.Body)
-	// body, err := ioutil.ReadAll(resp.Body)
+	log.Printf("[INFO] Updating do config in bigip:%s", clientBigip.Host)
+	task, err := deployDo(clientBigip, d)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while reading http response with DO json:%v ", err))
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode > 202 {
-		return diag.FromErr(fmt.Errorf("error while Sending/Posting http request with DO json :%s  %v", body.String(), err))
-	}
-	respRef := make(map[string]interface{})
-	if err := json.Unmarshal(body.Bytes(), &respRef); err != nil {
 		return diag.FromErr(err)
 	}
-	respID := respRef["id"].(string)
-
-	var doSuccess = false
-
-	if resp.StatusCode == 200 {
-		log.Printf("[DEBUG] response status is 200 ok and no aysnc flag in declaration")
-		doSuccess = true
-		d.SetId(respID)
-	}
-
-	if resp.StatusCode == http.StatusAccepted {
-		start := time.Now()
-	forLoop:
-		for time.Since(start).Seconds() < float64(timeoutSec) {
-			log.Printf("[DEBUG]Value of Timeout counter in seconds :%v", math.Ceil(time.Since(start).Seconds()))
-			url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-			req, _ := http.NewRequest("GET", url, nil)
-			if clientBigip.Token != "" {
-				req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-			} else {
-				req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-			}
-			req.Header.Set("Accept", "application/json")
-			req.Header.Set("Content-Type", "application/json")
-			taskResp, err := client.Do(req)
-
-			defer func() {
-				if err := taskResp.Body.Close(); err != nil {
-					log.Printf("[DEBUG] Could not close the request to %s", url)
-				}
-			}()
-
-			if err != nil {
-				log.Printf("[DEBUG]Polling the task id until the timeout")
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			switch {
-			case taskResp.StatusCode == 200:
-				var respBody bytes.Buffer
-				_, err = io.Copy(&respBody, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				doSuccess = true
-				d.SetId(respID)
-				break forLoop
-			case taskResp.StatusCode == 202:
-				var respBody bytes.Buffer
-				_, err = io.Copy(&respBody, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				resultMap := respRef1["result"]
-				if resultMap.(map[string]interface{})["status"] != "RUNNING" {
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", resultMap))
-				}
-			default:
-				log.Printf("StatusCode:%+v", taskResp.StatusCode)
-			}
-			time.Sleep(1 * time.Second)
-		}
-	}
+	d.SetId(task.ID)
 
-	if !doSuccess {
-		log.Printf("[DEBUG] Didn't get successful response within timeout")
-		url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-		req, _ := http.NewRequest("GET", url, nil)
-		if clientBigip.Token != "" {
-			req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-		} else {
-			req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-		}
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Content-Type", "application/json")
-		taskResp, err := client.Do(req)
+	return resourceBigipDoRead(ctx, d, meta)
+}
 
-		defer func() {
-			if err := taskResp.Body.Close(); err != nil {
-				log.Printf("[DEBUG] Could not close the request to %s", url)
-			}
-		}()
+// deployDo posts do_json and waits up to timeout minutes for the DO task to finish.
+func deployDo(clientBigip *bigip.BigIP, d *schema.ResourceData) (*bigip.DOTask, error) {
+	doJson := d.Get("do_json").(string)
+	timeout := time.Duration(d.Get("timeout").(int)) * time.Minute
+	log.Printf("[DEBUG] DO timeout is :%s", timeout)
 
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("Timedout while polling the DO task id with error :%v ", err))
-		}
-		var respBody bytes.Buffer
-		_, err = io.Copy(&respBody, taskResp.Body)
-		// respBody, err := ioutil.ReadAll(taskResp.Body)
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("Timedout while polling the DO task id with error :%v ", err))
-		}
-		respRef2 := make(map[string]interface{})
-		if err := json.Unmarshal(respBody.Bytes(), &respRef2); err != nil {
-			return diag.FromErr(err)
+	task, err := clientBigip.DeployDO(doJson, timeout, doPollInterval)
+	if err != nil {
+		if task != nil && task.ID != "" {
+			return nil, fmt.Errorf("error while deploying DO declaration (task %s, result %+v): %v", task.ID, task.Result, err)
 		}
-
-		resultMap := respRef2["result"]
-		d.SetId("")
-		return diag.FromErr(fmt.Errorf("timeout while polling the DO task id with result:%v", resultMap))
+		return nil, fmt.Errorf("error while deploying DO declaration: %v", err)
 	}
-
-	return resourceBigipDoRead(ctx, d, meta)
+	return task, nil
 }
 
 func resourceBigipDoDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -525,24 +199,63 @@ func resourceBigipDoDelete(ctx context.Context, d *schema.ResourceData, meta int
 	return nil
 }
 
-func connectBigIP(d *schema.ResourceData) (*bigip.BigIP, error) {
+// doClient returns the client to use for DO calls: the provider client, or a
+// dedicated one when the resource targets a different BIG-IP.
+func doClient(d *schema.ResourceData, meta interface{}) (*bigip.BigIP, error) {
+	clientBigip := meta.(*bigip.BigIP)
+	if d.Get("bigip_address").(string) != "" && d.Get("bigip_user").(string) != "" && d.Get("bigip_password").(string) != "" || d.Get("bigip_port").(string) != "" {
+		clientBigip2, err := connectBigIP(d, clientBigip)
+		if err != nil {
+			log.Printf("Connection to BIGIP Failed with :%v", err)
+			return nil, err
+		}
+		return clientBigip2, nil
+	}
+	return clientBigip, nil
+}
+
+// connectBigIP opens a session to the BIG-IP named by the bigip_* attributes,
+// reusing the TLS, timeout and retry settings of the provider client.
+func connectBigIP(d *schema.ResourceData, provider *bigip.BigIP) (*bigip.BigIP, error) {
 	var portVal string
 	if _, ok := d.GetOk("bigip_port"); ok {
 		portVal = d.Get("bigip_port").(string)
 	} else {
 		portVal = "443"
 	}
+	address := d.Get("bigip_address").(string)
+	if address == "" {
+		address = strings.TrimPrefix(strings.TrimPrefix(provider.Host, "https://"), "http://")
+		if i := strings.LastIndex(address, ":"); i > 0 {
+			address = address[:i]
+		}
+	}
 	bigipConfig := bigip.Config{
-		Address:           d.Get("bigip_address").(string),
-		Port:              portVal,
-		Username:          d.Get("bigip_user").(string),
-		Password:          d.Get("bigip_password").(string),
-		CertVerifyDisable: true,
+		Address:       address,
+		Port:          portVal,
+		Username:      d.Get("bigip_user").(string),
+		Password:      d.Get("bigip_password").(string),
+		ConfigOptions: provider.ConfigOptions,
+	}
+	if bigipConfig.Username == "" {
+		bigipConfig.Username = provider.User
+		bigipConfig.Password = provider.Password
 	}
 
+	client := bigip.NewSession(&bigipConfig)
+	// the provider trust settings apply to every request, the token login included
+	if provider.Transport != nil && provider.Transport.TLSClientConfig != nil {
+		client.Transport.TLSClientConfig = provider.Transport.TLSClientConfig.Clone()
+	}
 	if d.Get("bigip_token_auth").(bool) {
-		bigipConfig.LoginReference = d.Get("bigiq_login_ref").(string)
+		if err := client.TokenLogin(&bigipConfig); err != nil {
+			return nil, err
+		}
 	}
-
-	return Client(&bigipConfig)
+	client.UserAgent = provider.UserAgent
+	client.Teem = provider.Teem
+	if err := client.ValidateConnection(); err != nil {
+		return nil, err
+	}
+	return client, nil
 }
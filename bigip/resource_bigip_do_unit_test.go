@@ -0,0 +1,256 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_do against a fake Declarative Onboarding endpoint - no F5 BIG-IP connection required
+
+const testDoDeclaration = `{"schemaVersion":"1.0.0","class":"Device","Common":{"class":"Tenant","hostname":"bigip1.example.com"}}`
+
+// fakeDoServer serves the DO declare and task endpoints. A task reports
+// RUNNING for the given number of polls and then finishes with result.
+type fakeDoServer struct {
+	*httptest.Server
+	mu           sync.Mutex
+	pollsLeft    int
+	result       string
+	polls        int
+	posted       string
+	authHeaders  []string
+	basicUsers   []string
+	logins       int
+	declarations int
+}
+
+func newFakeDoServer(t *testing.T, runningPolls int, result string) *fakeDoServer {
+	f := &fakeDoServer{pollsLeft: runningPolls, result: result}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		f.record(r)
+		f.posted = string(body)
+		f.declarations++
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprint(w, `{"id":"task-1","result":{"class":"Result","code":202,"status":"RUNNING","message":"processing"}}`)
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/task/task-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.record(r)
+		f.polls++
+		w.Header().Set("Content-Type", "application/json")
+		if f.pollsLeft > 0 {
+			f.pollsLeft--
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprint(w, `{"id":"task-1","result":{"class":"Result","code":202,"status":"RUNNING","message":"processing"}}`)
+			return
+		}
+		if strings.Contains(f.result, `"ERROR"`) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_, _ = fmt.Fprintf(w, `{"id":"task-1",%s,"declaration":%s}`, f.result, testDoDeclaration)
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/task", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `[{"id":"task-0","result":{"code":200,"status":"OK"}},{"id":"task-1",%s}]`, f.result)
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/inspect", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `[{"id":"inspect","result":{"code":200,"status":"OK"},"declaration":%s}]`, testDoDeclaration)
+	})
+	mux.HandleFunc("/mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.logins++
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"token":{"token":"do-token"},"timeout":{"timeout":1200},"refreshToken":{"token":"do-refresh"}}`)
+	})
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeDoServer) record(r *http.Request) {
+	f.authHeaders = append(f.authHeaders, r.Header.Get("X-F5-Auth-Token"))
+	if user, _, ok := r.BasicAuth(); ok {
+		f.basicUsers = append(f.basicUsers, user)
+	}
+}
+
+func testDoResourceData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	if _, ok := raw["do_json"]; !ok {
+		raw["do_json"] = testDoDeclaration
+	}
+	return schema.TestResourceDataRaw(t, resourceBigipDo().Schema, raw)
+}
+
+func TestResourceBigipDoCreatePollsTask(t *testing.T) {
+	withFastPolling(t, &doPollInterval)
+	f := newFakeDoServer(t, 2, `"result":{"class":"Result","code":200,"status":"OK","message":"success"}`)
+	client := testFakeBigipClient(f.Server)
+	client.Token = "token-123"
+
+	d := testDoResourceData(t, map[string]interface{}{})
+	diags := resourceBigipDoCreate(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "task-1", d.Id())
+	assert.Equal(t, 1, f.declarations)
+	assert.Equal(t, testDoDeclaration, f.posted)
+	// two RUNNING polls, the finishing poll and the read
+	assert.Equal(t, 4, f.polls)
+	assert.Contains(t, d.Get("do_json").(string), "bigip1.example.com")
+	for _, h := range f.authHeaders {
+		assert.Equal(t, "token-123", h)
+	}
+}
+
+func TestResourceBigipDoCreateTaskError(t *testing.T) {
+	withFastPolling(t, &doPollInterval)
+	f := newFakeDoServer(t, 1, `"result":{"class":"Result","code":422,"status":"ERROR","message":"invalid config - rolled back","errors":["hostname is invalid"]}`)
+	client := testFakeBigipClient(f.Server)
+
+	d := testDoResourceData(t, map[string]interface{}{})
+	d.SetId("stale")
+	diags := resourceBigipDoCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a failed DO task")
+	}
+	assert.Contains(t, diags[0].Summary, "hostname is invalid")
+	assert.Contains(t, diags[0].Summary, "ERROR")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipDoUpdateKeepsStateOnError(t *testing.T) {
+	withFastPolling(t, &doPollInterval)
+	f := newFakeDoServer(t, 0, `"result":{"class":"Result","code":422,"status":"ERROR","message":"invalid config"}`)
+	client := testFakeBigipClient(f.Server)
+
+	d := testDoResourceData(t, map[string]interface{}{})
+	d.SetId("task-0")
+	diags := resourceBigipDoUpdate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a failed DO task")
+	}
+	assert.Equal(t, "task-0", d.Id())
+}
+
+func TestBigipWaitForDOTaskTimeout(t *testing.T) {
+	f := newFakeDoServer(t, 1000, "")
+	client := testFakeBigipClient(f.Server)
+
+	task, err := client.WaitForDOTask("task-1", 50*time.Millisecond, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	assert.Contains(t, err.Error(), "timed out")
+	if assert.NotNil(t, task) {
+		assert.True(t, task.Running())
+	}
+}
+
+func TestBigipDOTasksAndInspect(t *testing.T) {
+	f := newFakeDoServer(t, 0, `"result":{"code":200,"status":"OK"}`)
+	client := testFakeBigipClient(f.Server)
+
+	tasks, err := client.GetDOTasks()
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, "task-1", tasks[1].ID)
+	assert.True(t, tasks[1].Succeeded())
+
+	inspect, err := client.InspectDO()
+	assert.NoError(t, err)
+	assert.Equal(t, "Device", inspect.Declaration["class"])
+}
+
+func TestResourceBigipDoHonorsProviderTLS(t *testing.T) {
+	f := newFakeDoServer(t, 0, `"result":{"code":200,"status":"OK"}`)
+
+	// A provider client that does not trust the fake server must fail.
+	untrusted := testFakeBigipClient(f.Server)
+	untrusted.Transport.TLSClientConfig.RootCAs = x509.NewCertPool()
+	d := testDoResourceData(t, map[string]interface{}{})
+	d.SetId("task-1")
+	diags := resourceBigipDoRead(context.Background(), d, untrusted)
+	if !diags.HasError() {
+		t.Fatal("expected a certificate verification error")
+	}
+	assert.Contains(t, diags[0].Summary, "certificate")
+
+	// A resource level bigip_address reuses the provider trust settings
+	// together with its own credentials.
+	u, _ := url.Parse(f.URL)
+	d = testDoResourceData(t, map[string]interface{}{
+		"bigip_address":  u.Hostname(),
+		"bigip_port":     u.Port(),
+		"bigip_user":     "do-admin",
+		"bigip_password": "do-secret",
+	})
+	d.SetId("task-1")
+	diags = resourceBigipDoRead(context.Background(), d, testFakeBigipClient(f.Server))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.NotEmpty(t, f.basicUsers)
+	for _, user := range f.basicUsers {
+		assert.Equal(t, "do-admin", user)
+	}
+}
+
+func TestResourceBigipDoTokenLoginHonorsProviderTLS(t *testing.T) {
+	f := newFakeDoServer(t, 0, `"result":{"code":200,"status":"OK"}`)
+	u, _ := url.Parse(f.URL)
+	raw := map[string]interface{}{
+		"bigip_address":    u.Hostname(),
+		"bigip_port":       u.Port(),
+		"bigip_user":       "do-admin",
+		"bigip_password":   "do-secret",
+		"bigip_token_auth": true,
+	}
+
+	// The credentials are not sent to a server the provider does not trust.
+	untrusted := testFakeBigipClient(f.Server)
+	untrusted.Transport.TLSClientConfig.RootCAs = x509.NewCertPool()
+	_, err := connectBigIP(testDoResourceData(t, raw), untrusted)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "certificate")
+	}
+	assert.Equal(t, 0, f.logins)
+
+	client, err := connectBigIP(testDoResourceData(t, raw), testFakeBigipClient(f.Server))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, 1, f.logins)
+	assert.Equal(t, "do-token", client.Token)
+}
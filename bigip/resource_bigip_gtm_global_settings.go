@@ -0,0 +1,279 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const gtmGlobalSettingsID = "gtm-global-settings"
+
+func resourceBigipGtmGlobalSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmGlobalSettingsCreate,
+		ReadContext:   resourceBigipGtmGlobalSettingsRead,
+		UpdateContext: resourceBigipGtmGlobalSettingsUpdate,
+		DeleteContext: resourceBigipGtmGlobalSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// general
+			"synchronization": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Synchronize GTM configuration with other systems in the synchronization group",
+			},
+			"synchronization_group_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the GTM synchronization group this system belongs to",
+			},
+			"synchronization_time_tolerance": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of seconds a peer's clock may differ before synchronization is refused",
+			},
+			"synchronization_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of seconds to wait for a synchronization to complete",
+			},
+			"synchronize_zone_files": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Synchronize DNS zone files across the synchronization group",
+			},
+			"heartbeat_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Interval in seconds at which GTM queries the local big3d for updates",
+			},
+			"auto_discovery": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Automatically discover links and virtual servers",
+			},
+			"monitor_disabled_objects": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Continue monitoring objects that are disabled",
+			},
+			// load-balancing
+			"topology_longest_match": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Evaluate topology records by longest match",
+			},
+			"verify_vs_availability": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Verify the availability of virtual servers before sending traffic to them",
+			},
+			"ignore_path_ttl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Use path metrics even when their TTL has expired",
+			},
+			"respect_fallback_dependency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Respect virtual server dependencies when using the fallback method",
+			},
+			"failure_rcode_response": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+				Description:  "Return an RCODE response when load balancing fails",
+			},
+			// metrics
+			"metrics_collection_protocols": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"dns-dot", "dns-rev", "icmp", "tcp", "udp"}, false)},
+				Description: "Protocols used to probe local DNS servers for path metrics",
+			},
+			"metrics_caches": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Interval in seconds between metrics cache refreshes",
+			},
+			"default_probe_limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of times a local DNS server is probed",
+			},
+			"path_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds path metrics are kept",
+			},
+			"hops_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds traceroute hop data is kept",
+			},
+			"inactive_ldns_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds an inactive local DNS server is kept in the metrics cache",
+			},
+			"inactive_paths_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds an inactive path is kept in the metrics cache",
+			},
+		},
+	}
+}
+
+func resourceBigipGtmGlobalSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Configuring GTM global settings")
+
+	if err := modifyGtmGlobalSettings(d, meta.(*bigip.BigIP)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(gtmGlobalSettingsID)
+
+	return resourceBigipGtmGlobalSettingsRead(ctx, d, meta)
+}
+
+func resourceBigipGtmGlobalSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	log.Printf("[INFO] Reading GTM global settings")
+
+	general, err := client.GetGTMGlobalSettingsGeneral()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving GTM general global settings: %v", err))
+	}
+	lb, err := client.GetGTMGlobalSettingsLoadBalancing()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving GTM load-balancing global settings: %v", err))
+	}
+	metrics, err := client.GetGTMGlobalSettingsMetrics()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving GTM metrics global settings: %v", err))
+	}
+
+	d.SetId(gtmGlobalSettingsID)
+
+	d.Set("synchronization", general.Synchronization)
+	d.Set("synchronization_group_name", general.SynchronizationGroupName)
+	d.Set("synchronization_time_tolerance", general.SynchronizationTimeTolerance)
+	d.Set("synchronization_timeout", general.SynchronizationTimeout)
+	d.Set("synchronize_zone_files", general.SynchronizeZoneFiles)
+	d.Set("heartbeat_interval", general.HeartbeatInterval)
+	d.Set("auto_discovery", general.AutoDiscovery)
+	d.Set("monitor_disabled_objects", general.MonitorDisabledObjects)
+
+	d.Set("topology_longest_match", lb.TopologyLongestMatch)
+	d.Set("verify_vs_availability", lb.VerifyVsAvailability)
+	d.Set("ignore_path_ttl", lb.IgnorePathTtl)
+	d.Set("respect_fallback_dependency", lb.RespectFallbackDependency)
+	d.Set("failure_rcode_response", lb.FailureRcodeResponse)
+
+	d.Set("metrics_collection_protocols", metrics.MetricsCollectionProtocols)
+	d.Set("metrics_caches", metrics.MetricsCaches)
+	d.Set("default_probe_limit", metrics.DefaultProbeLimit)
+	d.Set("path_ttl", metrics.PathTtl)
+	d.Set("hops_ttl", metrics.HopsTtl)
+	d.Set("inactive_ldns_ttl", metrics.InactiveLdnsTtl)
+	d.Set("inactive_paths_ttl", metrics.InactivePathsTtl)
+
+	return nil
+}
+
+func resourceBigipGtmGlobalSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Updating GTM global settings")
+
+	if err := modifyGtmGlobalSettings(d, meta.(*bigip.BigIP)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBigipGtmGlobalSettingsRead(ctx, d, meta)
+}
+
+func resourceBigipGtmGlobalSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Global settings always exist on the device; destroying the resource only
+	// stops Terraform from managing them and leaves the current values in place.
+	log.Printf("[INFO] Removing GTM global settings from state, device configuration is left unchanged")
+	d.SetId("")
+	return nil
+}
+
+func modifyGtmGlobalSettings(d *schema.ResourceData, client *bigip.BigIP) error {
+	general := &bigip.GTMGlobalSettingsGeneral{
+		Synchronization:              d.Get("synchronization").(string),
+		SynchronizationGroupName:     d.Get("synchronization_group_name").(string),
+		SynchronizationTimeTolerance: d.Get("synchronization_time_tolerance").(int),
+		SynchronizationTimeout:       d.Get("synchronization_timeout").(int),
+		SynchronizeZoneFiles:         d.Get("synchronize_zone_files").(string),
+		HeartbeatInterval:            d.Get("heartbeat_interval").(int),
+		AutoDiscovery:                d.Get("auto_discovery").(string),
+		MonitorDisabledObjects:       d.Get("monitor_disabled_objects").(string),
+	}
+	if err := client.ModifyGTMGlobalSettingsGeneral(general); err != nil {
+		return fmt.Errorf("error modifying GTM general global settings: %v", err)
+	}
+
+	lb := &bigip.GTMGlobalSettingsLoadBalancing{
+		TopologyLongestMatch:      d.Get("topology_longest_match").(string),
+		VerifyVsAvailability:      d.Get("verify_vs_availability").(string),
+		IgnorePathTtl:             d.Get("ignore_path_ttl").(string),
+		RespectFallbackDependency: d.Get("respect_fallback_dependency").(string),
+		FailureRcodeResponse:      d.Get("failure_rcode_response").(string),
+	}
+	if err := client.ModifyGTMGlobalSettingsLoadBalancing(lb); err != nil {
+		return fmt.Errorf("error modifying GTM load-balancing global settings: %v", err)
+	}
+
+	metrics := &bigip.GTMGlobalSettingsMetrics{
+		MetricsCollectionProtocols: listToStringSlice(d.Get("metrics_collection_protocols").([]interface{})),
+		MetricsCaches:              d.Get("metrics_caches").(int),
+		DefaultProbeLimit:          d.Get("default_probe_limit").(int),
+		PathTtl:                    d.Get("path_ttl").(int),
+		HopsTtl:                    d.Get("hops_ttl").(int),
+		InactiveLdnsTtl:            d.Get("inactive_ldns_ttl").(int),
+		InactivePathsTtl:           d.Get("inactive_paths_ttl").(int),
+	}
+	if err := client.ModifyGTMGlobalSettingsMetrics(metrics); err != nil {
+		return fmt.Errorf("error modifying GTM metrics global settings: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBigipGtmGlobalSettings_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmGlobalSettingsConfig("yes", 300),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_global_settings.test", "id", gtmGlobalSettingsID),
+					resource.TestCheckResourceAttr("bigip_gtm_global_settings.test", "topology_longest_match", "yes"),
+					resource.TestCheckResourceAttr("bigip_gtm_global_settings.test", "synchronization_group_name", "tf_sync_group"),
+					resource.TestCheckResourceAttr("bigip_gtm_global_settings.test", "path_ttl", "300"),
+				),
+			},
+			{
+				Config: testAccBigipGtmGlobalSettingsConfig("no", 2400),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_global_settings.test", "topology_longest_match", "no"),
+					resource.TestCheckResourceAttr("bigip_gtm_global_settings.test", "path_ttl", "2400"),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_global_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     gtmGlobalSettingsID,
+			},
+		},
+	})
+}
+
+func testAccBigipGtmGlobalSettingsConfig(longestMatch string, pathTTL int) string {
+	return fmt.Sprintf(`
+resource "bigip_gtm_global_settings" "test" {
+  synchronization              = "no"
+  synchronization_group_name   = "tf_sync_group"
+  topology_longest_match       = "%s"
+  metrics_collection_protocols = ["icmp", "tcp"]
+  path_ttl                     = %d
+}
+`, longestMatch, pathTTL)
+}
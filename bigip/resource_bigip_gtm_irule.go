@@ -0,0 +1,137 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipGtmIRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmIRuleCreate,
+		ReadContext:   resourceBigipGtmIRuleRead,
+		UpdateContext: resourceBigipGtmIRuleUpdate,
+		DeleteContext: resourceBigipGtmIRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the GTM iRule",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the GTM iRule",
+			},
+			"irule": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The iRule body",
+				StateFunc: func(s interface{}) string {
+					return strings.TrimSpace(s.(string))
+				},
+			},
+		},
+	}
+}
+
+func resourceBigipGtmIRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating GTM iRule: %s in partition %s", name, partition)
+
+	rule := &bigip.GTMIRule{
+		Name:      name,
+		Partition: partition,
+		Rule:      d.Get("irule").(string),
+	}
+
+	err := client.CreateGTMIRule(rule)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating GTM iRule %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipGtmIRuleRead(ctx, d, meta)
+}
+
+func resourceBigipGtmIRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading GTM iRule: %s", fullPath)
+
+	rule, err := client.GetGTMIRule(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving GTM iRule %s: %v", fullPath, err))
+	}
+	if rule == nil {
+		log.Printf("[WARN] GTM iRule %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	// Parse partition and name from fullPath so that import populates both
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	} else {
+		d.Set("name", rule.Name)
+		if rule.Partition != "" {
+			d.Set("partition", rule.Partition)
+		}
+	}
+	d.Set("irule", rule.Rule)
+
+	return nil
+}
+
+func resourceBigipGtmIRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating GTM iRule: %s", fullPath)
+
+	rule := &bigip.GTMIRule{
+		Rule: d.Get("irule").(string),
+	}
+
+	err := client.ModifyGTMIRule(fullPath, rule)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating GTM iRule %s: %v", fullPath, err))
+	}
+
+	return resourceBigipGtmIRuleRead(ctx, d, meta)
+}
+
+func resourceBigipGtmIRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting GTM iRule: %s", fullPath)
+
+	err := client.DeleteGTMIRule(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting GTM iRule %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
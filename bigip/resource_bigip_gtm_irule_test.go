@@ -0,0 +1,161 @@
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var TEST_GTM_IRULE_NAME = "test_gtm_rule"
+
+func TestAccBigipGtmIRule_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmIRuleDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmIRuleConfig("10.0.0.0/8"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmIRuleExists(TEST_GTM_IRULE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_gtm_irule.test-rule", "name", TEST_GTM_IRULE_NAME),
+					resource.TestCheckResourceAttr("bigip_gtm_irule.test-rule", "partition", "Common"),
+					resource.TestCheckResourceAttrSet("bigip_gtm_irule.test-rule", "irule"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmIRule_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmIRuleDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmIRuleConfig("10.0.0.0/8"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmIRuleExists(TEST_GTM_IRULE_NAME, true),
+				),
+			},
+			{
+				Config: testAccBigipGtmIRuleConfig("192.168.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmIRuleExists(TEST_GTM_IRULE_NAME, true),
+					resource.TestMatchResourceAttr("bigip_gtm_irule.test-rule", "irule", regexp.MustCompile(regexp.QuoteMeta("192.168.0.0/16"))),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmIRule_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmIRuleDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmIRuleConfig("10.0.0.0/8"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmIRuleExists(TEST_GTM_IRULE_NAME, true),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_irule.test-rule",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("/Common/%s", TEST_GTM_IRULE_NAME),
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmIRule_dataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmIRuleDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmIRuleConfig("10.0.0.0/8") + `
+data "bigip_gtm_irule" "test-rule" {
+  name      = bigip_gtm_irule.test-rule.name
+  partition = bigip_gtm_irule.test-rule.partition
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_gtm_irule.test-rule", "name", TEST_GTM_IRULE_NAME),
+					resource.TestCheckResourceAttrPair("data.bigip_gtm_irule.test-rule", "irule", "bigip_gtm_irule.test-rule", "irule"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckGtmIRuleExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+		fullPath := fmt.Sprintf("/Common/%s", name)
+
+		rule, err := client.GetGTMIRule(fullPath)
+		if err != nil && exists {
+			return err
+		}
+		if exists && rule == nil {
+			return fmt.Errorf("GTM iRule %s does not exist", fullPath)
+		}
+		if !exists && rule != nil {
+			return fmt.Errorf("GTM iRule %s still exists", fullPath)
+		}
+		return nil
+	}
+}
+
+func testCheckGtmIRuleDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_gtm_irule" {
+			continue
+		}
+
+		rule, err := client.GetGTMIRule(rs.Primary.ID)
+		if err != nil {
+			return nil
+		}
+		if rule != nil {
+			return fmt.Errorf("GTM iRule %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccBigipGtmIRuleConfig(subnet string) string {
+	return fmt.Sprintf(`
+resource "bigip_gtm_irule" "test-rule" {
+  name      = "%s"
+  partition = "Common"
+  irule     = <<EOF
+when DNS_REQUEST {
+  if { [IP::addr [IP::client_addr] equals %s] } {
+    pool /Common/internal_pool
+  }
+}
+EOF
+}
+`, TEST_GTM_IRULE_NAME, subnet)
+}
@@ -0,0 +1,279 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipGtmLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmLinkCreate,
+		ReadContext:   resourceBigipGtmLinkRead,
+		UpdateContext: resourceBigipGtmLinkUpdate,
+		DeleteContext: resourceBigipGtmLinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the GTM link",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the GTM link",
+			},
+			"datacenter": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full path of the datacenter the link belongs to, e.g. /Common/dc1",
+			},
+			"router_addresses": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IP addresses of the router(s) through which the link is reached",
+			},
+			"uplink_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "IP address on the uplink side of the router, used to measure path metrics",
+			},
+			"monitor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Health monitor for the link, defaults to /Common/bigip_link on the device",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the link",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable or disable the link",
+			},
+			"weighting": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ratio",
+				ValidateFunc: validation.StringInSlice([]string{"ratio", "price"}, false),
+				Description:  "Weighting method used when load balancing across links",
+			},
+			"link_ratio": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Ratio weight of the link when weighting is ratio",
+			},
+			"prepaid": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Prepaid bandwidth of the link in bits per second, used when weighting is price",
+			},
+			"duplex_billing": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+				Description:  "Bill inbound and outbound traffic separately",
+			},
+			"limit_max_inbound_bps": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum inbound bits per second before the link is marked unavailable, 0 disables the limit",
+			},
+			"limit_max_outbound_bps": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum outbound bits per second before the link is marked unavailable, 0 disables the limit",
+			},
+			"limit_max_total_bps": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum total bits per second before the link is marked unavailable, 0 disables the limit",
+			},
+		},
+	}
+}
+
+func resourceBigipGtmLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating GTM Link: %s in partition %s", name, partition)
+
+	link := getGtmLinkConfig(d)
+	link.Name = name
+	link.Partition = partition
+
+	err := client.CreateGTMLink(link)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating GTM Link %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipGtmLinkRead(ctx, d, meta)
+}
+
+func resourceBigipGtmLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading GTM Link: %s", fullPath)
+
+	link, err := client.GetGTMLink(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving GTM Link %s: %v", fullPath, err))
+	}
+	if link == nil {
+		log.Printf("[WARN] GTM Link %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	} else {
+		d.Set("name", link.Name)
+		if link.Partition != "" {
+			d.Set("partition", link.Partition)
+		}
+	}
+
+	d.Set("datacenter", link.Datacenter)
+	var routerAddresses []string
+	for _, addr := range link.RouterAddresses {
+		routerAddresses = append(routerAddresses, addr.Name)
+	}
+	d.Set("router_addresses", routerAddresses)
+	d.Set("uplink_address", link.UplinkAddress)
+	d.Set("monitor", link.Monitor)
+	d.Set("description", link.Description)
+	d.Set("enabled", !link.Disabled)
+	if link.Weighting != "" {
+		d.Set("weighting", link.Weighting)
+	}
+	if link.LinkRatio != 0 {
+		d.Set("link_ratio", link.LinkRatio)
+	}
+	d.Set("prepaid", link.Prepaid)
+	if link.DuplexBilling != "" {
+		d.Set("duplex_billing", link.DuplexBilling)
+	}
+	d.Set("limit_max_inbound_bps", gtmLinkLimit(link.LimitMaxInboundBps, link.LimitMaxInboundBpsStatus))
+	d.Set("limit_max_outbound_bps", gtmLinkLimit(link.LimitMaxOutboundBps, link.LimitMaxOutboundBpsStatus))
+	d.Set("limit_max_total_bps", gtmLinkLimit(link.LimitMaxTotalBps, link.LimitMaxTotalBpsStatus))
+
+	return nil
+}
+
+func resourceBigipGtmLinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating GTM Link: %s", fullPath)
+
+	link := getGtmLinkConfig(d)
+
+	err := client.ModifyGTMLink(fullPath, link)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating GTM Link %s: %v", fullPath, err))
+	}
+
+	return resourceBigipGtmLinkRead(ctx, d, meta)
+}
+
+func resourceBigipGtmLinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting GTM Link: %s", fullPath)
+
+	err := client.DeleteGTMLink(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting GTM Link %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getGtmLinkConfig(d *schema.ResourceData) *bigip.GTMLink {
+	enabled := d.Get("enabled").(bool)
+	link := &bigip.GTMLink{
+		Datacenter:          d.Get("datacenter").(string),
+		UplinkAddress:       d.Get("uplink_address").(string),
+		Monitor:             d.Get("monitor").(string),
+		Description:         d.Get("description").(string),
+		Enabled:             enabled,
+		Disabled:            !enabled,
+		Weighting:           d.Get("weighting").(string),
+		LinkRatio:           d.Get("link_ratio").(int),
+		Prepaid:             d.Get("prepaid").(int),
+		DuplexBilling:       d.Get("duplex_billing").(string),
+		LimitMaxInboundBps:  d.Get("limit_max_inbound_bps").(int),
+		LimitMaxOutboundBps: d.Get("limit_max_outbound_bps").(int),
+		LimitMaxTotalBps:    d.Get("limit_max_total_bps").(int),
+	}
+	link.LimitMaxInboundBpsStatus = gtmLinkLimitStatus(link.LimitMaxInboundBps)
+	link.LimitMaxOutboundBpsStatus = gtmLinkLimitStatus(link.LimitMaxOutboundBps)
+	link.LimitMaxTotalBpsStatus = gtmLinkLimitStatus(link.LimitMaxTotalBps)
+
+	for _, addr := range listToStringSlice(d.Get("router_addresses").([]interface{})) {
+		link.RouterAddresses = append(link.RouterAddresses, bigip.GTMLinkRouterAddress{
+			Name:        addr,
+			Translation: "none",
+		})
+	}
+	return link
+}
+
+// gtmLinkLimitStatus maps a configured bandwidth limit onto the status flag
+// BIG-IP expects alongside it; a limit of 0 disables the check.
+func gtmLinkLimitStatus(limit int) string {
+	if limit > 0 {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// gtmLinkLimit returns the effective limit, treating a limit whose status is
+// disabled as no limit at all.
+func gtmLinkLimit(limit int, status string) int {
+	if status == "disabled" {
+		return 0
+	}
+	return limit
+}
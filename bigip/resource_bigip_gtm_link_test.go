@@ -0,0 +1,138 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var TEST_GTM_LINK_NAME = "test_link"
+
+func TestAccBigipGtmLink_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmLinkDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmLinkConfig(1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmLinkExists(TEST_GTM_LINK_NAME, true),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "name", TEST_GTM_LINK_NAME),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "datacenter", "/Common/test_link_dc"),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "router_addresses.#", "1"),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "router_addresses.0", "10.10.10.1"),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "link_ratio", "1"),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "limit_max_total_bps", "0"),
+				),
+			},
+			{
+				Config: testAccBigipGtmLinkConfig(5, 1000000),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmLinkExists(TEST_GTM_LINK_NAME, true),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "link_ratio", "5"),
+					resource.TestCheckResourceAttr("bigip_gtm_link.test-link", "limit_max_total_bps", "1000000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmLink_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmLinkDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmLinkConfig(1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmLinkExists(TEST_GTM_LINK_NAME, true),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_link.test-link",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("/Common/%s", TEST_GTM_LINK_NAME),
+			},
+		},
+	})
+}
+
+func TestGtmLinkLimitStatus(t *testing.T) {
+	if got := gtmLinkLimitStatus(0); got != "disabled" {
+		t.Errorf("expected disabled for a zero limit, got %s", got)
+	}
+	if got := gtmLinkLimitStatus(100); got != "enabled" {
+		t.Errorf("expected enabled for a non-zero limit, got %s", got)
+	}
+	if got := gtmLinkLimit(100, "disabled"); got != 0 {
+		t.Errorf("expected a disabled limit to read back as 0, got %d", got)
+	}
+	if got := gtmLinkLimit(100, "enabled"); got != 100 {
+		t.Errorf("expected an enabled limit to read back as 100, got %d", got)
+	}
+}
+
+func testCheckGtmLinkExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+		fullPath := fmt.Sprintf("/Common/%s", name)
+
+		link, err := client.GetGTMLink(fullPath)
+		if err != nil && exists {
+			return err
+		}
+		if exists && link == nil {
+			return fmt.Errorf("GTM Link %s does not exist", fullPath)
+		}
+		if !exists && link != nil {
+			return fmt.Errorf("GTM Link %s still exists", fullPath)
+		}
+		return nil
+	}
+}
+
+func testCheckGtmLinkDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_gtm_link" {
+			continue
+		}
+
+		link, err := client.GetGTMLink(rs.Primary.ID)
+		if err != nil {
+			return nil
+		}
+		if link != nil {
+			return fmt.Errorf("GTM Link %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccBigipGtmLinkConfig(ratio, totalBps int) string {
+	return fmt.Sprintf(`
+resource "bigip_gtm_datacenter" "test-link-dc" {
+  name      = "test_link_dc"
+  partition = "Common"
+}
+
+resource "bigip_gtm_link" "test-link" {
+  name                = "%s"
+  datacenter          = bigip_gtm_datacenter.test-link-dc.id
+  router_addresses    = ["10.10.10.1"]
+  link_ratio          = %d
+  limit_max_total_bps = %d
+}
+`, TEST_GTM_LINK_NAME, ratio, totalBps)
+}
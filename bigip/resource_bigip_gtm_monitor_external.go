@@ -0,0 +1,185 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipGtmMonitorExternal() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmMonitorExternalCreate,
+		ReadContext:   resourceBigipGtmMonitorExternalRead,
+		UpdateContext: resourceBigipGtmMonitorExternalUpdate,
+		DeleteContext: resourceBigipGtmMonitorExternalDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the GTM External monitor",
+				ValidateFunc: validateF5NameWithDirectory,
+			},
+			"defaults_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Inherit properties from this monitor",
+				Default:     "/Common/external",
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the IP address and service port of the resource that is the destination of this monitor. Format: ip:port. Default is \"*:*\"",
+				Default:     "*:*",
+			},
+			"interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies, in seconds, the frequency at which the system issues the monitor check",
+				Default:     30,
+			},
+			"timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the number of seconds the target has in which to respond to the monitor request",
+				Default:     120,
+			},
+			"probe_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the number of seconds after which the BIG-IP system times out the probe request to the BIG-IP system",
+				Default:     5,
+			},
+			"run": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Specifies the full path of the external monitor program file on the BIG-IP (e.g. /Common/my_probe)",
+			},
+			"args": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the command-line arguments that the external program requires",
+			},
+		},
+	}
+}
+
+func resourceBigipGtmMonitorExternalCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating GTM External Monitor: %s", name)
+
+	monitor := &bigip.Gtmmonitor{
+		Name:          name,
+		Defaults_from: d.Get("defaults_from").(string),
+		Destination:   d.Get("destination").(string),
+		Interval:      d.Get("interval").(int),
+		Timeout:       d.Get("timeout").(int),
+		Probe_timeout: d.Get("probe_timeout").(int),
+		Run:           d.Get("run").(string),
+		Args:          d.Get("args").(string),
+	}
+
+	err := client.CreateGtmMonitor(monitor, "external")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating GTM External Monitor %s: %v", name, err))
+	}
+
+	d.SetId(name)
+
+	return resourceBigipGtmMonitorExternalRead(ctx, d, meta)
+}
+
+func resourceBigipGtmMonitorExternalRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Reading GTM External Monitor: %s", name)
+
+	monitor, err := client.GetGtmMonitor(name, "external")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] GTM External Monitor %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading GTM External Monitor %s: %v", name, err))
+	}
+
+	if monitor == nil {
+		log.Printf("[WARN] GTM External Monitor %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", monitor.FullPath)
+	d.Set("defaults_from", monitor.Defaults_from)
+	d.Set("destination", monitor.Destination)
+	d.Set("interval", monitor.Interval)
+	d.Set("timeout", monitor.Timeout)
+	d.Set("probe_timeout", monitor.Probe_timeout)
+	d.Set("run", monitor.Run)
+	d.Set("args", monitor.Args)
+
+	return nil
+}
+
+func resourceBigipGtmMonitorExternalUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Updating GTM External Monitor: %s", name)
+
+	monitor := &bigip.Gtmmonitor{
+		Name:          name,
+		Defaults_from: d.Get("defaults_from").(string),
+		Destination:   d.Get("destination").(string),
+		Interval:      d.Get("interval").(int),
+		Timeout:       d.Get("timeout").(int),
+		Probe_timeout: d.Get("probe_timeout").(int),
+		Run:           d.Get("run").(string),
+		Args:          d.Get("args").(string),
+	}
+
+	err := client.ModifyGtmMonitor(name, monitor, "external")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating GTM External Monitor %s: %v", name, err))
+	}
+
+	return resourceBigipGtmMonitorExternalRead(ctx, d, meta)
+}
+
+func resourceBigipGtmMonitorExternalDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Deleting GTM External Monitor: %s", name)
+
+	err := client.DeleteGtmMonitor(name, "external")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] GTM External Monitor %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error deleting GTM External Monitor %s: %v", name, err))
+	}
+
+	d.SetId("")
+	return nil
+}
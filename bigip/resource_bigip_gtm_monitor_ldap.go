@@ -0,0 +1,253 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipGtmMonitorLdap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmMonitorLdapCreate,
+		ReadContext:   resourceBigipGtmMonitorLdapRead,
+		UpdateContext: resourceBigipGtmMonitorLdapUpdate,
+		DeleteContext: resourceBigipGtmMonitorLdapDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the GTM LDAP monitor",
+				ValidateFunc: validateF5NameWithDirectory,
+			},
+			"defaults_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Inherit properties from this monitor",
+				Default:     "/Common/ldap",
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the IP address and service port of the resource that is the destination of this monitor. Format: ip:port. Default is \"*:*\"",
+				Default:     "*:*",
+			},
+			"interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies, in seconds, the frequency at which the system issues the monitor check",
+				Default:     10,
+			},
+			"timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the number of seconds the target has in which to respond to the monitor request",
+				Default:     31,
+			},
+			"probe_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the number of seconds after which the BIG-IP system times out the probe request to the BIG-IP system",
+				Default:     5,
+			},
+			"ignore_down_response": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies whether the monitor ignores a down response from the system it is monitoring",
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"base": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the location in the LDAP tree from which the monitor starts the health check",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies an LDAP key for which the monitor searches",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the user name if the monitored target requires authentication",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Specifies the password if the monitored target requires authentication",
+			},
+			"security": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies the secure protocol type for communications with the target (none, ssl or tls)",
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice([]string{"none", "ssl", "tls"}, false),
+			},
+			"mandatory_attributes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies whether the target must include attributes in its response to be considered up",
+				Default:      "no",
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+			},
+			"chase_referrals": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies whether, upon receipt of an LDAP referral entry, the target follows that referral",
+				Default:      "yes",
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+			},
+			"debug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies whether the monitor sends error messages and additional information to a log file created and labeled specifically for this monitor",
+				Default:      "no",
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+			},
+		},
+	}
+}
+
+func resourceBigipGtmMonitorLdapCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating GTM LDAP Monitor: %s", name)
+
+	monitor := &bigip.Gtmmonitor{
+		Name:                 name,
+		Defaults_from:        d.Get("defaults_from").(string),
+		Destination:          d.Get("destination").(string),
+		Interval:             d.Get("interval").(int),
+		Timeout:              d.Get("timeout").(int),
+		Probe_timeout:        d.Get("probe_timeout").(int),
+		Ignore_down_response: d.Get("ignore_down_response").(string),
+		Base:                 d.Get("base").(string),
+		Filter:               d.Get("filter").(string),
+		Username:             d.Get("username").(string),
+		Password:             d.Get("password").(string),
+		Security:             d.Get("security").(string),
+		Mandatory_attributes: d.Get("mandatory_attributes").(string),
+		Chase_referrals:      d.Get("chase_referrals").(string),
+		Debug:                d.Get("debug").(string),
+	}
+
+	err := client.CreateGtmMonitor(monitor, "ldap")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating GTM LDAP Monitor %s: %v", name, err))
+	}
+
+	d.SetId(name)
+
+	return resourceBigipGtmMonitorLdapRead(ctx, d, meta)
+}
+
+func resourceBigipGtmMonitorLdapRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Reading GTM LDAP Monitor: %s", name)
+
+	monitor, err := client.GetGtmMonitor(name, "ldap")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] GTM LDAP Monitor %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading GTM LDAP Monitor %s: %v", name, err))
+	}
+
+	if monitor == nil {
+		log.Printf("[WARN] GTM LDAP Monitor %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", monitor.FullPath)
+	d.Set("defaults_from", monitor.Defaults_from)
+	d.Set("destination", monitor.Destination)
+	d.Set("interval", monitor.Interval)
+	d.Set("timeout", monitor.Timeout)
+	d.Set("probe_timeout", monitor.Probe_timeout)
+	d.Set("ignore_down_response", monitor.Ignore_down_response)
+	d.Set("base", monitor.Base)
+	d.Set("filter", monitor.Filter)
+	d.Set("username", monitor.Username)
+	// Password is sensitive, don't set it back
+	d.Set("security", monitor.Security)
+	d.Set("mandatory_attributes", monitor.Mandatory_attributes)
+	d.Set("chase_referrals", monitor.Chase_referrals)
+	d.Set("debug", monitor.Debug)
+
+	return nil
+}
+
+func resourceBigipGtmMonitorLdapUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Updating GTM LDAP Monitor: %s", name)
+
+	monitor := &bigip.Gtmmonitor{
+		Name:                 name,
+		Defaults_from:        d.Get("defaults_from").(string),
+		Destination:          d.Get("destination").(string),
+		Interval:             d.Get("interval").(int),
+		Timeout:              d.Get("timeout").(int),
+		Probe_timeout:        d.Get("probe_timeout").(int),
+		Ignore_down_response: d.Get("ignore_down_response").(string),
+		Base:                 d.Get("base").(string),
+		Filter:               d.Get("filter").(string),
+		Username:             d.Get("username").(string),
+		Password:             d.Get("password").(string),
+		Security:             d.Get("security").(string),
+		Mandatory_attributes: d.Get("mandatory_attributes").(string),
+		Chase_referrals:      d.Get("chase_referrals").(string),
+		Debug:                d.Get("debug").(string),
+	}
+
+	err := client.ModifyGtmMonitor(name, monitor, "ldap")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating GTM LDAP Monitor %s: %v", name, err))
+	}
+
+	return resourceBigipGtmMonitorLdapRead(ctx, d, meta)
+}
+
+func resourceBigipGtmMonitorLdapDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Deleting GTM LDAP Monitor: %s", name)
+
+	err := client.DeleteGtmMonitor(name, "ldap")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] GTM LDAP Monitor %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error deleting GTM LDAP Monitor %s: %v", name, err))
+	}
+
+	d.SetId("")
+	return nil
+}
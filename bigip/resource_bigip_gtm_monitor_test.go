@@ -672,3 +672,220 @@ func TestAccBigipGtmMonitorTcp_minimal(t *testing.T) {
 		},
 	})
 }
+
+var TestGtmMonitorGatewayIcmpResource = `
+resource "bigip_gtm_monitor_gateway_icmp" "test-gtm-gateway-icmp-monitor" {
+  name           = "/Common/test-gtm-gateway-icmp-monitor"
+  defaults_from  = "/Common/gateway_icmp"
+  interval       = 30
+  timeout        = 120
+  probe_interval = 1
+  probe_attempts = 3
+}
+`
+
+var TestGtmMonitorUdpResource = `
+resource "bigip_gtm_monitor_udp" "test-gtm-udp-monitor" {
+  name          = "/Common/test-gtm-udp-monitor"
+  defaults_from = "/Common/udp"
+  destination   = "*:53"
+  send          = "ping"
+  receive       = "pong"
+}
+`
+
+var TestGtmMonitorDnsResource = `
+resource "bigip_gtm_monitor_dns" "test-gtm-dns-monitor" {
+  name            = "/Common/test-gtm-dns-monitor"
+  defaults_from   = "/Common/dns"
+  destination     = "*:53"
+  qname           = "health.example.com"
+  qtype           = "a"
+  accept_rcode    = "no-error"
+  answer_contains = "query-type"
+}
+`
+
+var TestGtmMonitorLdapResource = `
+resource "bigip_gtm_monitor_ldap" "test-gtm-ldap-monitor" {
+  name          = "/Common/test-gtm-ldap-monitor"
+  defaults_from = "/Common/ldap"
+  destination   = "*:389"
+  base          = "dc=example,dc=com"
+  filter        = "(objectClass=*)"
+  username      = "cn=monitor,dc=example,dc=com"
+  password      = "monitorpass"
+  security      = "none"
+}
+`
+
+var TestGtmMonitorExternalResource = `
+resource "bigip_gtm_monitor_external" "test-gtm-external-monitor" {
+  name          = "/Common/test-gtm-external-monitor"
+  defaults_from = "/Common/external"
+  run           = "/Common/arg_example"
+  args          = "-p 8080"
+}
+`
+
+func TestAccBigipGtmMonitorGatewayIcmp_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmMonitorDestroyed("bigip_gtm_monitor_gateway_icmp", "gateway-icmp"),
+		Steps: []resource.TestStep{
+			{
+				Config: TestGtmMonitorGatewayIcmpResource,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmMonitorExists("/Common/test-gtm-gateway-icmp-monitor", "gateway-icmp", true),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_gateway_icmp.test-gtm-gateway-icmp-monitor", "defaults_from", "/Common/gateway_icmp"),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_gateway_icmp.test-gtm-gateway-icmp-monitor", "probe_attempts", "3"),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_monitor_gateway_icmp.test-gtm-gateway-icmp-monitor",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmMonitorUdp_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmMonitorDestroyed("bigip_gtm_monitor_udp", "udp"),
+		Steps: []resource.TestStep{
+			{
+				Config: TestGtmMonitorUdpResource,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmMonitorExists("/Common/test-gtm-udp-monitor", "udp", true),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_udp.test-gtm-udp-monitor", "send", "ping"),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_udp.test-gtm-udp-monitor", "receive", "pong"),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_monitor_udp.test-gtm-udp-monitor",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmMonitorDns_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmMonitorDestroyed("bigip_gtm_monitor_dns", "dns"),
+		Steps: []resource.TestStep{
+			{
+				Config: TestGtmMonitorDnsResource,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmMonitorExists("/Common/test-gtm-dns-monitor", "dns", true),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_dns.test-gtm-dns-monitor", "qname", "health.example.com"),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_dns.test-gtm-dns-monitor", "qtype", "a"),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_monitor_dns.test-gtm-dns-monitor",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmMonitorLdap_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmMonitorDestroyed("bigip_gtm_monitor_ldap", "ldap"),
+		Steps: []resource.TestStep{
+			{
+				Config: TestGtmMonitorLdapResource,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmMonitorExists("/Common/test-gtm-ldap-monitor", "ldap", true),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_ldap.test-gtm-ldap-monitor", "base", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_ldap.test-gtm-ldap-monitor", "security", "none"),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_monitor_ldap.test-gtm-ldap-monitor",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Password is sensitive and won't be returned in read
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmMonitorExternal_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmMonitorDestroyed("bigip_gtm_monitor_external", "external"),
+		Steps: []resource.TestStep{
+			{
+				Config: TestGtmMonitorExternalResource,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmMonitorExists("/Common/test-gtm-external-monitor", "external", true),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_external.test-gtm-external-monitor", "run", "/Common/arg_example"),
+					resource.TestCheckResourceAttr("bigip_gtm_monitor_external.test-gtm-external-monitor", "args", "-p 8080"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckGtmMonitorExists(name, monitorType string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+		monitor, err := client.GetGtmMonitor(name, monitorType)
+		if err != nil {
+			return err
+		}
+		if exists && monitor == nil {
+			return fmt.Errorf("GTM %s Monitor %s was not created", monitorType, name)
+		}
+		if !exists && monitor != nil {
+			return fmt.Errorf("GTM %s Monitor %s still exists", monitorType, name)
+		}
+		return nil
+	}
+}
+
+func testCheckGtmMonitorDestroyed(resourceType, monitorType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			name := rs.Primary.ID
+			monitor, err := client.GetGtmMonitor(name, monitorType)
+			if err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					return nil
+				}
+				return err
+			}
+			if monitor != nil {
+				return fmt.Errorf("GTM %s Monitor %s not destroyed", monitorType, name)
+			}
+		}
+		return nil
+	}
+}
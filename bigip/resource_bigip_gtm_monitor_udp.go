@@ -0,0 +1,245 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipGtmMonitorUdp() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmMonitorUdpCreate,
+		ReadContext:   resourceBigipGtmMonitorUdpRead,
+		UpdateContext: resourceBigipGtmMonitorUdpUpdate,
+		DeleteContext: resourceBigipGtmMonitorUdpDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the GTM UDP monitor",
+				ValidateFunc: validateF5NameWithDirectory,
+			},
+			"defaults_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Inherit properties from this monitor",
+				Default:     "/Common/udp",
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the IP address and service port of the resource that is the destination of this monitor. Format: ip:port. Default is \"*:*\"",
+				Default:     "*:*",
+			},
+			"interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies, in seconds, the frequency at which the system issues the monitor check",
+				Default:     30,
+			},
+			"timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the number of seconds the target has in which to respond to the monitor request",
+				Default:     120,
+			},
+			"probe_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the number of seconds after which the BIG-IP system times out the probe request to the BIG-IP system",
+				Default:     5,
+			},
+			"probe_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the frequency, in seconds, at which the system issues probes within a single monitor check",
+				Default:     1,
+			},
+			"probe_attempts": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Specifies the number of times the system attempts to probe the target before marking it down",
+				Default:     3,
+			},
+			"ignore_down_response": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies whether the monitor ignores a down response from the system it is monitoring",
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"transparent": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies whether the monitor operates in transparent mode",
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"reverse": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Instructs the system to mark the target resource down when the test is successful",
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"send": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the text string that the monitor sends to the target object",
+				Default:     "default send string",
+			},
+			"receive": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Specifies the text string that the monitor looks for in the returned resource",
+			},
+			"debug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies whether the monitor sends error messages and additional information to a log file created and labeled specifically for this monitor",
+				Default:      "no",
+				ValidateFunc: validation.StringInSlice([]string{"yes", "no"}, false),
+			},
+		},
+	}
+}
+
+func resourceBigipGtmMonitorUdpCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating GTM UDP Monitor: %s", name)
+
+	monitor := &bigip.Gtmmonitor{
+		Name:                 name,
+		Defaults_from:        d.Get("defaults_from").(string),
+		Destination:          d.Get("destination").(string),
+		Interval:             d.Get("interval").(int),
+		Timeout:              d.Get("timeout").(int),
+		Probe_timeout:        d.Get("probe_timeout").(int),
+		Probe_interval:       d.Get("probe_interval").(int),
+		Probe_attempts:       d.Get("probe_attempts").(int),
+		Ignore_down_response: d.Get("ignore_down_response").(string),
+		Transparent:          d.Get("transparent").(string),
+		Reverse:              d.Get("reverse").(string),
+		Send:                 d.Get("send").(string),
+		Recv:                 d.Get("receive").(string),
+		Debug:                d.Get("debug").(string),
+	}
+
+	err := client.CreateGtmMonitor(monitor, "udp")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating GTM UDP Monitor %s: %v", name, err))
+	}
+
+	d.SetId(name)
+
+	return resourceBigipGtmMonitorUdpRead(ctx, d, meta)
+}
+
+func resourceBigipGtmMonitorUdpRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Reading GTM UDP Monitor: %s", name)
+
+	monitor, err := client.GetGtmMonitor(name, "udp")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] GTM UDP Monitor %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading GTM UDP Monitor %s: %v", name, err))
+	}
+
+	if monitor == nil {
+		log.Printf("[WARN] GTM UDP Monitor %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", monitor.FullPath)
+	d.Set("defaults_from", monitor.Defaults_from)
+	d.Set("destination", monitor.Destination)
+	d.Set("interval", monitor.Interval)
+	d.Set("timeout", monitor.Timeout)
+	d.Set("probe_timeout", monitor.Probe_timeout)
+	d.Set("probe_interval", monitor.Probe_interval)
+	d.Set("probe_attempts", monitor.Probe_attempts)
+	d.Set("ignore_down_response", monitor.Ignore_down_response)
+	d.Set("transparent", monitor.Transparent)
+	d.Set("reverse", monitor.Reverse)
+	d.Set("send", monitor.Send)
+	d.Set("receive", monitor.Recv)
+	d.Set("debug", monitor.Debug)
+
+	return nil
+}
+
+func resourceBigipGtmMonitorUdpUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Updating GTM UDP Monitor: %s", name)
+
+	monitor := &bigip.Gtmmonitor{
+		Name:                 name,
+		Defaults_from:        d.Get("defaults_from").(string),
+		Destination:          d.Get("destination").(string),
+		Interval:             d.Get("interval").(int),
+		Timeout:              d.Get("timeout").(int),
+		Probe_timeout:        d.Get("probe_timeout").(int),
+		Probe_interval:       d.Get("probe_interval").(int),
+		Probe_attempts:       d.Get("probe_attempts").(int),
+		Ignore_down_response: d.Get("ignore_down_response").(string),
+		Transparent:          d.Get("transparent").(string),
+		Reverse:              d.Get("reverse").(string),
+		Send:                 d.Get("send").(string),
+		Recv:                 d.Get("receive").(string),
+		Debug:                d.Get("debug").(string),
+	}
+
+	err := client.ModifyGtmMonitor(name, monitor, "udp")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating GTM UDP Monitor %s: %v", name, err))
+	}
+
+	return resourceBigipGtmMonitorUdpRead(ctx, d, meta)
+}
+
+func resourceBigipGtmMonitorUdpDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+
+	log.Printf("[INFO] Deleting GTM UDP Monitor: %s", name)
+
+	err := client.DeleteGtmMonitor(name, "udp")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] GTM UDP Monitor %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error deleting GTM UDP Monitor %s: %v", name, err))
+	}
+
+	d.SetId("")
+	return nil
+}
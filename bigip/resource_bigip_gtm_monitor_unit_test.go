@@ -13,6 +13,23 @@ import (
 
 // Unit tests for GTM Monitor resources - no F5 BIG-IP connection required
 
+// gtmMonitorResources returns every GTM monitor resource keyed by its
+// BIG-IP monitor type, for tests that apply to all monitor types.
+func gtmMonitorResources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"http":         resourceBigipGtmMonitorHttp(),
+		"https":        resourceBigipGtmMonitorHttps(),
+		"tcp":          resourceBigipGtmMonitorTcp(),
+		"postgresql":   resourceBigipGtmMonitorPostgresql(),
+		"bigip":        resourceBigipGtmMonitorBigip(),
+		"gateway-icmp": resourceBigipGtmMonitorGatewayIcmp(),
+		"udp":          resourceBigipGtmMonitorUdp(),
+		"dns":          resourceBigipGtmMonitorDns(),
+		"ldap":         resourceBigipGtmMonitorLdap(),
+		"external":     resourceBigipGtmMonitorExternal(),
+	}
+}
+
 func TestResourceBigipGtmMonitorHttpSchema(t *testing.T) {
 	resource := resourceBigipGtmMonitorHttp()
 
@@ -229,15 +246,153 @@ func TestResourceBigipGtmMonitorBigipSchema(t *testing.T) {
 	}
 }
 
+func TestResourceBigipGtmMonitorGatewayIcmpSchema(t *testing.T) {
+	resource := resourceBigipGtmMonitorGatewayIcmp()
+
+	if resource.Schema == nil {
+		t.Fatal("Expected schema to be defined")
+	}
+
+	// Verify probe fields and their defaults
+	probeDefaults := map[string]interface{}{
+		"probe_interval": 1,
+		"probe_attempts": 3,
+	}
+	for field, expectedDefault := range probeDefaults {
+		s, ok := resource.Schema[field]
+		if !ok {
+			t.Errorf("Expected field '%s' to exist in schema", field)
+			continue
+		}
+		if s.Default != expectedDefault {
+			t.Errorf("Expected field '%s' default to be %v, got %v", field, expectedDefault, s.Default)
+		}
+	}
+
+	// Gateway ICMP monitors have nothing to send or receive
+	if _, ok := resource.Schema["send"]; ok {
+		t.Error("Gateway ICMP monitor should not have send field")
+	}
+	if _, ok := resource.Schema["receive"]; ok {
+		t.Error("Gateway ICMP monitor should not have receive field")
+	}
+
+	if s, ok := resource.Schema["defaults_from"]; ok {
+		expectedDefault := "/Common/gateway_icmp"
+		if s.Default != expectedDefault {
+			t.Errorf("Expected defaults_from default to be '%s', got '%v'", expectedDefault, s.Default)
+		}
+	}
+}
+
+func TestResourceBigipGtmMonitorUdpSchema(t *testing.T) {
+	resource := resourceBigipGtmMonitorUdp()
+
+	if resource.Schema == nil {
+		t.Fatal("Expected schema to be defined")
+	}
+
+	udpFields := []string{"send", "receive", "probe_interval", "probe_attempts", "debug"}
+	for _, field := range udpFields {
+		if _, ok := resource.Schema[field]; !ok {
+			t.Errorf("Expected UDP-specific field '%s' to exist in schema", field)
+		}
+	}
+
+	if s, ok := resource.Schema["send"]; ok {
+		expectedDefault := "default send string"
+		if s.Default != expectedDefault {
+			t.Errorf("Expected send default to be '%s', got '%v'", expectedDefault, s.Default)
+		}
+	}
+}
+
+func TestResourceBigipGtmMonitorDnsSchema(t *testing.T) {
+	resource := resourceBigipGtmMonitorDns()
+
+	if resource.Schema == nil {
+		t.Fatal("Expected schema to be defined")
+	}
+
+	// qname is the only DNS-specific field without a sensible default
+	if s, ok := resource.Schema["qname"]; !ok || !s.Required {
+		t.Error("Expected 'qname' field to exist and be required")
+	}
+
+	enumFields := map[string][]string{
+		"qtype":           {"a", "aaaa"},
+		"accept_rcode":    {"no-error", "anything"},
+		"answer_contains": {"any-type", "anything", "query-type"},
+	}
+	for field, valid := range enumFields {
+		s, ok := resource.Schema[field]
+		if !ok {
+			t.Errorf("Expected DNS-specific field '%s' to exist in schema", field)
+			continue
+		}
+		for _, v := range valid {
+			if _, errs := s.ValidateFunc(v, field); len(errs) > 0 {
+				t.Errorf("Expected '%s' to be a valid value for '%s', got %v", v, field, errs)
+			}
+		}
+		if _, errs := s.ValidateFunc("invalid", field); len(errs) == 0 {
+			t.Errorf("Expected 'invalid' to be rejected for '%s'", field)
+		}
+	}
+}
+
+func TestResourceBigipGtmMonitorLdapSchema(t *testing.T) {
+	resource := resourceBigipGtmMonitorLdap()
+
+	if resource.Schema == nil {
+		t.Fatal("Expected schema to be defined")
+	}
+
+	ldapFields := []string{"base", "filter", "username", "password", "security", "mandatory_attributes", "chase_referrals"}
+	for _, field := range ldapFields {
+		if _, ok := resource.Schema[field]; !ok {
+			t.Errorf("Expected LDAP-specific field '%s' to exist in schema", field)
+		}
+	}
+
+	if s, ok := resource.Schema["password"]; ok {
+		if !s.Sensitive {
+			t.Error("Expected password field to be marked as sensitive")
+		}
+	}
+
+	if s, ok := resource.Schema["security"]; ok {
+		if _, errs := s.ValidateFunc("starttls", "security"); len(errs) == 0 {
+			t.Error("Expected 'starttls' to be rejected for security")
+		}
+	}
+}
+
+func TestResourceBigipGtmMonitorExternalSchema(t *testing.T) {
+	resource := resourceBigipGtmMonitorExternal()
+
+	if resource.Schema == nil {
+		t.Fatal("Expected schema to be defined")
+	}
+
+	if s, ok := resource.Schema["run"]; !ok || !s.Required {
+		t.Error("Expected 'run' field to exist and be required")
+	}
+	if s, ok := resource.Schema["args"]; !ok || s.Required {
+		t.Error("Expected 'args' field to exist and be optional")
+	}
+
+	if s, ok := resource.Schema["defaults_from"]; ok {
+		expectedDefault := "/Common/external"
+		if s.Default != expectedDefault {
+			t.Errorf("Expected defaults_from default to be '%s', got '%v'", expectedDefault, s.Default)
+		}
+	}
+}
+
 func TestResourceBigipGtmMonitorSchemaTypes(t *testing.T) {
 	// Test that all resources have correct schema types
-	resources := map[string]*schema.Resource{
-		"http":       resourceBigipGtmMonitorHttp(),
-		"https":      resourceBigipGtmMonitorHttps(),
-		"tcp":        resourceBigipGtmMonitorTcp(),
-		"postgresql": resourceBigipGtmMonitorPostgresql(),
-		"bigip":      resourceBigipGtmMonitorBigip(),
-	}
+	resources := gtmMonitorResources()
 
 	for monitorType, resource := range resources {
 		t.Run(monitorType, func(t *testing.T) {
@@ -265,13 +420,7 @@ func TestResourceBigipGtmMonitorSchemaTypes(t *testing.T) {
 }
 
 func TestResourceBigipGtmMonitorNameValidation(t *testing.T) {
-	resources := map[string]*schema.Resource{
-		"http":       resourceBigipGtmMonitorHttp(),
-		"https":      resourceBigipGtmMonitorHttps(),
-		"tcp":        resourceBigipGtmMonitorTcp(),
-		"postgresql": resourceBigipGtmMonitorPostgresql(),
-		"bigip":      resourceBigipGtmMonitorBigip(),
-	}
+	resources := gtmMonitorResources()
 
 	for monitorType, resource := range resources {
 		t.Run(monitorType, func(t *testing.T) {
@@ -294,13 +443,7 @@ func TestResourceBigipGtmMonitorNameValidation(t *testing.T) {
 }
 
 func TestResourceBigipGtmMonitorDescriptions(t *testing.T) {
-	resources := map[string]*schema.Resource{
-		"http":       resourceBigipGtmMonitorHttp(),
-		"https":      resourceBigipGtmMonitorHttps(),
-		"tcp":        resourceBigipGtmMonitorTcp(),
-		"postgresql": resourceBigipGtmMonitorPostgresql(),
-		"bigip":      resourceBigipGtmMonitorBigip(),
-	}
+	resources := gtmMonitorResources()
 
 	for monitorType, resource := range resources {
 		t.Run(monitorType, func(t *testing.T) {
@@ -440,13 +583,7 @@ func TestValidateGtmBigipDestination(t *testing.T) {
 
 func TestResourceBigipGtmMonitorIntervalTimeoutValidation(t *testing.T) {
 	// Verify timeout is always greater than interval in defaults
-	resources := map[string]*schema.Resource{
-		"http":       resourceBigipGtmMonitorHttp(),
-		"https":      resourceBigipGtmMonitorHttps(),
-		"tcp":        resourceBigipGtmMonitorTcp(),
-		"postgresql": resourceBigipGtmMonitorPostgresql(),
-		"bigip":      resourceBigipGtmMonitorBigip(),
-	}
+	resources := gtmMonitorResources()
 
 	for monitorType, resource := range resources {
 		t.Run(monitorType, func(t *testing.T) {
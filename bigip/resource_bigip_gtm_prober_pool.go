@@ -0,0 +1,189 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipGtmProberPool() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmProberPoolCreate,
+		ReadContext:   resourceBigipGtmProberPoolRead,
+		UpdateContext: resourceBigipGtmProberPoolUpdate,
+		DeleteContext: resourceBigipGtmProberPoolDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the GTM prober pool",
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Common",
+				ForceNew:    true,
+				Description: "Partition of the GTM prober pool",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the prober pool",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable or disable the prober pool",
+			},
+			"load_balancing_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "global-availability",
+				ValidateFunc: validation.StringInSlice([]string{"global-availability", "round-robin"}, false),
+				Description:  "How probe requests are distributed across the pool members",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ordered list of BIG-IP GTM server full paths used as probers, e.g. /Common/server1",
+			},
+		},
+	}
+}
+
+func resourceBigipGtmProberPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+
+	log.Printf("[INFO] Creating GTM Prober Pool: %s in partition %s", name, partition)
+
+	pool := getGtmProberPoolConfig(d)
+	pool.Name = name
+	pool.Partition = partition
+
+	err := client.CreateGTMProberPool(pool)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating GTM Prober Pool %s: %v", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("/%s/%s", partition, name))
+
+	return resourceBigipGtmProberPoolRead(ctx, d, meta)
+}
+
+func resourceBigipGtmProberPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Reading GTM Prober Pool: %s", fullPath)
+
+	pool, err := client.GetGTMProberPool(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving GTM Prober Pool %s: %v", fullPath, err))
+	}
+	if pool == nil {
+		log.Printf("[WARN] GTM Prober Pool %s not found, removing from state", fullPath)
+		d.SetId("")
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullPath, "/"), "/", 2)
+	if len(parts) == 2 {
+		d.Set("partition", parts[0])
+		d.Set("name", parts[1])
+	} else {
+		d.Set("name", pool.Name)
+		if pool.Partition != "" {
+			d.Set("partition", pool.Partition)
+		}
+	}
+
+	d.Set("description", pool.Description)
+	d.Set("enabled", !pool.Disabled)
+	if pool.LoadBalancingMode != "" {
+		d.Set("load_balancing_mode", pool.LoadBalancingMode)
+	}
+
+	// BIG-IP returns members keyed by name; restore the configured order
+	members := pool.Members
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Order < members[j].Order
+	})
+	var memberNames []string
+	for _, m := range members {
+		if m.FullPath != "" {
+			memberNames = append(memberNames, m.FullPath)
+		} else {
+			memberNames = append(memberNames, m.Name)
+		}
+	}
+	d.Set("members", memberNames)
+
+	return nil
+}
+
+func resourceBigipGtmProberPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Updating GTM Prober Pool: %s", fullPath)
+
+	pool := getGtmProberPoolConfig(d)
+
+	err := client.ModifyGTMProberPool(fullPath, pool)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating GTM Prober Pool %s: %v", fullPath, err))
+	}
+
+	return resourceBigipGtmProberPoolRead(ctx, d, meta)
+}
+
+func resourceBigipGtmProberPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	fullPath := d.Id()
+	log.Printf("[INFO] Deleting GTM Prober Pool: %s", fullPath)
+
+	err := client.DeleteGTMProberPool(fullPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting GTM Prober Pool %s: %v", fullPath, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getGtmProberPoolConfig(d *schema.ResourceData) *bigip.GTMProberPool {
+	enabled := d.Get("enabled").(bool)
+	pool := &bigip.GTMProberPool{
+		Description:       d.Get("description").(string),
+		Enabled:           enabled,
+		Disabled:          !enabled,
+		LoadBalancingMode: d.Get("load_balancing_mode").(string),
+		Members:           []bigip.GTMProberPoolMember{},
+	}
+	for i, member := range listToStringSlice(d.Get("members").([]interface{})) {
+		pool.Members = append(pool.Members, bigip.GTMProberPoolMember{
+			Name:    member,
+			Order:   i,
+			Enabled: true,
+		})
+	}
+	return pool
+}
@@ -0,0 +1,131 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var TEST_GTM_PROBER_POOL_NAME = "test_prober_pool"
+
+func TestAccBigipGtmProberPool_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmProberPoolDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmProberPoolConfig("global-availability"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmProberPoolExists(TEST_GTM_PROBER_POOL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_gtm_prober_pool.test-prober-pool", "name", TEST_GTM_PROBER_POOL_NAME),
+					resource.TestCheckResourceAttr("bigip_gtm_prober_pool.test-prober-pool", "load_balancing_mode", "global-availability"),
+					resource.TestCheckResourceAttr("bigip_gtm_prober_pool.test-prober-pool", "members.#", "1"),
+					resource.TestCheckResourceAttr("bigip_gtm_prober_pool.test-prober-pool", "members.0", "/Common/test_prober_server"),
+				),
+			},
+			{
+				Config: testAccBigipGtmProberPoolConfig("round-robin"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmProberPoolExists(TEST_GTM_PROBER_POOL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_gtm_prober_pool.test-prober-pool", "load_balancing_mode", "round-robin"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmProberPool_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmProberPoolDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmProberPoolConfig("global-availability"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmProberPoolExists(TEST_GTM_PROBER_POOL_NAME, true),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_prober_pool.test-prober-pool",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("/Common/%s", TEST_GTM_PROBER_POOL_NAME),
+			},
+		},
+	})
+}
+
+func testCheckGtmProberPoolExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+		fullPath := fmt.Sprintf("/Common/%s", name)
+
+		pool, err := client.GetGTMProberPool(fullPath)
+		if err != nil && exists {
+			return err
+		}
+		if exists && pool == nil {
+			return fmt.Errorf("GTM Prober Pool %s does not exist", fullPath)
+		}
+		if !exists && pool != nil {
+			return fmt.Errorf("GTM Prober Pool %s still exists", fullPath)
+		}
+		return nil
+	}
+}
+
+func testCheckGtmProberPoolDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_gtm_prober_pool" {
+			continue
+		}
+
+		pool, err := client.GetGTMProberPool(rs.Primary.ID)
+		if err != nil {
+			return nil
+		}
+		if pool != nil {
+			return fmt.Errorf("GTM Prober Pool %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccBigipGtmProberPoolConfig(mode string) string {
+	return fmt.Sprintf(`
+resource "bigip_gtm_datacenter" "test-prober-dc" {
+  name      = "test_prober_dc"
+  partition = "Common"
+}
+
+resource "bigip_gtm_server" "test-prober-server" {
+  name       = "test_prober_server"
+  partition  = "Common"
+  datacenter = bigip_gtm_datacenter.test-prober-dc.id
+  product    = "bigip"
+  monitor    = "/Common/bigip"
+
+  addresses {
+    name        = "10.10.10.20"
+    translation = "none"
+  }
+}
+
+resource "bigip_gtm_prober_pool" "test-prober-pool" {
+  name                = "%s"
+  load_balancing_mode = "%s"
+  members             = [bigip_gtm_server.test-prober-server.id]
+}
+`, TEST_GTM_PROBER_POOL_NAME, mode)
+}
@@ -404,10 +404,14 @@ func resourceBigipGtmServerRead(ctx context.Context, d *schema.ResourceData, met
 		d.Set("addresses", addresses)
 	}
 
-	// Handle virtual servers
-	if len(server.GTMVirtual_Server) > 0 {
-		virtualServers := make([]interface{}, len(server.GTMVirtual_Server))
-		for i, vs := range server.GTMVirtual_Server {
+	// Handle virtual servers. Only entries managed through the inline
+	// virtual_servers attribute are tracked; virtual servers created by
+	// bigip_gtm_server_virtual_server or discovered by the system are ignored.
+	managedVS := gtmServerVirtualServerNameList(d.Get("virtual_servers").([]interface{}))
+	inlineVS := filterGtmServerVirtualServers(server.GTMVirtual_Server, managedVS)
+	if len(inlineVS) > 0 || len(managedVS) > 0 {
+		virtualServers := make([]interface{}, len(inlineVS))
+		for i, vs := range inlineVS {
 			// Handle translation_address - convert "none" to empty string
 			translationAddr := vs.TranslationAddress
 			if translationAddr == "none" {
@@ -607,9 +611,12 @@ func resourceBigipGtmServerUpdate(ctx context.Context, d *schema.ResourceData, m
 			}
 		}
 
-		// Delete virtual servers that are no longer configured
+		// Delete virtual servers that were removed from the inline list. Virtual
+		// servers that were never listed inline are managed elsewhere and left alone.
+		oldVS, _ := d.GetChange("virtual_servers")
+		previouslyManaged := gtmServerVirtualServerNames(oldVS.([]interface{}))
 		for vsName := range existingVS {
-			if !configuredVS[vsName] {
+			if previouslyManaged[vsName] && !configuredVS[vsName] {
 				log.Printf("[INFO] Deleting virtual server %s from GTM server %s", vsName, name)
 				err := client.DeleteGTMServerVirtualServer(name, vsName)
 				if err != nil {
@@ -642,3 +649,42 @@ func resourceBigipGtmServerDelete(ctx context.Context, d *schema.ResourceData, m
 	d.SetId("")
 	return nil
 }
+
+// gtmServerVirtualServerNameList returns the names of the virtual servers in
+// an inline virtual_servers list, in configuration order.
+func gtmServerVirtualServerNameList(virtualServers []interface{}) []string {
+	var names []string
+	for _, vsRaw := range virtualServers {
+		if vsMap, ok := vsRaw.(map[string]interface{}); ok {
+			names = append(names, vsMap["name"].(string))
+		}
+	}
+	return names
+}
+
+// gtmServerVirtualServerNames returns the names of the virtual servers in an
+// inline virtual_servers list as a set.
+func gtmServerVirtualServerNames(virtualServers []interface{}) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range gtmServerVirtualServerNameList(virtualServers) {
+		names[name] = true
+	}
+	return names
+}
+
+// filterGtmServerVirtualServers returns the virtual servers named in managed,
+// in the order of managed. Managed names missing on BIG-IP are skipped so that
+// the difference shows up as drift.
+func filterGtmServerVirtualServers(all []bigip.VSrecord, managed []string) []bigip.VSrecord {
+	byName := make(map[string]bigip.VSrecord, len(all))
+	for _, vs := range all {
+		byName[vs.Name] = vs
+	}
+	var filtered []bigip.VSrecord
+	for _, name := range managed {
+		if vs, ok := byName[name]; ok {
+			filtered = append(filtered, vs)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,257 @@
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceBigipGtmServerVirtualServer manages a single virtual server on a GTM
+// server, so that virtual servers can be owned by different configurations
+// than the server itself (compare bigip_ltm_pool_attachment).
+func resourceBigipGtmServerVirtualServer() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipGtmServerVirtualServerCreate,
+		ReadContext:   resourceBigipGtmServerVirtualServerRead,
+		UpdateContext: resourceBigipGtmServerVirtualServerUpdate,
+		DeleteContext: resourceBigipGtmServerVirtualServerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Full path of the GTM server the virtual server belongs to, e.g. /Common/server1",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the virtual server",
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Destination IP address and port (format: ip:port)",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable or disable the virtual server",
+			},
+			"translation_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Translation IP address for NAT",
+			},
+			"translation_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Translation port for NAT",
+			},
+			"monitor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Health monitor for this virtual server",
+			},
+			"limit_max_bps": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum bits per second limit",
+			},
+			"limit_max_bps_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+				Description:  "Enable or disable max bps limit (enabled/disabled)",
+			},
+			"limit_max_connections": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum connections limit",
+			},
+			"limit_max_connections_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+				Description:  "Enable or disable max connections limit (enabled/disabled)",
+			},
+			"limit_max_pps": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum packets per second limit",
+			},
+			"limit_max_pps_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validateEnabledDisabled,
+				Description:  "Enable or disable max pps limit (enabled/disabled)",
+			},
+			"dependency": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Full path of the virtual server this virtual server depends on",
+			},
+		},
+	}
+}
+
+// parseGtmServerVirtualServerID splits an id of the form <server>:<name>
+func parseGtmServerVirtualServerID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid GTM server virtual server id %q, expected <server full path>:<virtual server name>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceBigipGtmServerVirtualServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	server := d.Get("server").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Creating virtual server %s on GTM Server %s", name, server)
+
+	vs := getGtmServerVirtualServerConfig(d)
+	vs.Name = name
+
+	err := client.CreateGTMServerVirtualServer(server, vs)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating virtual server %s on GTM server %s: %v", name, server, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", server, name))
+
+	return resourceBigipGtmServerVirtualServerRead(ctx, d, meta)
+}
+
+func resourceBigipGtmServerVirtualServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	server, name, err := parseGtmServerVirtualServerID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading virtual server %s on GTM Server %s", name, server)
+
+	vs, err := client.GetGTMServerVirtualServer(server, name)
+	if err != nil {
+		if strings.Contains(err.Error(), "was not found") || strings.Contains(err.Error(), "01020036") {
+			log.Printf("[WARN] Virtual server %s on GTM server %s not found, removing from state", name, server)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error retrieving virtual server %s on GTM server %s: %v", name, server, err))
+	}
+	if vs == nil {
+		log.Printf("[WARN] Virtual server %s on GTM server %s not found, removing from state", name, server)
+		d.SetId("")
+		return nil
+	}
+
+	// Handle translation_address - convert "none" to empty string
+	translationAddr := vs.TranslationAddress
+	if translationAddr == "none" {
+		translationAddr = ""
+	}
+
+	d.Set("server", server)
+	d.Set("name", name)
+	d.Set("destination", vs.Destination)
+	d.Set("enabled", !vs.Disabled)
+	d.Set("translation_address", translationAddr)
+	d.Set("translation_port", vs.TranslationPort)
+	d.Set("monitor", vs.Monitor)
+	d.Set("limit_max_bps", vs.LimitMaxBps)
+	d.Set("limit_max_bps_status", vs.LimitMaxBpsStatus)
+	d.Set("limit_max_connections", vs.LimitMaxConnections)
+	d.Set("limit_max_connections_status", vs.LimitMaxConnectionsStatus)
+	d.Set("limit_max_pps", vs.LimitMaxPps)
+	d.Set("limit_max_pps_status", vs.LimitMaxPpsStatus)
+	d.Set("dependency", vs.DependsOn)
+
+	return nil
+}
+
+func resourceBigipGtmServerVirtualServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	server, name, err := parseGtmServerVirtualServerID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating virtual server %s on GTM Server %s", name, server)
+
+	vs := getGtmServerVirtualServerConfig(d)
+	vs.Name = name
+
+	err = client.ModifyGTMServerVirtualServer(server, name, vs)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating virtual server %s on GTM server %s: %v", name, server, err))
+	}
+
+	return resourceBigipGtmServerVirtualServerRead(ctx, d, meta)
+}
+
+func resourceBigipGtmServerVirtualServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+
+	server, name, err := parseGtmServerVirtualServerID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting virtual server %s from GTM Server %s", name, server)
+
+	err = client.DeleteGTMServerVirtualServer(server, name)
+	if err != nil {
+		if strings.Contains(err.Error(), "was not found") || strings.Contains(err.Error(), "01020036") {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error deleting virtual server %s from GTM server %s: %v", name, server, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getGtmServerVirtualServerConfig(d *schema.ResourceData) *bigip.VSrecord {
+	enabled := d.Get("enabled").(bool)
+	return &bigip.VSrecord{
+		Destination:               d.Get("destination").(string),
+		Enabled:                   enabled,
+		Disabled:                  !enabled,
+		TranslationAddress:        d.Get("translation_address").(string),
+		TranslationPort:           d.Get("translation_port").(int),
+		Monitor:                   d.Get("monitor").(string),
+		LimitMaxBps:               d.Get("limit_max_bps").(int),
+		LimitMaxBpsStatus:         d.Get("limit_max_bps_status").(string),
+		LimitMaxConnections:       d.Get("limit_max_connections").(int),
+		LimitMaxConnectionsStatus: d.Get("limit_max_connections_status").(string),
+		LimitMaxPps:               d.Get("limit_max_pps").(int),
+		LimitMaxPpsStatus:         d.Get("limit_max_pps_status").(string),
+		DependsOn:                 d.Get("dependency").(string),
+	}
+}
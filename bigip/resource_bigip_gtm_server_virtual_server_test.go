@@ -0,0 +1,175 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var TEST_GTM_SERVER_VS_SERVER = "test_gtm_server_standalone_vs"
+
+func TestAccBigipGtmServerVirtualServer_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmServerVirtualServerDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmServerVirtualServerConfig("10.20.30.50:8080"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmServerVirtualServerExists("/Common/"+TEST_GTM_SERVER_VS_SERVER, "app_vs"),
+					resource.TestCheckResourceAttr("bigip_gtm_server_virtual_server.app", "id", "/Common/"+TEST_GTM_SERVER_VS_SERVER+":app_vs"),
+					resource.TestCheckResourceAttr("bigip_gtm_server_virtual_server.app", "destination", "10.20.30.50:8080"),
+					// the inline list only tracks its own entry
+					resource.TestCheckResourceAttr("bigip_gtm_server.owner", "virtual_servers.#", "1"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.owner", "virtual_servers.0.name", "inline_vs"),
+				),
+			},
+			{
+				Config: testAccBigipGtmServerVirtualServerConfig("10.20.30.50:8443"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_server_virtual_server.app", "destination", "10.20.30.50:8443"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.owner", "virtual_servers.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "bigip_gtm_server_virtual_server.app",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "/Common/" + TEST_GTM_SERVER_VS_SERVER + ":app_vs",
+			},
+		},
+	})
+}
+
+func TestAccBigipGtmServerVirtualServer_dataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmServerVirtualServerDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmServerVirtualServerConfig("10.20.30.50:8080") + `
+data "bigip_gtm_server_virtual_servers" "all" {
+  name       = bigip_gtm_server.owner.name
+  depends_on = [bigip_gtm_server_virtual_server.app]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_gtm_server_virtual_servers.all", "virtual_servers.#", "2"),
+					resource.TestCheckResourceAttrSet("data.bigip_gtm_server_virtual_servers.all", "virtual_servers.0.availability_state"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseGtmServerVirtualServerID(t *testing.T) {
+	server, name, err := parseGtmServerVirtualServerID("/Common/server1:/Common/vs_http")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server != "/Common/server1" || name != "/Common/vs_http" {
+		t.Errorf("unexpected parse result %q, %q", server, name)
+	}
+	for _, id := range []string{"/Common/server1", ":vs", "/Common/server1:"} {
+		if _, _, err := parseGtmServerVirtualServerID(id); err == nil {
+			t.Errorf("expected an error for id %q", id)
+		}
+	}
+}
+
+func TestFilterGtmServerVirtualServers(t *testing.T) {
+	all := []bigip.VSrecord{{Name: "discovered"}, {Name: "b"}, {Name: "standalone"}, {Name: "a"}}
+
+	filtered := filterGtmServerVirtualServers(all, []string{"a", "b", "gone"})
+	if len(filtered) != 2 || filtered[0].Name != "a" || filtered[1].Name != "b" {
+		t.Errorf("expected [a b] in configuration order, got %+v", filtered)
+	}
+
+	if filtered := filterGtmServerVirtualServers(all, nil); len(filtered) != 0 {
+		t.Errorf("expected no virtual servers when none are managed inline, got %+v", filtered)
+	}
+
+	names := gtmServerVirtualServerNames([]interface{}{
+		map[string]interface{}{"name": "a"},
+		map[string]interface{}{"name": "b"},
+	})
+	if !names["a"] || !names["b"] || names["standalone"] {
+		t.Errorf("unexpected managed names %v", names)
+	}
+}
+
+func testCheckGtmServerVirtualServerExists(server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		vs, err := client.GetGTMServerVirtualServer(server, name)
+		if err != nil {
+			return err
+		}
+		if vs == nil {
+			return fmt.Errorf("virtual server %s on GTM server %s does not exist", name, server)
+		}
+		return nil
+	}
+}
+
+func testCheckGtmServerVirtualServerDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_gtm_server_virtual_server" {
+			continue
+		}
+		server, name, err := parseGtmServerVirtualServerID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		vs, err := client.GetGTMServerVirtualServer(server, name)
+		if err == nil && vs != nil {
+			return fmt.Errorf("virtual server %s on GTM server %s still exists", name, server)
+		}
+	}
+	return nil
+}
+
+func testAccBigipGtmServerVirtualServerConfig(destination string) string {
+	return fmt.Sprintf(`
+resource "bigip_gtm_datacenter" "test-datacenter" {
+  name      = "test_datacenter"
+  partition = "Common"
+}
+
+resource "bigip_gtm_server" "owner" {
+  name       = "%s"
+  partition  = "Common"
+  datacenter = bigip_gtm_datacenter.test-datacenter.id
+  product    = "generic-host"
+
+  virtual_server_discovery = "disabled"
+
+  addresses {
+    name = "10.20.30.50"
+  }
+
+  virtual_servers {
+    name        = "inline_vs"
+    destination = "10.20.30.50:80"
+  }
+}
+
+resource "bigip_gtm_server_virtual_server" "app" {
+  server      = bigip_gtm_server.owner.id
+  name        = "app_vs"
+  destination = "%s"
+}
+`, TEST_GTM_SERVER_VS_SERVER, destination)
+}
@@ -163,6 +163,14 @@ func resourceBigipGtmWideip() *schema.Resource {
 				},
 				Description: "Specifies the pools this WideIP uses for load balancing",
 			},
+			"irules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Ordered list of GTM iRules attached to the WideIP (e.g., '/Common/my_gtm_rule')",
+			},
 		},
 	}
 }
@@ -282,6 +290,10 @@ func resourceBigipGtmWideipRead(ctx context.Context, d *schema.ResourceData, met
 	}
 	d.Set("pools", pools)
 
+	// Handle iRules — always set so that detaching a rule outside of
+	// Terraform is reported as drift. The device preserves rule order.
+	d.Set("irules", wideip.Rules)
+
 	return nil
 }
 
@@ -351,6 +363,10 @@ func resourceBigipGtmWideipUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 	wideip.Pools = pools
 
+	// Handle iRules — always send a list so that removing every rule from
+	// the config detaches them on the device.
+	wideip.Rules = listToStringSlice(d.Get("irules").([]interface{}))
+
 	err := client.ModifyGTMWideIP(fullPath, wideip, recordType)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating GTM WideIP (%s): %s", fullPath, err))
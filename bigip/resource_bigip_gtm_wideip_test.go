@@ -89,6 +89,41 @@ func TestAccBigipGtmWideip_import(t *testing.T) {
 	})
 }
 
+func TestAccBigipGtmWideip_irules(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckGtmWideipDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipGtmWideipConfigIRules(`[bigip_gtm_irule.rule1.id, bigip_gtm_irule.rule2.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGtmWideipExists(TEST_WIDEIP_NAME, TEST_WIDEIP_TYPE, true),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "irules.#", "2"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "irules.0", "/Common/test_wideip_rule1"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "irules.1", "/Common/test_wideip_rule2"),
+				),
+			},
+			{
+				Config: testAccBigipGtmWideipConfigIRules(`[bigip_gtm_irule.rule2.id, bigip_gtm_irule.rule1.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "irules.#", "2"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "irules.0", "/Common/test_wideip_rule2"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "irules.1", "/Common/test_wideip_rule1"),
+				),
+			},
+			{
+				Config: testAccBigipGtmWideipConfigIRules(`[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "irules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testCheckGtmWideipExists(name, wideipType string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*bigip.BigIP)
@@ -175,3 +210,32 @@ resource "bigip_gtm_wideip" "test-wideip" {
 }
 `, TEST_WIDEIP_NAME, TEST_WIDEIP_TYPE)
 }
+
+func testAccBigipGtmWideipConfigIRules(irules string) string {
+	return fmt.Sprintf(`
+resource "bigip_gtm_irule" "rule1" {
+  name  = "test_wideip_rule1"
+  irule = <<EOF
+when DNS_REQUEST {
+  log local0. "rule1 [IP::client_addr]"
+}
+EOF
+}
+
+resource "bigip_gtm_irule" "rule2" {
+  name  = "test_wideip_rule2"
+  irule = <<EOF
+when DNS_REQUEST {
+  log local0. "rule2 [IP::client_addr]"
+}
+EOF
+}
+
+resource "bigip_gtm_wideip" "test-wideip" {
+  name      = "%s"
+  type      = "%s"
+  partition = "Common"
+  irules    = %s
+}
+`, TEST_WIDEIP_NAME, TEST_WIDEIP_TYPE, irules)
+}
@@ -283,7 +283,7 @@ func resourceBigipLtmProfileClientSsl() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
-				Description: "Certificate revocation file name",
+				Description: "Certificate revocation list the client certificates are checked against, e.g. the full_path of a bigip_ssl_crl",
 			},
 			"allow_expired_crl": {
 				Type:        schema.TypeString,
@@ -337,7 +337,7 @@ func resourceBigipLtmProfileClientSsl() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "disabled",
-				Description: "Specifies whether the system uses OCSP stapling.",
+				Description: "Specifies whether the system staples the OCSP response of the OCSP responder of the profile certificates, `enabled` / `disabled`.",
 			},
 
 			"tm_options": {
@@ -147,6 +147,20 @@ func resourceBigipLtmProfileServerSsl() *schema.Resource {
 				Description: "Client certificate file path.  Default None.",
 			},
 
+			"crl_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Certificate revocation list the server certificates are checked against, e.g. the full_path of a bigip_ssl_crl",
+			},
+
+			"allow_expired_crl": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "allow_expired_crl option to be `enabled` / `disabled`.  Default is `disabled`.",
+			},
+
 			"cache_size": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -491,6 +505,8 @@ func resourceBigipLtmProfileServerSslRead(ctx context.Context, d *schema.Resourc
 	_ = d.Set("c3d_cert_extension_includes", obj.C3dCertExtensionIncludes)
 	_ = d.Set("c3d_cert_lifespan", obj.C3dCertLifespan)
 	_ = d.Set("ca_file", obj.CaFile)
+	_ = d.Set("crl_file", obj.CrlFile)
+	_ = d.Set("allow_expired_crl", obj.AllowExpiredCrl)
 	_ = d.Set("cert", obj.Cert)
 	_ = d.Set("chain", obj.Chain)
 	if _, ok := d.GetOk("ciphers"); ok {
@@ -631,6 +647,8 @@ func getServerSslConfig(d *schema.ResourceData, config *bigip.ServerSSLProfile)
 	config.C3dCertExtensionIncludes = c3dCertExtensionIncludes
 	config.C3dCertLifespan = d.Get("c3d_cert_lifespan").(int)
 	config.CaFile = d.Get("ca_file").(string)
+	config.CrlFile = d.Get("crl_file").(string)
+	config.AllowExpiredCrl = d.Get("allow_expired_crl").(string)
 	config.CacheSize = d.Get("cache_size").(int)
 	config.CacheTimeout = d.Get("cache_timeout").(int)
 	config.Cert = d.Get("cert").(string)
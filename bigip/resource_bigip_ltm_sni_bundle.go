@@ -0,0 +1,360 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// sniProfileNameRegex matches the characters not allowed in the name of a
+// child profile, e.g. the * of a wildcard server name.
+var sniProfileNameRegex = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// sniBundleServer is one server block of a bigip_ltm_sni_bundle.
+type sniBundleServer struct {
+	ServerName string
+	Cert       string
+	Key        string
+	Chain      string
+	Passphrase string
+}
+
+func resourceBigipLtmSniBundle() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipLtmSniBundleCreate,
+		ReadContext:   resourceBigipLtmSniBundleRead,
+		UpdateContext: resourceBigipLtmSniBundleUpdate,
+		DeleteContext: resourceBigipLtmSniBundleDelete,
+		CustomizeDiff: resourceBigipLtmSniBundleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the bundle, the child profiles are named <name>_<server_name>",
+			},
+			"partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Common",
+				ForceNew:     true,
+				ValidateFunc: validatePartitionName,
+				Description:  "Partition of the child profiles",
+			},
+			"defaults_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/Common/clientssl",
+				Description: "Client SSL profile the child profiles inherit their other settings from",
+			},
+			"default_server_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Server name whose profile is the SNI default, used for clients that send no or an unknown server name",
+			},
+			"sni_require": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reject clients that send no server name",
+			},
+			"server": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Certificate and key served for a server name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Server name sent by the clients, e.g. www.example.com or *.example.com",
+						},
+						"cert": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Full path of the certificate, e.g. /Common/www.example.com.crt",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Full path of the key, e.g. /Common/www.example.com.key",
+						},
+						"chain": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Full path of the certificate chain",
+						},
+						"passphrase": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Passphrase of the key",
+						},
+					},
+				},
+			},
+			"profiles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Full paths of the child profiles, for the client_profiles of bigip_ltm_virtual_server",
+			},
+			"default_profile": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the child profile that is the SNI default",
+			},
+		},
+	}
+}
+
+func resourceBigipLtmSniBundleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := fmt.Sprintf("/%s/%s", d.Get("partition").(string), d.Get("name").(string))
+	log.Printf("[INFO] Creating SNI bundle %s", name)
+	if err := sniBundleApply(client, d, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating SNI bundle %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipLtmSniBundleRead(ctx, d, meta)
+}
+
+func resourceBigipLtmSniBundleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading SNI bundle %s", name)
+	passphrases := make(map[string]string)
+	for _, server := range sniBundleServers(d.Get("server").(*schema.Set)) {
+		passphrases[server.ServerName] = server.Passphrase
+	}
+
+	var servers []interface{}
+	var profiles []string
+	defaultProfile, defaultServer := "", ""
+	sniRequire := false
+	for _, profile := range listToStringSlice(d.Get("profiles").([]interface{})) {
+		p, err := client.GetClientSSLProfile(profile)
+		if err != nil && strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] Client SSL profile %s of SNI bundle %s not found", profile, name)
+			continue
+		}
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving client SSL profile %s: %v", profile, err))
+		}
+		server := map[string]interface{}{"server_name": p.ServerName, "passphrase": passphrases[p.ServerName]}
+		if len(p.CertKeyChain) > 0 {
+			server["cert"] = p.CertKeyChain[0].Cert
+			server["key"] = p.CertKeyChain[0].Key
+			if p.CertKeyChain[0].Chain != "none" {
+				server["chain"] = p.CertKeyChain[0].Chain
+			}
+		}
+		servers = append(servers, server)
+		profiles = append(profiles, profile)
+		if p.SniDefault == "true" {
+			defaultProfile, defaultServer = profile, p.ServerName
+			sniRequire = p.SniRequire == "true"
+			_ = d.Set("defaults_from", p.DefaultsFrom)
+		}
+	}
+	if len(profiles) == 0 {
+		log.Printf("[WARN] SNI bundle %s has no profiles left, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("server", servers)
+	_ = d.Set("profiles", profiles)
+	_ = d.Set("default_profile", defaultProfile)
+	_ = d.Set("default_server_name", defaultServer)
+	_ = d.Set("sni_require", sniRequire)
+	return nil
+}
+
+func resourceBigipLtmSniBundleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Updating SNI bundle %s", name)
+	old, _ := d.GetChange("server")
+	if err := sniBundleApply(client, d, sniBundleServers(old.(*schema.Set))); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating SNI bundle %s: %v", name, err))
+	}
+	return resourceBigipLtmSniBundleRead(ctx, d, meta)
+}
+
+func resourceBigipLtmSniBundleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting SNI bundle %s", name)
+	// the default profile goes last, so the others never lose their default
+	var profiles []string
+	for _, profile := range listToStringSlice(d.Get("profiles").([]interface{})) {
+		if profile != d.Get("default_profile").(string) {
+			profiles = append(profiles, profile)
+		}
+	}
+	if defaultProfile := d.Get("default_profile").(string); defaultProfile != "" {
+		profiles = append(profiles, defaultProfile)
+	}
+	for _, profile := range profiles {
+		if err := client.DeleteClientSSLProfile(profile); err != nil && !strings.Contains(err.Error(), "not found") {
+			return diag.FromErr(fmt.Errorf("error deleting client SSL profile %s of SNI bundle %s: %v", profile, name, err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipLtmSniBundleCustomizeDiff checks the default server name and
+// plans the profile list, so virtual servers see the profile names at plan time.
+func resourceBigipLtmSniBundleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("server") || !d.NewValueKnown("default_server_name") {
+		return nil
+	}
+	servers := sniBundleServers(d.Get("server").(*schema.Set))
+	seen := make(map[string]bool)
+	var profiles []string
+	for _, server := range servers {
+		if server.ServerName == "" {
+			// not known until apply
+			return d.SetNewComputed("profiles")
+		}
+		if seen[server.ServerName] {
+			return fmt.Errorf("server name %s is in more than one server block", server.ServerName)
+		}
+		seen[server.ServerName] = true
+		profiles = append(profiles, sniProfileName(d.Get("partition").(string), d.Get("name").(string), server.ServerName))
+	}
+	defaultServer := d.Get("default_server_name").(string)
+	if !seen[defaultServer] {
+		return fmt.Errorf("default_server_name %s is not the server_name of a server block", defaultServer)
+	}
+	sort.Strings(profiles)
+	if d.HasChanges("name", "partition", "server", "default_server_name") || len(d.Get("profiles").([]interface{})) != len(profiles) {
+		if err := d.SetNew("profiles", profiles); err != nil {
+			return err
+		}
+		return d.SetNew("default_profile", sniProfileName(d.Get("partition").(string), d.Get("name").(string), defaultServer))
+	}
+	return nil
+}
+
+// sniBundleApply creates or modifies the child profiles of the servers and
+// deletes the profiles of the old servers that are gone. The profiles that
+// are not the default are handled first, so that no two profiles of the
+// bundle are the SNI default at the same time.
+func sniBundleApply(client *bigip.BigIP, d *schema.ResourceData, old []sniBundleServer) error {
+	partition, name := d.Get("partition").(string), d.Get("name").(string)
+	defaultServer := d.Get("default_server_name").(string)
+	var servers, defaults []sniBundleServer
+	for _, server := range sniBundleServers(d.Get("server").(*schema.Set)) {
+		if server.ServerName == defaultServer {
+			defaults = append(defaults, server)
+		} else {
+			servers = append(servers, server)
+		}
+	}
+	servers = append(servers, defaults...)
+
+	existing := make(map[string]bool)
+	for _, server := range old {
+		existing[server.ServerName] = true
+	}
+	for _, server := range servers {
+		profile := sniProfile(d, partition, name, server, server.ServerName == defaultServer)
+		if existing[server.ServerName] {
+			delete(existing, server.ServerName)
+			log.Printf("[INFO] Modifying client SSL profile %s for %s", profile.FullPath, server.ServerName)
+			if err := client.ModifyClientSSLProfile(profile.FullPath, profile); err != nil {
+				return fmt.Errorf("error modifying client SSL profile %s: %v", profile.FullPath, err)
+			}
+			continue
+		}
+		log.Printf("[INFO] Creating client SSL profile %s for %s", profile.FullPath, server.ServerName)
+		if err := client.CreateClientSSLProfile(profile); err != nil {
+			return fmt.Errorf("error creating client SSL profile %s: %v", profile.FullPath, err)
+		}
+	}
+	for serverName := range existing {
+		profile := sniProfileName(partition, name, serverName)
+		log.Printf("[INFO] Deleting client SSL profile %s of removed server %s", profile, serverName)
+		if err := client.DeleteClientSSLProfile(profile); err != nil {
+			return fmt.Errorf("error deleting client SSL profile %s: %v", profile, err)
+		}
+	}
+
+	var profiles []string
+	for _, server := range servers {
+		profiles = append(profiles, sniProfileName(partition, name, server.ServerName))
+	}
+	sort.Strings(profiles)
+	_ = d.Set("profiles", profiles)
+	_ = d.Set("default_profile", sniProfileName(partition, name, defaultServer))
+	return nil
+}
+
+// sniProfile returns the child client SSL profile of a server.
+func sniProfile(d *schema.ResourceData, partition, name string, server sniBundleServer, sniDefault bool) *bigip.ClientSSLProfile {
+	type certKeyChain struct {
+		Name       string `json:"name,omitempty"`
+		Cert       string `json:"cert,omitempty"`
+		Chain      string `json:"chain,omitempty"`
+		Key        string `json:"key,omitempty"`
+		Passphrase string `json:"passphrase,omitempty"`
+	}
+	path := sniProfileName(partition, name, server.ServerName)
+	profile := &bigip.ClientSSLProfile{
+		Name:         path,
+		FullPath:     path,
+		DefaultsFrom: d.Get("defaults_from").(string),
+		ServerName:   server.ServerName,
+		SniDefault:   fmt.Sprintf("%t", sniDefault),
+		SniRequire:   "false",
+	}
+	if sniDefault {
+		profile.SniRequire = fmt.Sprintf("%t", d.Get("sni_require").(bool))
+	}
+	profile.CertKeyChain = append(profile.CertKeyChain, certKeyChain{
+		Name:       sniProfileNameRegex.ReplaceAllString(server.ServerName, "_"),
+		Cert:       server.Cert,
+		Key:        server.Key,
+		Chain:      server.Chain,
+		Passphrase: server.Passphrase,
+	})
+	return profile
+}
+
+// sniProfileName returns the full path of the child profile of a server
+// name, e.g. /Common/web_www.example.com or /Common/web__.example.com for
+// *.example.com.
+func sniProfileName(partition, name, serverName string) string {
+	return fmt.Sprintf("/%s/%s_%s", partition, name, sniProfileNameRegex.ReplaceAllString(serverName, "_"))
+}
+
+func sniBundleServers(set *schema.Set) []sniBundleServer {
+	var servers []sniBundleServer
+	for _, s := range set.List() {
+		server := s.(map[string]interface{})
+		servers = append(servers, sniBundleServer{
+			ServerName: server["server_name"].(string),
+			Cert:       server["cert"].(string),
+			Key:        server["key"].(string),
+			Chain:      server["chain"].(string),
+			Passphrase: server["passphrase"].(string),
+		})
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].ServerName < servers[j].ServerName })
+	return servers
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipLtmSniBundleConfig = `
+resource "bigip_ltm_sni_bundle" "test" {
+  name                = "tf-acc-sni"
+  default_server_name = "%s"
+
+  server {
+    server_name = "www.example.com"
+    cert        = "/Common/default.crt"
+    key         = "/Common/default.key"
+  }
+
+  server {
+    server_name = "api.example.com"
+    cert        = "/Common/default.crt"
+    key         = "/Common/default.key"
+  }
+}
+`
+
+func TestAccBigipLtmSniBundleCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckLtmSniBundleDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBigipLtmSniBundleConfig, "www.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_sni_bundle.test", "profiles.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_sni_bundle.test", "default_profile", "/Common/tf-acc-sni_www.example.com"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccBigipLtmSniBundleConfig, "api.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_sni_bundle.test", "default_profile", "/Common/tf-acc-sni_api.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckLtmSniBundleDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ltm_sni_bundle" {
+			continue
+		}
+		for _, name := range []string{"/Common/tf-acc-sni_www.example.com", "/Common/tf-acc-sni_api.example.com"} {
+			if p, err := client.GetClientSSLProfile(name); err == nil && p != nil {
+				return fmt.Errorf("client SSL profile %s not destroyed", name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_ltm_sni_bundle against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeClientSslServer keeps client SSL profiles by tilde path. Like a BIG-IP
+// with all the profiles on one virtual server, it refuses a second SNI default.
+type fakeClientSslServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	profiles map[string]map[string]interface{}
+	requests []string
+}
+
+func newFakeClientSslServer(t *testing.T) *fakeClientSslServer {
+	f := &fakeClientSslServer{profiles: make(map[string]map[string]interface{})}
+	mux := http.NewServeMux()
+	sniDefaults := func(except string) int {
+		n := 0
+		for name, p := range f.profiles {
+			if name != except && p["sniDefault"] == "true" {
+				n++
+			}
+		}
+		return n
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/profile/client-ssl", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		name := strings.ReplaceAll(body["name"].(string), "/", "~")
+		f.requests = append(f.requests, "POST "+name)
+		w.Header().Set("Content-Type", "application/json")
+		if body["sniDefault"] == "true" && sniDefaults(name) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":400,"message":"01070097:3: More than one profile has sni-default enabled"}`))
+			return
+		}
+		f.profiles[name] = body
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/client-ssl/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/ltm/profile/client-ssl/")
+		f.requests = append(f.requests, r.Method+" "+name)
+		w.Header().Set("Content-Type", "application/json")
+		profile, ok := f.profiles[name]
+		if !ok {
+			fakeBigipNotFound(w, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			if body["sniDefault"] == "true" && sniDefaults(name) > 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"code":400,"message":"01070097:3: More than one profile has sni-default enabled"}`))
+				return
+			}
+			for k, v := range body {
+				profile[k] = v
+			}
+		case "DELETE":
+			delete(f.profiles, name)
+		}
+		_ = json.NewEncoder(w).Encode(profile)
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func testSniServer(serverName, cert string) map[string]interface{} {
+	return map[string]interface{}{"server_name": serverName, "cert": "/Common/" + cert + ".crt", "key": "/Common/" + cert + ".key"}
+}
+
+func TestResourceBigipLtmSniBundleLifecycle(t *testing.T) {
+	f := newFakeClientSslServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	r := resourceBigipLtmSniBundle()
+	config := map[string]interface{}{
+		"name":                "web",
+		"default_server_name": "www.example.com",
+		"sni_require":         true,
+		"server": []interface{}{
+			testSniServer("www.example.com", "www"),
+			testSniServer("api.example.com", "api"),
+			testSniServer("*.example.com", "wildcard"),
+		},
+	}
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	assert.NoError(t, err)
+	planned := []interface{}{"/Common/web__.example.com", "/Common/web_api.example.com", "/Common/web_www.example.com"}
+	assert.Equal(t, planned, d.Get("profiles"), "the profile names are known at plan time")
+	if diags := resourceBigipLtmSniBundleCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "/Common/web", d.Id())
+	assert.Equal(t, planned, d.Get("profiles"))
+	assert.Equal(t, "/Common/web_www.example.com", d.Get("default_profile"))
+	assert.Equal(t, "POST ~Common~web_www.example.com", f.requests[len(f.requests)-1-3], "the default profile is created last")
+
+	www := f.profiles["~Common~web_www.example.com"]
+	assert.Equal(t, "true", www["sniDefault"])
+	assert.Equal(t, "true", www["sniRequire"])
+	assert.Equal(t, "www.example.com", www["serverName"])
+	assert.Equal(t, "/Common/clientssl", www["defaultsFrom"])
+	wildcard := f.profiles["~Common~web__.example.com"]
+	assert.Equal(t, "false", wildcard["sniDefault"])
+	assert.Equal(t, "false", wildcard["sniRequire"])
+	assert.Equal(t, "*.example.com", wildcard["serverName"])
+	chain := wildcard["certKeyChain"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "/Common/wildcard.crt", chain["cert"])
+	assert.Equal(t, "/Common/wildcard.key", chain["key"])
+
+	// move the default to api, drop the wildcard and add shop
+	state := d.State()
+	config["default_server_name"] = "api.example.com"
+	config["server"] = []interface{}{
+		testSniServer("www.example.com", "www"),
+		testSniServer("api.example.com", "api-2026"),
+		testSniServer("shop.example.com", "shop"),
+	}
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	assert.NoError(t, err)
+	f.requests = nil
+	if diags := resourceBigipLtmSniBundleUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []interface{}{"/Common/web_api.example.com", "/Common/web_shop.example.com", "/Common/web_www.example.com"}, d.Get("profiles"))
+	assert.Equal(t, "/Common/web_api.example.com", d.Get("default_profile"))
+	assert.Equal(t, "false", f.profiles["~Common~web_www.example.com"]["sniDefault"])
+	assert.Equal(t, "true", f.profiles["~Common~web_api.example.com"]["sniDefault"])
+	assert.NotContains(t, f.profiles, "~Common~web__.example.com")
+	assert.Equal(t, "/Common/api-2026.crt", f.profiles["~Common~web_api.example.com"]["certKeyChain"].([]interface{})[0].(map[string]interface{})["cert"])
+
+	// a profile deleted on the device shows up as a missing server
+	f.mu.Lock()
+	delete(f.profiles, "~Common~web_shop.example.com")
+	f.mu.Unlock()
+	if diags := resourceBigipLtmSniBundleRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, 2, d.Get("server").(*schema.Set).Len())
+
+	if diags := resourceBigipLtmSniBundleDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.profiles)
+	assert.Equal(t, "DELETE ~Common~web_api.example.com", f.requests[len(f.requests)-1], "the default profile is deleted last")
+}
+
+func TestResourceBigipLtmSniBundleValidation(t *testing.T) {
+	r := resourceBigipLtmSniBundle()
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                "web",
+		"default_server_name": "shop.example.com",
+		"server":              []interface{}{testSniServer("www.example.com", "www")},
+	}), nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "default_server_name shop.example.com is not the server_name of a server block")
+	}
+
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                "web",
+		"default_server_name": "www.example.com",
+		"server":              []interface{}{testSniServer("www.example.com", "www"), testSniServer("www.example.com", "www-2026")},
+	}), nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "server name www.example.com is in more than one server block")
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var restObjectPath = regexp.MustCompile(`^/mgmt/[^\s~]+[^/\s]$`)
+
+func resourceBigipRestObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipRestObjectCreate,
+		ReadContext:   resourceBigipRestObjectRead,
+		UpdateContext: resourceBigipRestObjectUpdate,
+		DeleteContext: resourceBigipRestObjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBigipRestObjectImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(restObjectPath, "must be an iControl REST collection path, e.g. /mgmt/tm/ltm/profile/http2"),
+				Description:  "iControl REST path of the collection the object is created in, e.g. /mgmt/tm/ltm/profile/http2",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the object in the collection, e.g. /Common/my-http2, it is added to the body when the body has no name",
+			},
+			"body": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "JSON object of the fields of the object managed by Terraform, only these fields are compared with the BIG-IP",
+				StateFunc: func(v interface{}) string {
+					jsonString, _ := structure.NormalizeJsonString(v)
+					return jsonString
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					var oldJsonref, newJsonref map[string]interface{}
+					_ = json.Unmarshal([]byte(new), &newJsonref)
+					if old == "" && d.Id() != "" {
+						// an imported object has no body yet, the configuration is compared with the object on the BIG-IP
+						var device map[string]interface{}
+						_ = json.Unmarshal([]byte(d.Get("response").(string)), &device)
+						return device != nil && newJsonref != nil && reflect.DeepEqual(restObjectObserved(newJsonref, device), newJsonref)
+					}
+					_ = json.Unmarshal([]byte(old), &oldJsonref)
+					return oldJsonref != nil && reflect.DeepEqual(oldJsonref, newJsonref)
+				},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := restObjectBody(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%q %v", k, err))
+					}
+					return
+				},
+			},
+			"update_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PATCH",
+				ValidateFunc: validation.StringInSlice([]string{"PATCH", "PUT"}, false),
+				Description:  "Method updating the object, PATCH changes only the fields of the body, PUT resets the other fields to their defaults",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON of the whole object as returned by the BIG-IP",
+			},
+		},
+	}
+}
+
+func resourceBigipRestObjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	id := restObjectID(d.Get("path").(string), d.Get("name").(string))
+	body, err := restObjectBody(d.Get("body").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating REST object %s: body %v", id, err))
+	}
+	if _, ok := body["name"]; !ok {
+		body["name"] = d.Get("name").(string)
+	}
+	data, _ := json.Marshal(body)
+	log.Printf("[INFO] Creating REST object %s", id)
+	if _, err := client.RestCall("POST", d.Get("path").(string), data); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating REST object %s: %v", id, err))
+	}
+	d.SetId(id)
+	return resourceBigipRestObjectRead(ctx, d, meta)
+}
+
+func resourceBigipRestObjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	id := d.Id()
+	log.Printf("[INFO] Reading REST object %s", id)
+	resp, err := client.RestCall("GET", id, nil)
+	if err != nil && restObjectNotFound(err) {
+		log.Printf("[WARN] REST object %s not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving REST object %s: %v", id, err))
+	}
+	var device map[string]interface{}
+	if err := json.Unmarshal(resp, &device); err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving REST object %s: %v", id, err))
+	}
+	response, _ := json.Marshal(device)
+	_ = d.Set("response", string(response))
+	// an imported object has no body yet, the plan compares the configuration with the response
+	if body, err := restObjectBody(d.Get("body").(string)); err == nil {
+		observed, _ := json.Marshal(restObjectObserved(body, device))
+		_ = d.Set("body", string(observed))
+	}
+	return nil
+}
+
+func resourceBigipRestObjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	id := d.Id()
+	method := d.Get("update_method").(string)
+	body, err := restObjectBody(d.Get("body").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating REST object %s: body %v", id, err))
+	}
+	data, _ := json.Marshal(body)
+	log.Printf("[INFO] Updating REST object %s with %s", id, method)
+	if _, err := client.RestCall(method, id, data); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating REST object %s: %v", id, err))
+	}
+	return resourceBigipRestObjectRead(ctx, d, meta)
+}
+
+func resourceBigipRestObjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	id := d.Id()
+	log.Printf("[INFO] Deleting REST object %s", id)
+	if _, err := client.RestCall("DELETE", id, nil); err != nil && !restObjectNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error deleting REST object %s: %v", id, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipRestObjectImport splits the full REST path of the object,
+// e.g. /mgmt/tm/ltm/profile/http2/~Common~my-http2, in its collection path and name.
+func resourceBigipRestObjectImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	i := strings.LastIndex(id, "/")
+	if i < 0 || i == len(id)-1 || !restObjectPath.MatchString(id[:i]) {
+		return nil, fmt.Errorf("expected the REST path of the object, e.g. /mgmt/tm/ltm/profile/http2/~Common~my-http2, got %s", id)
+	}
+	_ = d.Set("path", id[:i])
+	_ = d.Set("name", strings.ReplaceAll(id[i+1:], "~", "/"))
+	_ = d.Set("update_method", "PATCH")
+	return []*schema.ResourceData{d}, nil
+}
+
+// restObjectID returns the REST path of the named object of the collection at path.
+func restObjectID(path, name string) string {
+	return path + "/" + strings.ReplaceAll(name, "/", "~")
+}
+
+// restObjectBody parses body, it must be a JSON object.
+func restObjectBody(body string) (map[string]interface{}, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &object); err != nil {
+		return nil, fmt.Errorf("must be a JSON object: %v", err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("must be a JSON object, got %s", body)
+	}
+	return object, nil
+}
+
+// restObjectObserved returns the fields of config with their values on the
+// device, nested objects, also in arrays, only with their configured fields.
+// The configured value is kept for the fields the device does not return,
+// such as passwords.
+func restObjectObserved(config, device map[string]interface{}) map[string]interface{} {
+	observed := make(map[string]interface{}, len(config))
+	for k, v := range config {
+		deviceValue, ok := device[k]
+		if !ok {
+			observed[k] = v
+			continue
+		}
+		observed[k] = restObjectObservedValue(v, deviceValue)
+	}
+	return observed
+}
+
+// restObjectObservedValue projects the device value of a field onto its
+// configured value. Array elements are projected onto the configured element
+// at the same index, the elements the device has in excess are kept as they are.
+func restObjectObservedValue(config, device interface{}) interface{} {
+	switch config := config.(type) {
+	case map[string]interface{}:
+		if nestedDevice, ok := device.(map[string]interface{}); ok {
+			return restObjectObserved(config, nestedDevice)
+		}
+	case []interface{}:
+		if deviceList, ok := device.([]interface{}); ok {
+			observed := make([]interface{}, len(deviceList))
+			for i, v := range deviceList {
+				if i < len(config) {
+					v = restObjectObservedValue(config[i], v)
+				}
+				observed[i] = v
+			}
+			return observed
+		}
+	}
+	return device
+}
+
+func restObjectNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "HTTP 404")
+}
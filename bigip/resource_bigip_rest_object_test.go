@@ -0,0 +1,78 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipRestObjectConfig = `
+resource "bigip_rest_object" "test" {
+  path = "/mgmt/tm/ltm/profile/http2"
+  name = "/Common/tf-acc-rest-http2"
+  body = jsonencode({
+    concurrentStreamsPerConnection = %d
+    connectionIdleTimeout          = 120
+  })
+}
+
+data "bigip_rest_object" "test" {
+  path = bigip_rest_object.test.path
+  name = bigip_rest_object.test.name
+}
+`
+
+func TestAccBigipRestObjectCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckRestObjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBigipRestObjectConfig, 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_rest_object.test", "id", "/mgmt/tm/ltm/profile/http2/~Common~tf-acc-rest-http2"),
+					resource.TestCheckResourceAttr("bigip_rest_object.test", "body", `{"concurrentStreamsPerConnection":20,"connectionIdleTimeout":120}`),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccBigipRestObjectConfig, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_rest_object.test", "body", `{"concurrentStreamsPerConnection":30,"connectionIdleTimeout":120}`),
+					resource.TestMatchResourceAttr("data.bigip_rest_object.test", "response", regexp.MustCompile(`"fullPath":"/Common/tf-acc-rest-http2"`)),
+				),
+			},
+			{
+				ResourceName:            "bigip_rest_object.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"body", "response"},
+			},
+		},
+	})
+}
+
+func testCheckRestObjectDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_rest_object" {
+			continue
+		}
+		if _, err := client.RestCall("GET", rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("REST object %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for the generic REST object resource against a fake BIG-IP - no F5 BIG-IP connection required
+
+const testRestObjectPath = "/mgmt/tm/ltm/profile/http2"
+
+// fakeRestObjectServer keeps the HTTP/2 profiles by name, it fills in the
+// defaults of the fields a request leaves out the way the BIG-IP does.
+type fakeRestObjectServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	objects  map[string]map[string]interface{}
+	requests []string
+	posted   map[string]interface{}
+}
+
+func fakeRestObjectDefaults(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":                           "tm:ltm:profile:http2:http2state",
+		"name":                           name,
+		"partition":                      "Common",
+		"fullPath":                       "/Common/" + name,
+		"defaultsFrom":                   "/Common/http2",
+		"concurrentStreamsPerConnection": float64(10),
+		"connectionIdleTimeout":          float64(300),
+		"enforceTlsRequirements":         "enabled",
+	}
+}
+
+func newFakeRestObjectServer(t *testing.T) *fakeRestObjectServer {
+	f := &fakeRestObjectServer{objects: make(map[string]map[string]interface{})}
+	mux := http.NewServeMux()
+	mux.HandleFunc(testRestObjectPath, func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.posted = body
+			name := strings.TrimPrefix(body["name"].(string), "/Common/")
+			object := fakeRestObjectDefaults(name)
+			for k, v := range body {
+				object[k] = v
+			}
+			object["name"] = name
+			f.objects[name] = object
+			_ = json.NewEncoder(w).Encode(object)
+			return
+		}
+		items := make([]interface{}, 0, len(f.objects))
+		for _, object := range f.objects {
+			items = append(items, object)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "tm:ltm:profile:http2:http2collectionstate", "items": items})
+	})
+	mux.HandleFunc(testRestObjectPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, testRestObjectPath+"/"), "~Common~")
+		object, ok := f.objects[name]
+		if !ok {
+			fakeBigipNotFound(w, "/Common/"+name)
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "PATCH":
+			for k, v := range body {
+				object[k] = v
+			}
+		case "PUT":
+			object = fakeRestObjectDefaults(name)
+			for k, v := range body {
+				object[k] = v
+			}
+			f.objects[name] = object
+		case "DELETE":
+			delete(f.objects, name)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(object)
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// testRestObjectPlan returns the plan of config against state.
+func testRestObjectPlan(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}, client *bigip.BigIP) *terraform.InstanceDiff {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return diff
+}
+
+func TestResourceBigipRestObject(t *testing.T) {
+	f := newFakeRestObjectServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipRestObject()
+
+	config := map[string]interface{}{
+		"path": testRestObjectPath,
+		"name": "/Common/h2",
+		"body": `{"concurrentStreamsPerConnection": 20, "activationModes": ["alpn"]}`,
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, testRestObjectPath+"/~Common~h2", d.Id())
+	assert.Equal(t, float64(20), f.objects["h2"]["concurrentStreamsPerConnection"])
+	assert.Equal(t, "/Common/h2", f.posted["name"], "the name is added to the body")
+	assert.JSONEq(t, `{"activationModes":["alpn"],"concurrentStreamsPerConnection":20}`, d.Get("body").(string))
+	var response map[string]interface{}
+	_ = json.Unmarshal([]byte(d.Get("response").(string)), &response)
+	assert.Equal(t, "enabled", response["enforceTlsRequirements"])
+
+	// the fields left out of the body are not compared
+	assert.Nil(t, testRestObjectPlan(t, r, d.State(), config, client))
+	f.objects["h2"]["connectionIdleTimeout"] = float64(600)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Nil(t, testRestObjectPlan(t, r, d.State(), config, client))
+
+	// a change of a field of the body on the BIG-IP is a drift
+	f.objects["h2"]["concurrentStreamsPerConnection"] = float64(5)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	diff := testRestObjectPlan(t, r, d.State(), config, client)
+	if assert.NotNil(t, diff) {
+		assert.Contains(t, diff.Attributes, "body")
+	}
+	d = testResourceApply(t, r, d.State(), config, client)
+	assert.Contains(t, f.requests, "PATCH "+testRestObjectPath+"/~Common~h2")
+	assert.Equal(t, float64(20), f.objects["h2"]["concurrentStreamsPerConnection"])
+	assert.Equal(t, float64(600), f.objects["h2"]["connectionIdleTimeout"], "PATCH keeps the other fields")
+
+	config["update_method"] = "PUT"
+	config["body"] = `{"concurrentStreamsPerConnection": 30}`
+	d = testResourceApply(t, r, d.State(), config, client)
+	assert.Contains(t, f.requests, "PUT "+testRestObjectPath+"/~Common~h2")
+	assert.Equal(t, float64(30), f.objects["h2"]["concurrentStreamsPerConnection"])
+	assert.Equal(t, float64(300), f.objects["h2"]["connectionIdleTimeout"], "PUT resets the other fields")
+	assert.JSONEq(t, `{"concurrentStreamsPerConnection":30}`, d.Get("body").(string))
+
+	// import by the REST path of the object
+	imported := r.Data(&terraform.InstanceState{ID: testRestObjectPath + "/~Common~h2"})
+	if _, err := resourceBigipRestObjectImport(context.Background(), imported, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), imported, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, testRestObjectPath, imported.Get("path"))
+	assert.Equal(t, "/Common/h2", imported.Get("name"))
+	assert.Contains(t, imported.Get("response"), `"concurrentStreamsPerConnection":30`)
+
+	// the first plan after the import only updates the object when the configuration does not match it
+	config["update_method"] = "PATCH"
+	assert.Nil(t, testRestObjectPlan(t, r, imported.State(), config, client))
+	config["body"] = `{"concurrentStreamsPerConnection": 40}`
+	diff = testRestObjectPlan(t, r, imported.State(), config, client)
+	if assert.NotNil(t, diff) {
+		assert.Contains(t, diff.Attributes, "body")
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.objects)
+	if diags := r.ReadContext(context.Background(), imported, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", imported.Id())
+}
+
+func TestResourceBigipRestObjectObserved(t *testing.T) {
+	config := map[string]interface{}{
+		"password": "secret",
+		"timeout":  float64(10),
+		"options":  map[string]interface{}{"mode": "fast"},
+	}
+	device := map[string]interface{}{
+		"timeout": float64(20),
+		"options": map[string]interface{}{"mode": "slow", "retries": float64(3)},
+		"kind":    "tm:sys:example",
+	}
+	assert.Equal(t, map[string]interface{}{
+		"password": "secret",
+		"timeout":  float64(20),
+		"options":  map[string]interface{}{"mode": "slow"},
+	}, restObjectObserved(config, device))
+
+	// objects in arrays are projected element by element
+	config = map[string]interface{}{
+		"members": []interface{}{map[string]interface{}{"name": "a:80"}},
+		"vlans":   []interface{}{"/Common/internal"},
+	}
+	device = map[string]interface{}{
+		"members": []interface{}{
+			map[string]interface{}{"name": "a:80", "address": "10.0.0.1", "selfLink": "https://localhost/mgmt/tm/ltm/pool/~Common~p/members/a:80"},
+		},
+		"vlans": []interface{}{"/Common/internal"},
+	}
+	assert.Equal(t, config, restObjectObserved(config, device))
+
+	// a member added on the device shows up as it is
+	device["members"] = append(device["members"].([]interface{}), map[string]interface{}{"name": "b:80"})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "a:80"},
+		map[string]interface{}{"name": "b:80"},
+	}, restObjectObserved(config, device)["members"])
+}
+
+func TestResourceBigipRestObjectBodyInvalid(t *testing.T) {
+	validate := resourceBigipRestObject().Schema["body"].ValidateFunc
+	for _, body := range []string{"null", "[]", `"name"`, "1", "{"} {
+		_, errs := validate(body, "body")
+		assert.NotEmpty(t, errs, body)
+	}
+	_, errs := validate(`{"name": "h2"}`, "body")
+	assert.Empty(t, errs)
+
+	f := newFakeRestObjectServer(t)
+	client := testFakeBigipClient(f.Server)
+	d := resourceBigipRestObject().Data(nil)
+	_ = d.Set("path", testRestObjectPath)
+	_ = d.Set("name", "/Common/h2")
+	_ = d.Set("body", "null")
+	assert.True(t, resourceBigipRestObjectCreate(context.Background(), d, client).HasError(), "a null body is an error, not a panic")
+	d.SetId(testRestObjectPath + "/~Common~h2")
+	assert.True(t, resourceBigipRestObjectUpdate(context.Background(), d, client).HasError())
+	assert.Empty(t, f.requests)
+}
+
+func TestResourceBigipRestObjectImportInvalid(t *testing.T) {
+	r := resourceBigipRestObject()
+	for _, id := range []string{"/Common/h2", "/mgmt/tm/ltm/profile/http2/", "h2"} {
+		d := r.Data(&terraform.InstanceState{ID: id})
+		_, err := resourceBigipRestObjectImport(context.Background(), d, nil)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestDataSourceBigipRestObject(t *testing.T) {
+	f := newFakeRestObjectServer(t)
+	client := testFakeBigipClient(f.Server)
+	f.objects["h2"] = fakeRestObjectDefaults("h2")
+	r := dataSourceBigipRestObject()
+
+	d := r.Data(nil)
+	_ = d.Set("path", testRestObjectPath)
+	_ = d.Set("name", "/Common/h2")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, testRestObjectPath+"/~Common~h2", d.Id())
+	assert.Contains(t, d.Get("response"), `"fullPath":"/Common/h2"`)
+
+	collection := r.Data(nil)
+	_ = collection.Set("path", testRestObjectPath)
+	if diags := r.ReadContext(context.Background(), collection, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Contains(t, collection.Get("response"), `"items":[`)
+
+	missing := r.Data(nil)
+	_ = missing.Set("path", testRestObjectPath)
+	_ = missing.Set("name", "/Common/missing")
+	assert.True(t, r.ReadContext(context.Background(), missing, client).HasError())
+}
@@ -0,0 +1,482 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sslAcmeNow is the time the renewal window is checked against, tests override it.
+var sslAcmeNow = time.Now
+
+// acmeChallengeIRule answers HTTP-01 challenges from the data group %[1]s,
+// "/.well-known/acme-challenge/" is 28 characters long.
+const acmeChallengeIRule = `when HTTP_REQUEST priority 100 {
+    if { [HTTP::path] starts_with "/.well-known/acme-challenge/" } {
+        set response [class lookup [string range [HTTP::path] 28 end] %[1]s]
+        if { $response ne "" } {
+            HTTP::respond 200 content $response "Content-Type" "text/plain"
+        }
+    }
+}`
+
+func resourceBigipSslAcmeCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSslAcmeCertificateCreate,
+		ReadContext:   resourceBigipSslAcmeCertificateRead,
+		UpdateContext: resourceBigipSslAcmeCertificateUpdate,
+		DeleteContext: resourceBigipSslAcmeCertificateDelete,
+		CustomizeDiff: resourceBigipSslAcmeCertificateCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the certificate on the BIG-IP, also the name of the generated key",
+			},
+			"partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Common",
+				ForceNew:     true,
+				ValidateFunc: validatePartitionName,
+				Description:  "Partition of the certificate and the generated key",
+			},
+			"directory_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+				Description:  "Directory URL of the ACME server, e.g. https://acme-v02.api.letsencrypt.org/directory",
+			},
+			"ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM bundle of the CAs trusted to verify the ACME server, by default the system roots are trusted",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Contact email address of the ACME account",
+			},
+			"account_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded ECDSA P-256 key of the ACME account, generated when not set",
+			},
+			"account_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the ACME account",
+			},
+			"common_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "DNS name of the certificate",
+			},
+			"subject_alternative_names": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional DNS names of the certificate",
+			},
+			"key_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Full path of an existing key on the BIG-IP to reuse, by default a key is generated",
+			},
+			"key_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "rsa-private",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"rsa-private", "ec-private"}, false),
+				Description:  "Type of the generated key, rsa-private or ec-private",
+			},
+			"key_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2048,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+				Description:  "Size of a generated RSA key in bits",
+			},
+			"curve_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "prime256v1",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"prime256v1", "secp384r1"}, false),
+				Description:  "Curve of a generated EC key",
+			},
+			"security_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "normal",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"normal", "fips"}, false),
+				Description:  "Security type of the generated key, normal or fips",
+			},
+			"virtual_server": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full path of the HTTP virtual server that receives the HTTP-01 challenges of the ACME server on port 80",
+			},
+			"renewal_window_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Renew the certificate when it expires within this many days",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Minutes to wait for the ACME server to validate the challenges and issue the certificate",
+			},
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the certificate",
+			},
+			"key_full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the key of the certificate",
+			},
+			"certificate_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded certificate chain installed on the BIG-IP",
+			},
+			"certificate_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the certificate on the ACME server",
+			},
+			"expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry of the certificate in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func resourceBigipSslAcmeCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	certPath := fmt.Sprintf("/%s/%s", d.Get("partition").(string), d.Get("name").(string))
+	keyPath := certPath
+	if v, ok := d.GetOk("key_name"); ok {
+		keyPath = v.(string)
+	} else {
+		log.Printf("[INFO] Generating key %s", keyPath)
+		if err := client.CreateCryptoKey(sslCryptoKey(d, keyPath)); err != nil {
+			return diag.FromErr(fmt.Errorf("error generating key %s: %v", keyPath, err))
+		}
+	}
+	_ = d.Set("key_full_path", keyPath)
+
+	log.Printf("[INFO] Issuing ACME certificate %s", certPath)
+	chain, err := acmeIssueCertificate(ctx, client, d)
+	if err == nil {
+		err = client.UploadCertificate(chain, &bigip.Certificate{Name: d.Get("name").(string), Partition: d.Get("partition").(string)})
+	}
+	if err != nil {
+		if _, ok := d.GetOk("key_name"); !ok {
+			if kerr := client.DeleteKey(keyPath); kerr != nil {
+				log.Printf("[WARN] Could not delete generated key %s: %v", keyPath, kerr)
+			}
+		}
+		return diag.FromErr(fmt.Errorf("error creating ACME certificate %s: %v", certPath, err))
+	}
+	_ = d.Set("certificate_pem", chain)
+	d.SetId(certPath)
+	return resourceBigipSslAcmeCertificateRead(ctx, d, meta)
+}
+
+func resourceBigipSslAcmeCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading ACME certificate %s", name)
+	cert, err := client.GetCertificate(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] ACME certificate %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving ACME certificate %s: %v", name, err))
+	}
+	_ = d.Set("full_path", name)
+	_ = d.Set("expiration", time.Unix(cert.ExpirationDate, 0).UTC().Format(time.RFC3339))
+	return nil
+}
+
+func resourceBigipSslAcmeCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	expiration, _ := d.GetChange("expiration")
+	if !d.HasChanges("common_name", "subject_alternative_names") && !acmeRenewalDue(expiration.(string), d.Get("renewal_window_days").(int)) {
+		return resourceBigipSslAcmeCertificateRead(ctx, d, meta)
+	}
+
+	log.Printf("[INFO] Renewing ACME certificate %s", name)
+	chain, err := acmeIssueCertificate(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error renewing ACME certificate %s: %v", name, err))
+	}
+	if err := client.UpdateCertificate(chain, &bigip.Certificate{Name: d.Get("name").(string), Partition: d.Get("partition").(string)}); err != nil {
+		return diag.FromErr(fmt.Errorf("error installing renewed ACME certificate %s: %v", name, err))
+	}
+	_ = d.Set("certificate_pem", chain)
+	return resourceBigipSslAcmeCertificateRead(ctx, d, meta)
+}
+
+func resourceBigipSslAcmeCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting ACME certificate %s", name)
+	if err := client.DeleteCertificate(name); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting ACME certificate %s: %v", name, err))
+	}
+	// a reused key belongs to whoever created it
+	if d.Get("key_name").(string) == "" {
+		keyPath := d.Get("key_full_path").(string)
+		if err := client.DeleteKey(keyPath); err != nil {
+			return diag.FromErr(fmt.Errorf("error deleting key %s: %v", keyPath, err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipSslAcmeCertificateCustomizeDiff plans a renewal once the
+// certificate expires within renewal_window_days.
+func resourceBigipSslAcmeCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !acmeRenewalDue(d.Get("expiration").(string), d.Get("renewal_window_days").(int)) {
+		return nil
+	}
+	log.Printf("[INFO] ACME certificate %s expires on %s, planning renewal", d.Id(), d.Get("expiration").(string))
+	for _, k := range []string{"certificate_pem", "certificate_url", "expiration"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// acmeRenewalDue reports whether a certificate expiring at expiration (RFC
+// 3339) expires within the renewal window.
+func acmeRenewalDue(expiration string, windowDays int) bool {
+	expires, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		return false
+	}
+	return !sslAcmeNow().AddDate(0, 0, windowDays).Before(expires)
+}
+
+// acmeIssueCertificate runs an ACME order for the names of the certificate
+// with a CSR signed by key_full_path on the BIG-IP, and returns the issued
+// PEM chain.
+func acmeIssueCertificate(ctx context.Context, client *bigip.BigIP, d *schema.ResourceData) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(d.Get("timeout").(int))*time.Minute)
+	defer cancel()
+
+	accountPEM := d.Get("account_key_pem").(string)
+	if accountPEM == "" {
+		var err error
+		if accountPEM, err = generateAcmeAccountKey(); err != nil {
+			return "", fmt.Errorf("error generating ACME account key: %v", err)
+		}
+		_ = d.Set("account_key_pem", accountPEM)
+	}
+	accountKey, err := parseAcmeAccountKey(accountPEM)
+	if err != nil {
+		return "", err
+	}
+	acme, err := newAcmeClient(ctx, d.Get("directory_url").(string), d.Get("ca_pem").(string), accountKey)
+	if err != nil {
+		return "", err
+	}
+	if err := acme.register(ctx, d.Get("email").(string)); err != nil {
+		return "", err
+	}
+	_ = d.Set("account_url", acme.kid)
+
+	names := []string{d.Get("common_name").(string)}
+	for _, san := range listToStringSlice(d.Get("subject_alternative_names").([]interface{})) {
+		if !contains(names, san) {
+			names = append(names, san)
+		}
+	}
+	order, orderURL, err := acme.newOrder(ctx, names)
+	if err != nil {
+		return "", err
+	}
+	certPath := fmt.Sprintf("/%s/%s", d.Get("partition").(string), d.Get("name").(string))
+	if err := acmeAnswerChallenges(ctx, client, acme, order, d.Get("virtual_server").(string), certPath+"_acme_challenge"); err != nil {
+		return "", err
+	}
+
+	csr, err := acmeCsr(client, certPath, d.Get("key_full_path").(string), names)
+	if err != nil {
+		return "", err
+	}
+	certURL, err := acme.finalize(ctx, order, orderURL, csr)
+	if err != nil {
+		return "", err
+	}
+	chain, err := acme.certificate(ctx, certURL)
+	if err != nil {
+		return "", err
+	}
+	_ = d.Set("certificate_url", certURL)
+	return chain, nil
+}
+
+// acmeAnswerChallenges serves the HTTP-01 challenges of the pending
+// authorizations of the order from a temporary data group and iRule named
+// challengeName on the virtual server, and waits for the ACME server to
+// validate them. The iRule and data group are removed again afterwards.
+func acmeAnswerChallenges(ctx context.Context, client *bigip.BigIP, acme *acmeClient, order *acmeOrder, virtual, challengeName string) (err error) {
+	var challenges []acmeChallenge
+	var authorizations []string
+	var records []bigip.DataGroupRecord
+	for _, url := range order.Authorizations {
+		authz, err := acme.authorization(ctx, url)
+		if err != nil {
+			return err
+		}
+		if authz.Status == "valid" {
+			continue
+		}
+		var http01 *acmeChallenge
+		for i := range authz.Challenges {
+			if authz.Challenges[i].Type == "http-01" {
+				http01 = &authz.Challenges[i]
+			}
+		}
+		if http01 == nil {
+			return fmt.Errorf("the ACME server offers no http-01 challenge for %s", authz.Identifier.Value)
+		}
+		challenges = append(challenges, *http01)
+		authorizations = append(authorizations, url)
+		records = append(records, bigip.DataGroupRecord{Name: http01.Token, Data: acme.keyAuthorization(http01.Token)})
+	}
+	if len(challenges) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Serving %d ACME challenges from %s on %s", len(challenges), challengeName, virtual)
+	if err := client.AddInternalDataGroup(&bigip.DataGroup{Name: challengeName, Type: "string", Records: records}); err != nil {
+		return fmt.Errorf("error creating data group %s: %v", challengeName, err)
+	}
+	defer func() {
+		if derr := client.DeleteInternalDataGroup(challengeName); derr != nil && err == nil {
+			err = fmt.Errorf("error deleting data group %s: %v", challengeName, derr)
+		}
+	}()
+	if err := client.CreateIRule(challengeName, fmt.Sprintf(acmeChallengeIRule, challengeName)); err != nil {
+		return fmt.Errorf("error creating iRule %s: %v", challengeName, err)
+	}
+	defer func() {
+		if derr := client.DeleteIRule(challengeName); derr != nil && err == nil {
+			err = fmt.Errorf("error deleting iRule %s: %v", challengeName, derr)
+		}
+	}()
+	rules, err := client.VirtualServerRules(virtual)
+	if err != nil {
+		return fmt.Errorf("error retrieving virtual server %s: %v", virtual, err)
+	}
+	if err := client.SetVirtualServerRules(virtual, append([]string{challengeName}, rules...)); err != nil {
+		return fmt.Errorf("error attaching iRule %s to virtual server %s: %v", challengeName, virtual, err)
+	}
+	defer func() {
+		if derr := acmeDetachIRule(client, virtual, challengeName); derr != nil && err == nil {
+			err = derr
+		}
+	}()
+
+	for _, challenge := range challenges {
+		if err := acme.accept(ctx, challenge); err != nil {
+			return err
+		}
+	}
+	for _, url := range authorizations {
+		if err := acme.waitAuthorization(ctx, url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// acmeDetachIRule removes the challenge iRule from the virtual server, keeping
+// any change made to its other iRules in the meantime.
+func acmeDetachIRule(client *bigip.BigIP, virtual, rule string) error {
+	rules, err := client.VirtualServerRules(virtual)
+	if err != nil {
+		return fmt.Errorf("error retrieving virtual server %s: %v", virtual, err)
+	}
+	var kept []string
+	for _, r := range rules {
+		if r != rule {
+			kept = append(kept, r)
+		}
+	}
+	if err := client.SetVirtualServerRules(virtual, kept); err != nil {
+		return fmt.Errorf("error detaching iRule %s from virtual server %s: %v", rule, virtual, err)
+	}
+	return nil
+}
+
+// acmeCsr creates a CSR for the names signed by the key on the BIG-IP and
+// returns it DER encoded, the CSR is removed from the BIG-IP again.
+func acmeCsr(client *bigip.BigIP, certPath, keyPath string, names []string) ([]byte, error) {
+	csrPath := certPath + "_acme"
+	var sans []string
+	for _, name := range names {
+		sans = append(sans, "DNS:"+name)
+	}
+	err := client.CreateCsr(&bigip.Csr{
+		Name:                   csrPath,
+		Key:                    keyPath,
+		CommonName:             names[0],
+		SubjectAlternativeName: strings.Join(sans, ", "),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating CSR %s: %v", csrPath, err)
+	}
+	defer func() {
+		if err := client.DeleteCsr(csrPath); err != nil {
+			log.Printf("[WARN] Could not delete CSR %s: %v", csrPath, err)
+		}
+	}()
+	csrPEM, err := client.CsrPem(csrPath)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving CSR %s: %v", csrPath, err)
+	}
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil {
+		return nil, fmt.Errorf("error decoding CSR %s", csrPath)
+	}
+	return block.Bytes, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipSslAcmeCertificateConfig = `
+resource "bigip_ssl_acme_certificate" "test" {
+  name           = "tf-acc-acme"
+  directory_url  = "%s"
+  ca_pem         = file("%s")
+  common_name    = "%s"
+  virtual_server = "%s"
+  key_type       = "ec-private"
+}
+`
+
+// TestAccBigipSslAcmeCertificateCreate runs against Pebble or another ACME
+// test server and needs ACME_DIRECTORY_URL, ACME_CA_FILE (the CA of the
+// directory, e.g. pebble.minica.pem), ACME_DOMAIN, a name that resolves to
+// ACME_VIRTUAL_SERVER, an HTTP virtual server on port 80
+func TestAccBigipSslAcmeCertificateCreate(t *testing.T) {
+	directory := os.Getenv("ACME_DIRECTORY_URL")
+	caFile := os.Getenv("ACME_CA_FILE")
+	domain := os.Getenv("ACME_DOMAIN")
+	virtual := os.Getenv("ACME_VIRTUAL_SERVER")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+			if directory == "" || caFile == "" || domain == "" || virtual == "" {
+				t.Skip("ACME_DIRECTORY_URL, ACME_CA_FILE, ACME_DOMAIN and ACME_VIRTUAL_SERVER must be set to run this test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSslAcmeCertificateDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBigipSslAcmeCertificateConfig, directory, caFile, domain, virtual),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ssl_acme_certificate.test", "full_path", "/Common/tf-acc-acme"),
+					resource.TestCheckResourceAttr("bigip_ssl_acme_certificate.test", "key_full_path", "/Common/tf-acc-acme"),
+					resource.TestCheckResourceAttrSet("bigip_ssl_acme_certificate.test", "certificate_pem"),
+					resource.TestCheckResourceAttrSet("bigip_ssl_acme_certificate.test", "expiration"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckSslAcmeCertificateDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ssl_acme_certificate" {
+			continue
+		}
+		if _, err := client.GetCertificate(rs.Primary.ID); err == nil {
+			return fmt.Errorf("ACME certificate %s not destroyed", rs.Primary.ID)
+		}
+		if _, err := client.GetKey(rs.Primary.ID); err == nil {
+			return fmt.Errorf("key %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_ssl_acme_certificate against a fake BIG-IP and a fake
+// ACME server that behaves like Pebble - no F5 BIG-IP connection required
+
+// challengeResponse answers an HTTP-01 request for the token to the virtual
+// server the way the challenge iRule would: from the data group the first
+// iRule of the virtual server looks the token up in.
+func (f *fakeSslBigipServer) challengeResponse(virtual, token string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lookup := regexp.MustCompile(`class lookup \[string range \[HTTP::path\] 28 end\] (\S+)\]`)
+	for _, rule := range f.vsRules[strings.ReplaceAll(virtual, "/", "~")] {
+		if m := lookup.FindStringSubmatch(f.rules[strings.ReplaceAll(rule, "/", "~")]); m != nil {
+			return f.dataGroups[strings.ReplaceAll(m[1], "/", "~")][token]
+		}
+	}
+	return ""
+}
+
+// fakeAcmeServer is an RFC 8555 server in the spirit of Pebble: it checks the
+// JWS of every request, validates HTTP-01 challenges against the fake BIG-IP
+// and issues certificates from its own CA.
+type fakeAcmeServer struct {
+	*httptest.Server
+	mu             sync.Mutex
+	bigip          *fakeSslBigipServer
+	virtual        string
+	invalid        bool
+	caKey          *ecdsa.PrivateKey
+	ca             *x509.Certificate
+	nonces         map[string]bool
+	accounts       map[string]*ecdsa.PublicKey
+	orders         map[string]*fakeAcmeOrder
+	authorizations map[string]*fakeAcmeAuthorization
+	issued         []*x509.Certificate
+	nextID         int
+}
+
+type fakeAcmeOrder struct {
+	names          []string
+	authorizations []string
+	status         string
+	polls          int
+	cert           []byte
+}
+
+type fakeAcmeAuthorization struct {
+	name   string
+	token  string
+	status string
+	thumb  string
+	err    string
+}
+
+func newFakeAcmeServer(t *testing.T, b *fakeSslBigipServer, virtual string) *fakeAcmeServer {
+	f := &fakeAcmeServer{
+		bigip:          b,
+		virtual:        virtual,
+		nonces:         make(map[string]bool),
+		accounts:       make(map[string]*ecdsa.PublicKey),
+		orders:         make(map[string]*fakeAcmeOrder),
+		authorizations: make(map[string]*fakeAcmeAuthorization),
+	}
+	f.caKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Pebble Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(5, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &f.caKey.PublicKey, f.caKey)
+	f.ca, _ = x509.ParseCertificate(der)
+	f.Server = httptest.NewTLSServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeAcmeServer) id() string {
+	f.nextID++
+	return fmt.Sprint(f.nextID)
+}
+
+func (f *fakeAcmeServer) problem(w http.ResponseWriter, status int, kind, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"type": "urn:ietf:params:acme:error:" + kind, "detail": detail, "status": status})
+}
+
+func (f *fakeAcmeServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	nonce := f.id()
+	f.nonces[nonce] = true
+	w.Header().Set("Replay-Nonce", nonce)
+	switch {
+	case r.URL.Path == "/dir":
+		_ = json.NewEncoder(w).Encode(map[string]string{"newNonce": f.URL + "/nonce", "newAccount": f.URL + "/account", "newOrder": f.URL + "/order"})
+		return
+	case r.URL.Path == "/nonce":
+		return
+	}
+
+	// every other request is a JWS signed POST
+	var jws struct{ Protected, Payload, Signature string }
+	_ = json.NewDecoder(r.Body).Decode(&jws)
+	decode := func(s string) []byte { b, _ := base64.RawURLEncoding.DecodeString(s); return b }
+	var header struct {
+		Alg, Nonce, URL, Kid string
+		Jwk                  struct{ Crv, Kty, X, Y string }
+	}
+	if err := json.Unmarshal(decode(jws.Protected), &header); err != nil || header.Alg != "ES256" {
+		f.problem(w, http.StatusBadRequest, "malformed", "bad protected header")
+		return
+	}
+	if !f.nonces[header.Nonce] {
+		f.problem(w, http.StatusBadRequest, "badNonce", "unknown nonce")
+		return
+	}
+	delete(f.nonces, header.Nonce)
+	if header.URL != f.URL+r.URL.Path {
+		f.problem(w, http.StatusUnauthorized, "unauthorized", "url mismatch")
+		return
+	}
+	key := f.accounts[header.Kid]
+	if r.URL.Path == "/account" {
+		key = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(decode(header.Jwk.X)), Y: new(big.Int).SetBytes(decode(header.Jwk.Y))}
+	}
+	signature := decode(jws.Signature)
+	digest := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	if key == nil || len(signature) != 64 || !ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])) {
+		f.problem(w, http.StatusUnauthorized, "unauthorized", "bad signature")
+		return
+	}
+	payload := decode(jws.Payload)
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch parts[0] {
+	case "account":
+		for kid, existing := range f.accounts {
+			if existing.Equal(key) {
+				w.Header().Set("Location", kid)
+				_, _ = fmt.Fprint(w, `{"status":"valid"}`)
+				return
+			}
+		}
+		kid := f.URL + "/account/" + f.id()
+		f.accounts[kid] = key
+		w.Header().Set("Location", kid)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"status":"valid"}`)
+	case "order":
+		if len(parts) == 1 {
+			var request struct{ Identifiers []acmeIdentifier }
+			_ = json.Unmarshal(payload, &request)
+			jwk, _ := json.Marshal(acmeJwk(key))
+			thumb := sha256.Sum256(jwk)
+			order := &fakeAcmeOrder{status: "pending"}
+			for _, identifier := range request.Identifiers {
+				id := f.id()
+				order.names = append(order.names, identifier.Value)
+				order.authorizations = append(order.authorizations, id)
+				f.authorizations[id] = &fakeAcmeAuthorization{name: identifier.Value, token: "token-" + id, status: "pending", thumb: acmeEncode(thumb[:])}
+			}
+			id := f.id()
+			f.orders[id] = order
+			w.Header().Set("Location", f.URL+"/order/"+id)
+			w.WriteHeader(http.StatusCreated)
+			f.writeOrder(w, id)
+			return
+		}
+		order := f.orders[parts[1]]
+		if order.status == "processing" {
+			if order.polls++; order.polls > 1 {
+				order.status = "valid"
+			}
+		}
+		f.writeOrder(w, parts[1])
+	case "authz":
+		f.writeAuthorization(w, parts[1])
+	case "chall":
+		authz := f.authorizations[parts[1]]
+		// validate like Pebble would, by requesting the token from the virtual server
+		f.mu.Unlock()
+		response := f.bigip.challengeResponse(f.virtual, authz.token)
+		f.mu.Lock()
+		if !f.invalid && response == authz.token+"."+authz.thumb {
+			authz.status = "valid"
+		} else {
+			authz.status = "invalid"
+			authz.err = fmt.Sprintf("The key authorization file from the server did not match this challenge, got %q", response)
+		}
+		_, _ = fmt.Fprintf(w, `{"type":"http-01","url":"%s/chall/%s","token":"%s","status":"processing"}`, f.URL, parts[1], authz.token)
+	case "finalize":
+		order := f.orders[parts[1]]
+		for _, id := range order.authorizations {
+			if f.authorizations[id].status != "valid" {
+				f.problem(w, http.StatusForbidden, "orderNotReady", "order is not ready")
+				return
+			}
+		}
+		var request struct{ Csr string }
+		_ = json.Unmarshal(payload, &request)
+		csr, err := x509.ParseCertificateRequest(decode(request.Csr))
+		if err != nil || csr.CheckSignature() != nil {
+			f.problem(w, http.StatusBadRequest, "badCSR", "bad CSR")
+			return
+		}
+		names := append([]string(nil), csr.DNSNames...)
+		sort.Strings(names)
+		ordered := append([]string(nil), order.names...)
+		sort.Strings(ordered)
+		if strings.Join(names, ",") != strings.Join(ordered, ",") {
+			f.problem(w, http.StatusBadRequest, "badCSR", fmt.Sprintf("CSR names %v do not match the order %v", names, ordered))
+			return
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(len(f.issued) + 2)),
+			Subject:      pkix.Name{CommonName: csr.Subject.CommonName},
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().AddDate(0, 0, 90),
+		}
+		der, _ := x509.CreateCertificate(rand.Reader, template, f.ca, csr.PublicKey, f.caKey)
+		cert, _ := x509.ParseCertificate(der)
+		f.issued = append(f.issued, cert)
+		order.cert = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.ca.Raw})...)
+		order.status = "processing"
+		f.writeOrder(w, parts[1])
+	case "cert":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write(f.orders[parts[1]].cert)
+	}
+}
+
+func (f *fakeAcmeServer) writeOrder(w http.ResponseWriter, id string) {
+	order := f.orders[id]
+	if order.status == "pending" {
+		ready := true
+		for _, a := range order.authorizations {
+			ready = ready && f.authorizations[a].status == "valid"
+		}
+		if ready {
+			order.status = "ready"
+		}
+	}
+	out := map[string]interface{}{"status": order.status, "finalize": f.URL + "/finalize/" + id}
+	var authorizations []string
+	for _, a := range order.authorizations {
+		authorizations = append(authorizations, f.URL+"/authz/"+a)
+	}
+	out["authorizations"] = authorizations
+	if order.status == "valid" {
+		out["certificate"] = f.URL + "/cert/" + id
+	}
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func (f *fakeAcmeServer) writeAuthorization(w http.ResponseWriter, id string) {
+	authz := f.authorizations[id]
+	challenge := map[string]interface{}{"type": "http-01", "url": f.URL + "/chall/" + id, "token": authz.token, "status": authz.status}
+	if authz.err != "" {
+		challenge["error"] = map[string]interface{}{"type": "urn:ietf:params:acme:error:unauthorized", "detail": authz.err}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":     authz.status,
+		"identifier": map[string]string{"type": "dns", "value": authz.name},
+		"challenges": []interface{}{
+			map[string]interface{}{"type": "dns-01", "url": f.URL + "/chall/dns-" + id, "token": authz.token, "status": "pending"},
+			challenge,
+		},
+	})
+}
+
+func (f *fakeAcmeServer) caPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.Certificate().Raw}))
+}
+
+func withSslAcmeNow(t *testing.T, now time.Time) {
+	saved := sslAcmeNow
+	sslAcmeNow = func() time.Time { return now }
+	t.Cleanup(func() { sslAcmeNow = saved })
+}
+
+func testSslAcmeConfig(acme *fakeAcmeServer, extra map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"name":                      "www",
+		"directory_url":             acme.URL + "/dir",
+		"ca_pem":                    acme.caPEM(),
+		"email":                     "admin@example.com",
+		"common_name":               "www.example.com",
+		"subject_alternative_names": []interface{}{"example.com", "www.example.com"},
+		"key_type":                  "ec-private",
+		"virtual_server":            "/Common/www_http",
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+	return config
+}
+
+func TestResourceBigipSslAcmeCertificateLifecycle(t *testing.T) {
+	withFastPolling(t, &acmePollInterval)
+	b := newFakeSslBigipServer(t)
+	acme := newFakeAcmeServer(t, b, "/Common/www_http")
+	client := testFakeBigipClient(b.Server)
+
+	r := resourceBigipSslAcmeCertificate()
+	config := testSslAcmeConfig(acme, nil)
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceBigipSslAcmeCertificateCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "/Common/www", d.Id())
+	assert.Equal(t, "/Common/www", d.Get("key_full_path"))
+	assert.Equal(t, "ec-private", b.keyConfigs["~Common~www"]["keyType"])
+	assert.Equal(t, "prime256v1", b.keyConfigs["~Common~www"]["curveName"])
+	assert.NotContains(t, b.keyConfigs["~Common~www"], "keySize")
+
+	assert.Len(t, acme.issued, 1)
+	assert.Equal(t, []string{"www.example.com", "example.com"}, acme.issued[0].DNSNames, "duplicate names are ordered once")
+	assert.Equal(t, string(b.certs["~Common~www"]), d.Get("certificate_pem"), "the whole chain is installed")
+	assert.Contains(t, d.Get("certificate_url"), acme.URL+"/cert/")
+	assert.Contains(t, d.Get("account_url"), acme.URL+"/account/")
+	assert.Contains(t, d.Get("account_key_pem"), "EC PRIVATE KEY")
+	assert.Equal(t, acme.issued[0].NotAfter.UTC().Format(time.RFC3339), d.Get("expiration"))
+
+	// the challenge objects are cleaned up and the virtual server is left as it was
+	assert.Equal(t, []string{"/Common/redirect"}, b.vsRules["~Common~www_http"])
+	assert.Empty(t, b.dataGroups)
+	assert.Empty(t, b.rules)
+	assert.Empty(t, b.csrs)
+
+	// nothing to do inside the renewal window
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// 70 days later the certificate expires within the 30 days window
+	withSslAcmeNow(t, time.Now().AddDate(0, 0, 70))
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	if assert.NotNil(t, diff) {
+		assert.True(t, diff.Attributes["certificate_pem"].NewComputed)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	assert.NoError(t, err)
+	if diags := resourceBigipSslAcmeCertificateUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Len(t, acme.issued, 2)
+	assert.Len(t, acme.accounts, 1, "the account is reused")
+	assert.Equal(t, string(b.certs["~Common~www"]), d.Get("certificate_pem"))
+	block, _ := pem.Decode(b.certs["~Common~www"])
+	assert.Equal(t, acme.issued[1].Raw, block.Bytes, "the renewed certificate is installed")
+	assert.Contains(t, b.requests, "PATCH /mgmt/tm/sys/file/ssl-cert/~Common~www/")
+	assert.Equal(t, []string{"/Common/redirect"}, b.vsRules["~Common~www_http"])
+
+	if diags := resourceBigipSslAcmeCertificateDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, b.certs)
+	assert.Empty(t, b.keys, "the generated key is deleted")
+	d.SetId("/Common/www")
+	if diags := resourceBigipSslAcmeCertificateRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSslAcmeCertificateReusesKey(t *testing.T) {
+	withFastPolling(t, &acmePollInterval)
+	b := newFakeSslBigipServer(t)
+	b.keys["~Common~existing.key"], _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	acme := newFakeAcmeServer(t, b, "/Common/www_http")
+	client := testFakeBigipClient(b.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSslAcmeCertificate().Schema, testSslAcmeConfig(acme, map[string]interface{}{
+		"key_name": "/Common/existing.key",
+	}))
+	if diags := resourceBigipSslAcmeCertificateCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, b.keyConfigs, "no key is generated")
+	assert.Equal(t, &b.keys["~Common~existing.key"].PublicKey, acme.issued[0].PublicKey)
+
+	if diags := resourceBigipSslAcmeCertificateDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Contains(t, b.keys, "~Common~existing.key", "a reused key is kept")
+}
+
+func TestResourceBigipSslAcmeCertificateInvalidChallenge(t *testing.T) {
+	withFastPolling(t, &acmePollInterval)
+	b := newFakeSslBigipServer(t)
+	acme := newFakeAcmeServer(t, b, "/Common/www_http")
+	acme.invalid = true
+	client := testFakeBigipClient(b.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSslAcmeCertificate().Schema, testSslAcmeConfig(acme, nil))
+	diags := resourceBigipSslAcmeCertificateCreate(context.Background(), d, client)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "authorization of www.example.com is invalid")
+		assert.Contains(t, diags[0].Summary, "did not match this challenge")
+	}
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, []string{"/Common/redirect"}, b.vsRules["~Common~www_http"])
+	assert.Empty(t, b.dataGroups)
+	assert.Empty(t, b.rules)
+	assert.Empty(t, b.keys, "the generated key is removed again")
+	assert.Empty(t, b.certs)
+}
+
+func TestAcmeRenewalDue(t *testing.T) {
+	withSslAcmeNow(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, acmeRenewalDue("2026-03-01T00:00:00Z", 30))
+	assert.True(t, acmeRenewalDue("2026-01-31T00:00:00Z", 30))
+	assert.True(t, acmeRenewalDue("2025-12-01T00:00:00Z", 30), "expired")
+	assert.False(t, acmeRenewalDue("", 30), "not issued yet")
+}
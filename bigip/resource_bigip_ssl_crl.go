@@ -0,0 +1,200 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipSslCrl() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSslCrlCreate,
+		ReadContext:   resourceBigipSslCrlRead,
+		UpdateContext: resourceBigipSslCrlUpdate,
+		DeleteContext: resourceBigipSslCrlDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceBigipSslCrlCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the CRL file on the BIG-IP",
+			},
+			"partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Common",
+				ForceNew:     true,
+				ValidateFunc: validatePartitionName,
+				Description:  "Partition of the CRL file",
+			},
+			"content": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"content", "source"},
+				Description:  "PEM encoded CRL",
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"content", "source"},
+				Description:  "Path of a local PEM or DER encoded CRL file, read at plan time",
+			},
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the CRL file, for the crl_file of SSL profiles",
+			},
+			"checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-1 hex digest of the CRL on the BIG-IP, the CRL is uploaded again when the digest of the local CRL differs",
+			},
+			"next_update": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time by which the issuer publishes the next CRL, in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func resourceBigipSslCrlCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := fmt.Sprintf("/%s/%s", d.Get("partition").(string), d.Get("name").(string))
+	data, _, err := sslCrlData(d.Get("content").(string), d.Get("source").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error uploading CRL %s: %v", name, err))
+	}
+	log.Printf("[INFO] Uploading CRL %s (%d bytes)", name, len(data))
+	crl := &bigip.SslCrl{Name: d.Get("name").(string), Partition: d.Get("partition").(string)}
+	if err := client.UploadSslCrl(data, crl); err != nil {
+		return diag.FromErr(fmt.Errorf("error uploading CRL %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSslCrlRead(ctx, d, meta)
+}
+
+func resourceBigipSslCrlRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading CRL %s", name)
+	crl, err := client.GetSslCrl(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] CRL %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving CRL %s: %v", name, err))
+	}
+	if parts := strings.Split(strings.TrimPrefix(name, "/"), "/"); len(parts) == 2 {
+		_ = d.Set("partition", parts[0])
+		_ = d.Set("name", parts[1])
+	}
+	_ = d.Set("full_path", name)
+	// SHA1:<size>:<hex digest>
+	_ = d.Set("checksum", crl.Checksum[strings.LastIndex(crl.Checksum, ":")+1:])
+	if _, list, err := sslCrlData(d.Get("content").(string), d.Get("source").(string)); err == nil {
+		_ = d.Set("next_update", list.NextUpdate.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func resourceBigipSslCrlUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	if d.HasChange("checksum") {
+		data, _, err := sslCrlData(d.Get("content").(string), d.Get("source").(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating CRL %s: %v", name, err))
+		}
+		log.Printf("[INFO] Updating CRL %s (%d bytes)", name, len(data))
+		crl := &bigip.SslCrl{Name: d.Get("name").(string), Partition: d.Get("partition").(string)}
+		if err := client.UpdateSslCrl(data, crl); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating CRL %s: %v", name, err))
+		}
+	}
+	return resourceBigipSslCrlRead(ctx, d, meta)
+}
+
+func resourceBigipSslCrlDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting CRL %s", name)
+	if err := client.DeleteSslCrl(name); err != nil && !strings.Contains(err.Error(), "not found") {
+		return diag.FromErr(fmt.Errorf("error deleting CRL %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipSslCrlCustomizeDiff plans the checksum and next update of the
+// local CRL, so a CRL changed locally or on the BIG-IP is uploaded again.
+func resourceBigipSslCrlCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("content") || !d.NewValueKnown("source") {
+		_ = d.SetNewComputed("checksum")
+		return d.SetNewComputed("next_update")
+	}
+	data, list, err := sslCrlData(d.Get("content").(string), d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+	sum := sha1.Sum(data)
+	if checksum := hex.EncodeToString(sum[:]); d.Get("checksum").(string) != checksum {
+		if err := d.SetNew("checksum", checksum); err != nil {
+			return err
+		}
+	}
+	if nextUpdate := list.NextUpdate.UTC().Format(time.RFC3339); d.Get("next_update").(string) != nextUpdate {
+		return d.SetNew("next_update", nextUpdate)
+	}
+	return nil
+}
+
+// sslCrlData returns the CRL of content or of the source file PEM encoded,
+// the way it is uploaded to the BIG-IP.
+func sslCrlData(content, source string) ([]byte, *x509.RevocationList, error) {
+	data := []byte(content)
+	if source != "" {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, nil, fmt.Errorf("error reading CRL file: %v", err)
+		}
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "X509 CRL" {
+			return nil, nil, fmt.Errorf("expected an X509 CRL PEM block, got %s", block.Type)
+		}
+		list, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing CRL: %v", err)
+		}
+		return data, list, nil
+	}
+	list, err := x509.ParseRevocationList(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing CRL: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: data}), list, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipSslCrlConfig = `
+resource "bigip_ssl_crl" "test" {
+  name   = "tf-acc-ca.crl"
+  source = "%s"
+}
+
+resource "bigip_ltm_profile_client_ssl" "test" {
+  name           = "/Common/tf-acc-crl-clientssl"
+  defaults_from  = "/Common/clientssl"
+  peer_cert_mode = "require"
+  ca_file        = "/Common/ca-bundle.crt"
+  crl_file       = bigip_ssl_crl.test.full_path
+}
+
+resource "bigip_ltm_profile_server_ssl" "test" {
+  name          = "/Common/tf-acc-crl-serverssl"
+  defaults_from = "/Common/serverssl"
+  ca_file       = "/Common/ca-bundle.crt"
+  crl_file      = bigip_ssl_crl.test.full_path
+}
+`
+
+func TestAccBigipSslCrlCreate(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "tf-acc-ca.crl")
+	writeCrl := func(number int64) {
+		if err := os.WriteFile(source, testCrl(t, number, 2, time.Now().AddDate(0, 0, 7)), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSslCrlDestroyed,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { writeCrl(1) },
+				Config:    fmt.Sprintf(testAccBigipSslCrlConfig, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ssl_crl.test", "full_path", "/Common/tf-acc-ca.crl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test", "crl_file", "/Common/tf-acc-ca.crl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test", "crl_file", "/Common/tf-acc-ca.crl"),
+				),
+			},
+			{
+				// a new CRL from the CA is uploaded in place
+				PreConfig: func() { writeCrl(2) },
+				Config:    fmt.Sprintf(testAccBigipSslCrlConfig, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ssl_crl.test", "full_path", "/Common/tf-acc-ca.crl"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckSslCrlDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ssl_crl" {
+			continue
+		}
+		if _, err := client.GetSslCrl(rs.Primary.ID); err == nil {
+			return fmt.Errorf("CRL %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
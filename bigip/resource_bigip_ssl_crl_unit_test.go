@@ -0,0 +1,210 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_ssl_crl against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeSslCrlServer adds the CRLs, by tilde path, to the shared SSL fake BIG-IP.
+type fakeSslCrlServer struct {
+	*fakeSslBigipServer
+	crls map[string][]byte
+}
+
+func newFakeSslCrlServer(t *testing.T) *fakeSslCrlServer {
+	f := &fakeSslCrlServer{fakeSslBigipServer: newFakeSslBigipServer(t), crls: make(map[string][]byte)}
+	crl := func(w http.ResponseWriter, name string) {
+		sum := sha1.Sum(f.crls[name])
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": name, "checksum": fmt.Sprintf("SHA1:%d:%x", len(f.crls[name]), sum)})
+	}
+	f.handle("/mgmt/tm/sys/file/ssl-crl", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		name := fmt.Sprintf("~%s~%s", body["partition"], body["name"])
+		f.crls[name] = f.uploads[strings.TrimPrefix(body["sourcePath"].(string), "file:///var/config/rest/downloads/")]
+		crl(w, name)
+	})
+	f.handle("/mgmt/tm/sys/file/ssl-crl/", func(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/file/ssl-crl/")
+		if _, ok := f.crls[name]; !ok {
+			fakeBigipNotFound(w, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			f.crls[name] = f.uploads[strings.TrimPrefix(body["sourcePath"].(string), "file:///var/config/rest/downloads/")]
+		case "DELETE":
+			delete(f.crls, name)
+		}
+		crl(w, name)
+	})
+	return f
+}
+
+// testCrl returns a DER encoded CRL of a throwaway CA revoking the given
+// number of serial numbers.
+func testCrl(t *testing.T, number int64, revoked int, nextUpdate time.Time) []byte {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example CA"},
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCRLSign | x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	template := &x509.RevocationList{
+		Number:     big.NewInt(number),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: nextUpdate,
+	}
+	for i := 0; i < revoked; i++ {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(int64(1000 + i)),
+			RevocationTime: time.Now().Add(-time.Hour),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func testCrlPEM(t *testing.T, number int64, revoked int, nextUpdate time.Time) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: testCrl(t, number, revoked, nextUpdate)}))
+}
+
+func TestResourceBigipSslCrlLifecycle(t *testing.T) {
+	b := newFakeSslCrlServer(t)
+	client := testFakeBigipClient(b.Server)
+	r := resourceBigipSslCrl()
+
+	// large enough for the upload to take several chunks
+	nextUpdate := time.Now().Add(7 * 24 * time.Hour).UTC().Truncate(time.Second)
+	content := testCrlPEM(t, 1, 30000, nextUpdate)
+	assert.Greater(t, len(content), 512*1024)
+	config := map[string]interface{}{"name": "example-ca.crl", "content": content}
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	assert.NoError(t, err)
+	assert.Len(t, d.Get("checksum"), 40, "the checksum is known at plan time")
+	if diags := resourceBigipSslCrlCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "/Common/example-ca.crl", d.Id())
+	assert.Equal(t, "/Common/example-ca.crl", d.Get("full_path"))
+	assert.Equal(t, content, string(b.crls["~Common~example-ca.crl"]), "the chunks are put back together")
+	assert.Equal(t, nextUpdate.Format(time.RFC3339), d.Get("next_update"))
+
+	// nothing to do for the same CRL
+	state := d.State()
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "unexpected diff %v", diff)
+
+	// the CA published a new CRL
+	config["content"] = testCrlPEM(t, 2, 10, nextUpdate.Add(24*time.Hour))
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	assert.NoError(t, err)
+	b.requests = nil
+	if diags := resourceBigipSslCrlUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Contains(t, b.requests, "PATCH /mgmt/tm/sys/file/ssl-crl/~Common~example-ca.crl")
+	assert.Equal(t, config["content"], string(b.crls["~Common~example-ca.crl"]))
+	assert.Equal(t, nextUpdate.Add(24*time.Hour).Format(time.RFC3339), d.Get("next_update"))
+
+	// a CRL replaced on the BIG-IP is uploaded again
+	b.mu.Lock()
+	b.crls["~Common~example-ca.crl"] = []byte(content)
+	b.mu.Unlock()
+	if diags := resourceBigipSslCrlRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	diff, err = r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	if assert.NotNil(t, diff) {
+		assert.Contains(t, diff.Attributes, "checksum")
+	}
+
+	if diags := resourceBigipSslCrlDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, b.crls)
+}
+
+func TestResourceBigipSslCrlSource(t *testing.T) {
+	b := newFakeSslCrlServer(t)
+	client := testFakeBigipClient(b.Server)
+	r := resourceBigipSslCrl()
+
+	der := testCrl(t, 1, 3, time.Now().Add(24*time.Hour))
+	source := filepath.Join(t.TempDir(), "example-ca.crl")
+	if err := os.WriteFile(source, der, 0600); err != nil {
+		t.Fatal(err)
+	}
+	config := map[string]interface{}{"name": "example-ca.crl", "partition": "app", "source": source}
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	assert.NoError(t, err)
+	if diags := resourceBigipSslCrlCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	block, _ := pem.Decode(b.crls["~app~example-ca.crl"])
+	if assert.NotNil(t, block, "a DER CRL is uploaded PEM encoded") {
+		assert.Equal(t, der, block.Bytes)
+	}
+
+	// the file is read again on every plan
+	if err := os.WriteFile(source, testCrl(t, 2, 4, time.Now().Add(48*time.Hour)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	diff, err = r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err)
+	if assert.NotNil(t, diff) {
+		assert.Contains(t, diff.Attributes, "checksum")
+	}
+}
+
+func TestResourceBigipSslCrlInvalid(t *testing.T) {
+	r := resourceBigipSslCrl()
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":    "example-ca.crl",
+		"content": testCsrPEM(t),
+	}), nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected an X509 CRL PEM block, got CERTIFICATE REQUEST")
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sslCsrNameRegex matches the name of a key and CSR, e.g. www.example.com
+var sslCsrNameRegex = regexp.MustCompile(`^[\w.:-]+$`)
+
+func resourceBigipSslCsr() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSslCsrCreate,
+		ReadContext:   resourceBigipSslCsrRead,
+		DeleteContext: resourceBigipSslCsrDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the key and the CSR on the BIG-IP",
+				ValidateFunc: validation.StringMatch(sslCsrNameRegex, "must contain letters, numbers or [._-:], e.g. www.example.com"),
+			},
+			"partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Common",
+				ForceNew:     true,
+				ValidateFunc: validatePartitionName,
+				Description:  "Partition of the key and the CSR",
+			},
+			"key_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "rsa-private",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"rsa-private", "ec-private"}, false),
+				Description:  "Type of the key, rsa-private or ec-private",
+			},
+			"key_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2048,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+				Description:  "Size of an RSA key in bits",
+			},
+			"curve_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "prime256v1",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"prime256v1", "secp384r1"}, false),
+				Description:  "Curve of an EC key",
+			},
+			"security_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "normal",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"normal", "fips", "nethsm"}, false),
+				Description:  "Where the key is kept, normal, fips for the FIPS card or nethsm for a network HSM",
+			},
+			"common_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Common name of the subject",
+			},
+			"subject_alternative_names": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(DNS|IP|email|URI):\S+$`), "must be of the form DNS:<name>, IP:<address>, email:<address> or URI:<uri>"),
+				},
+				Description: "Subject alternative names, e.g. DNS:www.example.com or IP:192.0.2.10",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Organization of the subject",
+			},
+			"organizational_unit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Organizational unit of the subject",
+			},
+			"city": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "City of the subject",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "State or province of the subject",
+			},
+			"country": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(2, 2),
+				Description:  "Two letter country code of the subject",
+			},
+			"email_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Email address of the subject",
+			},
+			"key_full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the key, for bigip_ssl_csr_certificate and SSL profiles",
+			},
+			"csr_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded certificate signing request",
+			},
+		},
+	}
+}
+
+func resourceBigipSslCsrCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := fmt.Sprintf("/%s/%s", d.Get("partition").(string), d.Get("name").(string))
+	log.Printf("[INFO] Generating key %s", name)
+	if err := client.CreateCryptoKey(sslCryptoKey(d, name)); err != nil {
+		return diag.FromErr(fmt.Errorf("error generating key %s: %v", name, err))
+	}
+
+	log.Printf("[INFO] Creating CSR %s", name)
+	csr := &bigip.Csr{
+		Name:                   name,
+		Key:                    name,
+		CommonName:             d.Get("common_name").(string),
+		SubjectAlternativeName: strings.Join(listToStringSlice(d.Get("subject_alternative_names").([]interface{})), ", "),
+		Organization:           d.Get("organization").(string),
+		Ou:                     d.Get("organizational_unit").(string),
+		City:                   d.Get("city").(string),
+		State:                  d.Get("state").(string),
+		Country:                d.Get("country").(string),
+		EmailAddress:           d.Get("email_address").(string),
+	}
+	err := client.CreateCsr(csr)
+	var csrPEM string
+	if err == nil {
+		csrPEM, err = client.CsrPem(name)
+	}
+	if err != nil {
+		// the key is of no use without its CSR
+		if kerr := client.DeleteKey(name); kerr != nil {
+			log.Printf("[WARN] Could not delete key %s: %v", name, kerr)
+		}
+		return diag.FromErr(fmt.Errorf("error creating CSR %s: %v", name, err))
+	}
+	_ = d.Set("csr_pem", csrPEM)
+	d.SetId(name)
+	return resourceBigipSslCsrRead(ctx, d, meta)
+}
+
+func resourceBigipSslCsrRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading CSR %s", name)
+	// without its key the CSR cannot be signed into a usable certificate
+	_, err := client.GetKey(name)
+	if err == nil {
+		_, err = client.GetCsr(name)
+	}
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] Key or CSR %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving CSR %s: %v", name, err))
+	}
+	_ = d.Set("key_full_path", name)
+	return nil
+}
+
+func resourceBigipSslCsrDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting CSR and key %s", name)
+	if err := client.DeleteCsr(name); err != nil && !strings.Contains(err.Error(), "not found") {
+		return diag.FromErr(fmt.Errorf("error deleting CSR %s: %v", name, err))
+	}
+	if err := client.DeleteKey(name); err != nil && !strings.Contains(err.Error(), "not found") {
+		return diag.FromErr(fmt.Errorf("error deleting key %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// sslCryptoKey returns the key to generate on the BIG-IP from the key_type,
+// key_size, curve_name and security_type of the resource.
+func sslCryptoKey(d *schema.ResourceData, name string) *bigip.CryptoKey {
+	key := &bigip.CryptoKey{
+		Name:         name,
+		KeyType:      d.Get("key_type").(string),
+		SecurityType: d.Get("security_type").(string),
+	}
+	if key.KeyType == "ec-private" {
+		key.CurveName = d.Get("curve_name").(string)
+	} else {
+		key.KeySize = d.Get("key_size").(int)
+	}
+	return key
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipSslCsrCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSslCsrCertificateCreate,
+		ReadContext:   resourceBigipSslCsrCertificateRead,
+		UpdateContext: resourceBigipSslCsrCertificateUpdate,
+		DeleteContext: resourceBigipSslCsrCertificateDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the certificate on the BIG-IP",
+			},
+			"partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Common",
+				ForceNew:     true,
+				ValidateFunc: validatePartitionName,
+				Description:  "Partition of the certificate",
+			},
+			"key_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Full path of the key on the BIG-IP the certificate was issued for, e.g. the key_full_path of a bigip_ssl_csr",
+			},
+			"certificate_pem": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "PEM encoded signed certificate, optionally followed by its chain",
+			},
+			"csr_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded CSR the certificate was signed from, the certificate must carry its public key",
+			},
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the certificate",
+			},
+			"expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry of the certificate in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func resourceBigipSslCsrCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := fmt.Sprintf("/%s/%s", d.Get("partition").(string), d.Get("name").(string))
+	if err := sslCertificateMatchesCsr(d.Get("certificate_pem").(string), d.Get("csr_pem").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error installing certificate %s: %v", name, err))
+	}
+	log.Printf("[INFO] Installing certificate %s for key %s", name, d.Get("key_name").(string))
+	cert := &bigip.Certificate{Name: d.Get("name").(string), Partition: d.Get("partition").(string)}
+	if err := client.UploadCertificate(d.Get("certificate_pem").(string), cert); err != nil {
+		return diag.FromErr(fmt.Errorf("error installing certificate %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSslCsrCertificateRead(ctx, d, meta)
+}
+
+func resourceBigipSslCsrCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading certificate %s", name)
+	cert, err := client.GetCertificate(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] Certificate %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving certificate %s: %v", name, err))
+	}
+	_ = d.Set("full_path", name)
+	_ = d.Set("expiration", time.Unix(cert.ExpirationDate, 0).UTC().Format(time.RFC3339))
+	return nil
+}
+
+func resourceBigipSslCsrCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	if err := sslCertificateMatchesCsr(d.Get("certificate_pem").(string), d.Get("csr_pem").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating certificate %s: %v", name, err))
+	}
+	if d.HasChange("certificate_pem") {
+		// renewing keeps the name, so the SSL profiles using it pick up the new certificate
+		log.Printf("[INFO] Updating certificate %s", name)
+		cert := &bigip.Certificate{Name: d.Get("name").(string), Partition: d.Get("partition").(string)}
+		if err := client.UpdateCertificate(d.Get("certificate_pem").(string), cert); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating certificate %s: %v", name, err))
+		}
+	}
+	return resourceBigipSslCsrCertificateRead(ctx, d, meta)
+}
+
+func resourceBigipSslCsrCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting certificate %s", name)
+	if err := client.DeleteCertificate(name); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting certificate %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// sslCertificateMatchesCsr checks the first certificate of certPEM was issued
+// for the public key of csrPEM, there is nothing to check without a CSR.
+func sslCertificateMatchesCsr(certPEM, csrPEM string) error {
+	if csrPEM == "" {
+		return nil
+	}
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil {
+		return fmt.Errorf("no PEM block found in csr_pem")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("error parsing csr_pem: %v", err)
+	}
+	if block, _ = pem.Decode([]byte(certPEM)); block == nil {
+		return fmt.Errorf("no PEM block found in certificate_pem")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("error parsing certificate_pem: %v", err)
+	}
+	key, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !key.Equal(csr.PublicKey) {
+		return fmt.Errorf("the certificate %q was not issued for the key of the CSR", cert.Subject.CommonName)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipSslCsrConfig = `
+resource "bigip_ssl_csr" "test" {
+  name                      = "tf-acc-csr.example.com"
+  key_type                  = "ec-private"
+  common_name               = "tf-acc-csr.example.com"
+  subject_alternative_names = ["DNS:tf-acc-csr.example.com"]
+  organization              = "Example Inc"
+  country                   = "US"
+}
+`
+
+func TestAccBigipSslCsrCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSslCsrDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipSslCsrConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ssl_csr.test", "key_full_path", "/Common/tf-acc-csr.example.com"),
+					resource.TestMatchResourceAttr("bigip_ssl_csr.test", "csr_pem", regexp.MustCompile("^-----BEGIN CERTIFICATE REQUEST-----")),
+				),
+			},
+		},
+	})
+}
+
+func testCheckSslCsrDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ssl_csr" {
+			continue
+		}
+		if _, err := client.GetKey(rs.Primary.ID); err == nil {
+			return fmt.Errorf("key %s not destroyed", rs.Primary.ID)
+		}
+		if _, err := client.GetCsr(rs.Primary.ID); err == nil {
+			return fmt.Errorf("CSR %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
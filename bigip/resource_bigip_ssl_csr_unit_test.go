@@ -0,0 +1,234 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_ssl_csr and bigip_ssl_csr_certificate against the fake
+// BIG-IP of the bigip_ssl_acme_certificate unit tests - no F5 BIG-IP connection required
+
+// testSignCsr signs the CSR with a throwaway CA, as an external CA would.
+func testSignCsr(t *testing.T, csrPEM string, days int) string {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil {
+		t.Fatalf("no CSR in %q", csrPEM)
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(0, 0, days),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, csr.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestResourceBigipSslCsrLifecycle(t *testing.T) {
+	b := newFakeSslBigipServer(t)
+	client := testFakeBigipClient(b.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSslCsr().Schema, map[string]interface{}{
+		"name":                      "www.example.com",
+		"key_type":                  "ec-private",
+		"security_type":             "fips",
+		"common_name":               "www.example.com",
+		"subject_alternative_names": []interface{}{"DNS:www.example.com", "DNS:example.com"},
+		"organization":              "Example Inc",
+		"country":                   "US",
+	})
+	if diags := resourceBigipSslCsrCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "/Common/www.example.com", d.Id())
+	assert.Equal(t, "/Common/www.example.com", d.Get("key_full_path"))
+	key := b.keyConfigs["~Common~www.example.com"]
+	assert.Equal(t, "fips", key["securityType"])
+	assert.Equal(t, "prime256v1", key["curveName"])
+
+	block, _ := pem.Decode([]byte(d.Get("csr_pem").(string)))
+	if assert.NotNil(t, block) {
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		assert.NoError(t, err)
+		assert.Equal(t, "www.example.com", csr.Subject.CommonName)
+		assert.Equal(t, []string{"Example Inc"}, csr.Subject.Organization)
+		assert.Equal(t, []string{"www.example.com", "example.com"}, csr.DNSNames)
+		assert.Equal(t, &b.keys["~Common~www.example.com"].PublicKey, csr.PublicKey, "signed by the key on the BIG-IP")
+	}
+	for k, v := range d.State().Attributes {
+		assert.NotContains(t, v, "PRIVATE KEY", "%s must not hold a private key", k)
+	}
+
+	// a key deleted on the device takes the CSR with it
+	b.mu.Lock()
+	delete(b.keys, "~Common~www.example.com")
+	b.mu.Unlock()
+	if diags := resourceBigipSslCsrRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSslCsrDelete(t *testing.T) {
+	b := newFakeSslBigipServer(t)
+	client := testFakeBigipClient(b.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSslCsr().Schema, map[string]interface{}{
+		"name":        "api",
+		"partition":   "app",
+		"common_name": "api.example.com",
+	})
+	if diags := resourceBigipSslCsrCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, float64(2048), b.keyConfigs["~app~api"]["keySize"])
+	assert.Equal(t, "normal", b.keyConfigs["~app~api"]["securityType"])
+	assert.NotContains(t, b.keyConfigs["~app~api"], "curveName")
+
+	if diags := resourceBigipSslCsrDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, b.csrs)
+	assert.Empty(t, b.keys)
+
+	// the CSR and key are already gone after an out of band cleanup
+	if diags := resourceBigipSslCsrDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestResourceBigipSslCsrName(t *testing.T) {
+	validate := resourceBigipSslCsr().Schema["name"].ValidateFunc
+	for _, name := range []string{"www'; reboot; echo '", "$(reboot)", "www example", "/Common/www"} {
+		_, errs := validate(name, "name")
+		assert.NotEmpty(t, errs, name)
+	}
+	_, errs := validate("www.example.com_2024", "name")
+	assert.Empty(t, errs)
+
+	// go-bigip passes the name as a single word of the tmsh command
+	b := newFakeSslBigipServer(t)
+	client := testFakeBigipClient(b.Server)
+	b.csrs["~Common~www'; reboot; echo '"] = "-----BEGIN CERTIFICATE REQUEST-----\nMIIB\n-----END CERTIFICATE REQUEST-----\n"
+	csrPEM, err := client.CsrPem("/Common/www'; reboot; echo '")
+	if assert.NoError(t, err) {
+		assert.Contains(t, csrPEM, "MIIB")
+	}
+}
+
+func TestResourceBigipSslCsrCertificate(t *testing.T) {
+	b := newFakeSslBigipServer(t)
+	client := testFakeBigipClient(b.Server)
+
+	csr := schema.TestResourceDataRaw(t, resourceBigipSslCsr().Schema, map[string]interface{}{
+		"name":        "www.example.com",
+		"common_name": "www.example.com",
+	})
+	if diags := resourceBigipSslCsrCreate(context.Background(), csr, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	csrPEM := csr.Get("csr_pem").(string)
+
+	r := resourceBigipSslCsrCertificate()
+	signed := testSignCsr(t, csrPEM, 90)
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":            "www.example.com",
+		"key_name":        csr.Get("key_full_path"),
+		"certificate_pem": signed,
+		"csr_pem":         csrPEM,
+	})
+	if diags := resourceBigipSslCsrCertificateCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "/Common/www.example.com", d.Id())
+	assert.Equal(t, signed, string(b.certs["~Common~www.example.com"]))
+	assert.NotEmpty(t, d.Get("expiration"))
+
+	// a renewed certificate replaces the installed one in place
+	renewed := testSignCsr(t, csrPEM, 180)
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":            "www.example.com",
+		"key_name":        csr.Get("key_full_path"),
+		"certificate_pem": renewed,
+		"csr_pem":         csrPEM,
+	}), client)
+	assert.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	assert.NoError(t, err)
+	if diags := resourceBigipSslCsrCertificateUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, renewed, string(b.certs["~Common~www.example.com"]))
+	assert.Contains(t, b.requests, "PATCH /mgmt/tm/sys/file/ssl-cert/~Common~www.example.com/")
+
+	if diags := resourceBigipSslCsrCertificateDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, b.certs)
+	assert.Contains(t, b.keys, "~Common~www.example.com", "the key belongs to bigip_ssl_csr")
+}
+
+func TestResourceBigipSslCsrCertificateWrongKey(t *testing.T) {
+	b := newFakeSslBigipServer(t)
+	client := testFakeBigipClient(b.Server)
+
+	other := testSignCsr(t, testCsrPEM(t), 90)
+	csrPEM := testCsrPEM(t)
+	d := schema.TestResourceDataRaw(t, resourceBigipSslCsrCertificate().Schema, map[string]interface{}{
+		"name":            "www.example.com",
+		"key_name":        "/Common/www.example.com",
+		"certificate_pem": other,
+		"csr_pem":         csrPEM,
+	})
+	diags := resourceBigipSslCsrCertificateCreate(context.Background(), d, client)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "was not issued for the key of the CSR")
+	}
+	assert.Empty(t, b.certs, "nothing is installed")
+}
+
+// testCsrPEM returns a CSR for a fresh key.
+func testCsrPEM(t *testing.T) string {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "www.example.com"}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
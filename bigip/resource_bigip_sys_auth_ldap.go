@@ -0,0 +1,276 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sysAuthName is the name of the remote authentication configuration the
+// BIG-IP uses to authenticate its users.
+const sysAuthName = "system-auth"
+
+// sysAuthLdapProperties maps the attributes of bigip_sys_auth_ldap to the properties of auth ldap.
+var sysAuthLdapProperties = map[string]string{
+	"servers":                "servers",
+	"port":                   "port",
+	"version":                "version",
+	"bind_dn":                "bindDn",
+	"bind_password":          "bindPw",
+	"bind_timeout":           "bindTimeout",
+	"idle_timeout":           "idleTimeout",
+	"search_base_dn":         "searchBaseDn",
+	"search_scope":           "scope",
+	"search_timeout":         "searchTimeout",
+	"filter":                 "filter",
+	"login_attribute":        "loginAttribute",
+	"user_template":          "userTemplate",
+	"check_bind_password":    "checkBindPassword",
+	"check_roles_group":      "checkRolesGroup",
+	"group_dn":               "groupDn",
+	"group_member_attribute": "groupMemberAttribute",
+	"ssl":                    "ssl",
+	"ssl_ca_cert_file":       "sslCaCertFile",
+	"ssl_check_peer":         "sslCheckPeer",
+	"ssl_ciphers":            "sslCiphers",
+	"ssl_client_cert":        "sslClientCert",
+	"ssl_client_key":         "sslClientKey",
+}
+
+func resourceBigipSysAuthLdap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysAuthLdapCreate,
+		ReadContext:   resourceBigipSysAuthLdapRead,
+		UpdateContext: resourceBigipSysAuthLdapUpdate,
+		DeleteContext: resourceBigipSysAuthLdapDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"servers": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Addresses or host names of the LDAP servers",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port of the LDAP servers",
+			},
+			"version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntInSlice([]int{2, 3}),
+				Description:  "LDAP protocol version, 2 or 3",
+			},
+			"bind_dn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Distinguished name of the account used to search the directory",
+			},
+			"bind_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of the account used to search the directory",
+			},
+			"bind_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds to wait for a bind to complete",
+			},
+			"idle_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds before an idle connection to the LDAP servers is closed",
+			},
+			"search_base_dn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Distinguished name the search for users starts from, e.g. ou=people,dc=example,dc=com",
+			},
+			"search_scope": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"sub", "one", "base"}, false),
+				Description:  "Depth of the search for users, sub, one or base",
+			},
+			"search_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds to wait for a search to complete",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP filter users must match, e.g. (objectClass=person)",
+			},
+			"login_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Attribute holding the login name, e.g. uid or samaccountname",
+			},
+			"user_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Distinguished name of a user, with %s for the login name, used to bind as the user instead of searching",
+			},
+			"check_bind_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Check the password of the user by binding as the user, enabled or disabled",
+			},
+			"check_roles_group": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Check that the user is a member of group_dn, enabled or disabled",
+			},
+			"group_dn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Distinguished name of the group users must be a member of",
+			},
+			"group_member_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Attribute of the group listing its members, e.g. member",
+			},
+			"ssl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled", "start-tls"}, false),
+				Description:  "Encrypt the connection to the LDAP servers, enabled (LDAPS), start-tls or disabled",
+			},
+			"ssl_ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "CA certificate used to verify the LDAP servers, e.g. /Common/ldap-ca.crt",
+			},
+			"ssl_check_peer": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Verify the certificate of the LDAP servers, enabled or disabled",
+			},
+			"ssl_ciphers": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "OpenSSL cipher string of the ciphers offered to the LDAP servers",
+			},
+			"ssl_client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Client certificate presented to the LDAP servers",
+			},
+			"ssl_client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key of the client certificate presented to the LDAP servers",
+			},
+		},
+	}
+}
+
+func resourceBigipSysAuthLdapCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Creating LDAP authentication %s", sysAuthName)
+	config := sysSettingsPatch(d, sysAuthLdapProperties, true)
+	config["name"] = sysAuthName
+	if err := client.CreateAuthLdap(config); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating LDAP authentication %s: %v", sysAuthName, err))
+	}
+	d.SetId(sysAuthName)
+	return resourceBigipSysAuthLdapRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthLdapRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading LDAP authentication %s", sysAuthName)
+	ldap, err := client.GetAuthLdap(sysAuthName)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] LDAP authentication %s not found, removing from state", sysAuthName)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving LDAP authentication %s: %v", sysAuthName, err))
+	}
+
+	// the BIG-IP only uses system-auth, whatever id was imported, normalise it
+	d.SetId(sysAuthName)
+
+	// bind_password is write only and kept as configured
+	_ = d.Set("servers", ldap.Servers)
+	_ = d.Set("port", ldap.Port)
+	_ = d.Set("version", ldap.Version)
+	_ = d.Set("bind_dn", ldap.BindDn)
+	_ = d.Set("bind_timeout", ldap.BindTimeout)
+	_ = d.Set("idle_timeout", ldap.IdleTimeout)
+	_ = d.Set("search_base_dn", ldap.SearchBaseDn)
+	_ = d.Set("search_scope", ldap.Scope)
+	_ = d.Set("search_timeout", ldap.SearchTimeout)
+	_ = d.Set("filter", ldap.Filter)
+	_ = d.Set("login_attribute", ldap.LoginAttribute)
+	_ = d.Set("user_template", ldap.UserTemplate)
+	_ = d.Set("check_bind_password", ldap.CheckBindPassword)
+	_ = d.Set("check_roles_group", ldap.CheckRolesGroup)
+	_ = d.Set("group_dn", ldap.GroupDn)
+	_ = d.Set("group_member_attribute", ldap.GroupMemberAttribute)
+	_ = d.Set("ssl", ldap.Ssl)
+	_ = d.Set("ssl_ca_cert_file", ldap.SslCaCertFile)
+	_ = d.Set("ssl_check_peer", ldap.SslCheckPeer)
+	_ = d.Set("ssl_ciphers", ldap.SslCiphers)
+	_ = d.Set("ssl_client_cert", ldap.SslClientCert)
+	_ = d.Set("ssl_client_key", ldap.SslClientKey)
+	return nil
+}
+
+func resourceBigipSysAuthLdapUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating LDAP authentication %s", sysAuthName)
+	if config := sysSettingsPatch(d, sysAuthLdapProperties, false); len(config) > 0 {
+		if err := client.ModifyAuthLdap(sysAuthName, config); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating LDAP authentication %s: %v", sysAuthName, err))
+		}
+	}
+	return resourceBigipSysAuthLdapRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthLdapDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Deleting LDAP authentication %s", sysAuthName)
+	if err := client.DeleteAuthLdap(sysAuthName); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting LDAP authentication %s: %v", sysAuthName, err))
+	}
+	d.SetId("")
+	return nil
+}
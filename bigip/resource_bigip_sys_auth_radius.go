@@ -0,0 +1,247 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sysAuthRadiusProperties maps the attributes of bigip_sys_auth_radius to the properties of auth radius.
+var sysAuthRadiusProperties = map[string]string{
+	"retries":        "retries",
+	"service_type":   "serviceType",
+	"client_id":      "clientId",
+	"accounting_bug": "accountingBug",
+	"debug":          "debug",
+}
+
+func resourceBigipSysAuthRadius() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysAuthRadiusCreate,
+		ReadContext:   resourceBigipSysAuthRadiusRead,
+		UpdateContext: resourceBigipSysAuthRadiusUpdate,
+		DeleteContext: resourceBigipSysAuthRadiusDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    2,
+				Description: "Primary and optional secondary RADIUS server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+							Description:  "Address of the RADIUS server",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1812,
+							ValidateFunc: validation.IsPortNumber,
+							Description:  "Port of the RADIUS server",
+						},
+						"secret": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Shared secret of the RADIUS server",
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Seconds to wait for an answer of the RADIUS server",
+						},
+					},
+				},
+			},
+			"retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times a request is sent again to a server that does not answer",
+			},
+			"service_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Service-Type sent to the RADIUS servers, e.g. authenticate-only or login",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "NAS-Identifier sent to the RADIUS servers",
+			},
+			"accounting_bug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Work around RADIUS servers that do not handle accounting requests correctly, enabled or disabled",
+			},
+			"debug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Log debug messages of RADIUS authentication, enabled or disabled",
+			},
+		},
+	}
+}
+
+// radiusServerName is the name of the RADIUS server objects, the same names
+// the Configuration utility uses.
+func radiusServerName(i int) string {
+	return fmt.Sprintf("/Common/system_auth_name%d", i+1)
+}
+
+func radiusServerConfig(d *schema.ResourceData, i int) *bigip.RadiusServer {
+	prefix := fmt.Sprintf("server.%d.", i)
+	return &bigip.RadiusServer{
+		Name:    radiusServerName(i),
+		Server:  d.Get(prefix + "address").(string),
+		Port:    d.Get(prefix + "port").(int),
+		Secret:  d.Get(prefix + "secret").(string),
+		Timeout: d.Get(prefix + "timeout").(int),
+	}
+}
+
+func resourceBigipSysAuthRadiusCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Creating RADIUS authentication %s", sysAuthName)
+	var servers []string
+	for i := 0; i < d.Get("server.#").(int); i++ {
+		server := radiusServerConfig(d, i)
+		if err := client.CreateRadiusServer(server); err != nil {
+			return diag.FromErr(fmt.Errorf("error creating RADIUS server %s: %v", server.Name, err))
+		}
+		servers = append(servers, server.Name)
+	}
+	config := sysSettingsPatch(d, sysAuthRadiusProperties, true)
+	config["name"] = sysAuthName
+	config["servers"] = servers
+	if err := client.CreateAuthRadius(config); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating RADIUS authentication %s: %v", sysAuthName, err))
+	}
+	d.SetId(sysAuthName)
+	return resourceBigipSysAuthRadiusRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthRadiusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading RADIUS authentication %s", sysAuthName)
+	radius, err := client.GetAuthRadius(sysAuthName)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] RADIUS authentication %s not found, removing from state", sysAuthName)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving RADIUS authentication %s: %v", sysAuthName, err))
+	}
+
+	// the BIG-IP only uses system-auth, whatever id was imported, normalise it
+	d.SetId(sysAuthName)
+
+	var servers []interface{}
+	for i, name := range radius.Servers {
+		server, err := client.GetRadiusServer(name)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving RADIUS server %s: %v", name, err))
+		}
+		// the secret is write only and kept as configured
+		servers = append(servers, map[string]interface{}{
+			"address": server.Server,
+			"port":    server.Port,
+			"secret":  d.Get(fmt.Sprintf("server.%d.secret", i)).(string),
+			"timeout": server.Timeout,
+		})
+	}
+	_ = d.Set("server", servers)
+	_ = d.Set("retries", radius.Retries)
+	_ = d.Set("service_type", radius.ServiceType)
+	_ = d.Set("client_id", radius.ClientId)
+	_ = d.Set("accounting_bug", radius.AccountingBug)
+	_ = d.Set("debug", radius.Debug)
+	return nil
+}
+
+func resourceBigipSysAuthRadiusUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating RADIUS authentication %s", sysAuthName)
+	config := sysSettingsPatch(d, sysAuthRadiusProperties, false)
+	removed := 0
+	if d.HasChange("server") {
+		o, n := d.GetChange("server")
+		previous, count := len(o.([]interface{})), len(n.([]interface{}))
+		var servers []string
+		for i := 0; i < count; i++ {
+			server := radiusServerConfig(d, i)
+			var err error
+			if i < previous {
+				err = client.ModifyRadiusServer(server.Name, map[string]interface{}{
+					"server":  server.Server,
+					"port":    server.Port,
+					"secret":  server.Secret,
+					"timeout": server.Timeout,
+				})
+			} else {
+				err = client.CreateRadiusServer(server)
+			}
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error updating RADIUS server %s: %v", server.Name, err))
+			}
+			servers = append(servers, server.Name)
+		}
+		config["servers"] = servers
+		removed = previous - count
+	}
+	if len(config) > 0 {
+		if err := client.ModifyAuthRadius(sysAuthName, config); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating RADIUS authentication %s: %v", sysAuthName, err))
+		}
+	}
+	// servers that are no longer used can only be removed once system-auth stops referring to them
+	for i := d.Get("server.#").(int); removed > 0; i, removed = i+1, removed-1 {
+		if err := client.DeleteRadiusServer(radiusServerName(i)); err != nil {
+			return diag.FromErr(fmt.Errorf("error deleting RADIUS server %s: %v", radiusServerName(i), err))
+		}
+	}
+	return resourceBigipSysAuthRadiusRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthRadiusDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Deleting RADIUS authentication %s", sysAuthName)
+	if err := client.DeleteAuthRadius(sysAuthName); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting RADIUS authentication %s: %v", sysAuthName, err))
+	}
+	for i := 0; i < d.Get("server.#").(int); i++ {
+		if err := client.DeleteRadiusServer(radiusServerName(i)); err != nil {
+			return diag.FromErr(fmt.Errorf("error deleting RADIUS server %s: %v", radiusServerName(i), err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
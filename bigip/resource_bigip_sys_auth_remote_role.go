@@ -0,0 +1,150 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipSysAuthRemoteRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysAuthRemoteRoleCreate,
+		ReadContext:   resourceBigipSysAuthRemoteRoleRead,
+		UpdateContext: resourceBigipSysAuthRemoteRoleUpdate,
+		DeleteContext: resourceBigipSysAuthRemoteRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the remote role",
+			},
+			"line_order": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Position of the remote role, remote users get the first role whose attribute they match",
+			},
+			"attribute": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Attribute remote users must have, e.g. memberOf=cn=bigip-admins,ou=groups,dc=example,dc=com, or F5-LTM-User-Info-1=admins for RADIUS",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "BIG-IP role of the matching users, e.g. administrator, operator or guest",
+			},
+			"user_partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "All",
+				Description: "Partition the role applies to, or All",
+			},
+			"console": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"disabled", "tmsh"}, false),
+				Description:  "Console access of the matching users, disabled or tmsh",
+			},
+			"deny": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Deny access to the matching users, enabled or disabled",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the remote role",
+			},
+		},
+	}
+}
+
+func dataToRoleInfo(d *schema.ResourceData) *bigip.RoleInfo {
+	return &bigip.RoleInfo{
+		Name:          d.Get("name").(string),
+		Attribute:     d.Get("attribute").(string),
+		Console:       d.Get("console").(string),
+		Deny:          d.Get("deny").(string),
+		Description:   d.Get("description").(string),
+		LineOrder:     d.Get("line_order").(int),
+		Role:          d.Get("role").(string),
+		UserPartition: d.Get("user_partition").(string),
+	}
+}
+
+func resourceBigipSysAuthRemoteRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating remote role %s", name)
+	if err := client.CreateRoleInfo(dataToRoleInfo(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating remote role %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSysAuthRemoteRoleRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthRemoteRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading remote role %s", name)
+	role, err := client.GetRoleInfo(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] Remote role %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving remote role %s: %v", name, err))
+	}
+	_ = d.Set("name", role.Name)
+	_ = d.Set("line_order", role.LineOrder)
+	_ = d.Set("attribute", role.Attribute)
+	_ = d.Set("role", role.Role)
+	_ = d.Set("user_partition", role.UserPartition)
+	_ = d.Set("console", role.Console)
+	_ = d.Set("deny", role.Deny)
+	_ = d.Set("description", role.Description)
+	return nil
+}
+
+func resourceBigipSysAuthRemoteRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Updating remote role %s", name)
+	if err := client.ModifyRoleInfo(name, dataToRoleInfo(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating remote role %s: %v", name, err))
+	}
+	return resourceBigipSysAuthRemoteRoleRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthRemoteRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting remote role %s", name)
+	if err := client.DeleteRoleInfo(name); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting remote role %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const sysAuthSourceID = "auth-source"
+
+// sysRemoteUserProperties maps the attributes of bigip_sys_auth_source to the properties of auth remote-user.
+var sysRemoteUserProperties = map[string]string{
+	"default_role":          "defaultRole",
+	"default_partition":     "defaultPartition",
+	"remote_console_access": "remoteConsoleAccess",
+}
+
+func resourceBigipSysAuthSource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysAuthSourceCreate,
+		ReadContext:   resourceBigipSysAuthSourceRead,
+		UpdateContext: resourceBigipSysAuthSourceUpdate,
+		DeleteContext: resourceBigipSysAuthSourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"local", "active-directory", "ldap", "radius", "tacacs", "clientcert-ldap"}, false),
+				Description:  "Source users are authenticated against: local, active-directory, ldap, radius, tacacs or clientcert-ldap",
+			},
+			"fallback": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fall back to local users when the remote servers cannot be reached",
+			},
+			"default_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Role of remote users that do not match a remote role, e.g. no-access or guest",
+			},
+			"default_partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Partition of remote users that do not match a remote role, e.g. Common or All",
+			},
+			"remote_console_access": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"disabled", "tmsh"}, false),
+				Description:  "Console access of remote users that do not match a remote role, disabled or tmsh",
+			},
+		},
+	}
+}
+
+func resourceBigipSysAuthSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Configuring authentication source")
+	if err := sysAuthSourceModify(client, d, true); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(sysAuthSourceID)
+	return resourceBigipSysAuthSourceRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading authentication source")
+	source, err := client.GetAuthSource()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving authentication source: %v", err))
+	}
+	user, err := client.GetRemoteUser()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving remote user defaults: %v", err))
+	}
+
+	d.SetId(sysAuthSourceID)
+
+	_ = d.Set("type", source.Type)
+	_ = d.Set("fallback", source.Fallback == "true")
+	_ = d.Set("default_role", user.DefaultRole)
+	_ = d.Set("default_partition", user.DefaultPartition)
+	_ = d.Set("remote_console_access", user.RemoteConsoleAccess)
+	return nil
+}
+
+func resourceBigipSysAuthSourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating authentication source")
+	if err := sysAuthSourceModify(client, d, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceBigipSysAuthSourceRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthSourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	// switch back to local users so that the remote configuration can be
+	// removed, the remote user defaults are left in place
+	log.Printf("[INFO] Resetting authentication source to local")
+	if err := client.ModifyAuthSource(map[string]interface{}{"type": "local", "fallback": "false"}); err != nil {
+		return diag.FromErr(fmt.Errorf("error resetting authentication source: %v", err))
+	}
+	d.SetId("")
+	return nil
+}
+
+func sysAuthSourceModify(client *bigip.BigIP, d *schema.ResourceData, create bool) error {
+	if create || d.HasChanges("type", "fallback") {
+		source := map[string]interface{}{
+			"type":     d.Get("type").(string),
+			"fallback": fmt.Sprintf("%t", d.Get("fallback").(bool)),
+		}
+		if err := client.ModifyAuthSource(source); err != nil {
+			return fmt.Errorf("error modifying authentication source: %v", err)
+		}
+	}
+	if user := sysSettingsPatch(d, sysRemoteUserProperties, create); len(user) > 0 {
+		if err := client.ModifyRemoteUser(user); err != nil {
+			return fmt.Errorf("error modifying remote user defaults: %v", err)
+		}
+	}
+	return nil
+}
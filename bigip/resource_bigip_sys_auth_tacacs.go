@@ -0,0 +1,157 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sysAuthTacacsProperties maps the attributes of bigip_sys_auth_tacacs to the properties of auth tacacs.
+var sysAuthTacacsProperties = map[string]string{
+	"servers":        "servers",
+	"secret":         "secret",
+	"encryption":     "encryption",
+	"service":        "service",
+	"protocol":       "protocol",
+	"authentication": "authentication",
+	"accounting":     "accounting",
+	"debug":          "debug",
+}
+
+func resourceBigipSysAuthTacacs() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysAuthTacacsCreate,
+		ReadContext:   resourceBigipSysAuthTacacsRead,
+		UpdateContext: resourceBigipSysAuthTacacsUpdate,
+		DeleteContext: resourceBigipSysAuthTacacsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"servers": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Addresses or host names of the TACACS+ servers",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Shared secret of the TACACS+ servers",
+			},
+			"encryption": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Encrypt the TACACS+ packets, enabled or disabled",
+			},
+			"service": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the service users are authorized for, e.g. ppp",
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Protocol of the service users are authorized for, e.g. ip",
+			},
+			"authentication": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"use-first-server", "use-all-servers"}, false),
+				Description:  "Try only the first server that answers, use-first-server, or every server until one accepts the user, use-all-servers",
+			},
+			"accounting": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"send-to-first-server", "send-to-all-servers"}, false),
+				Description:  "Send accounting to the first server that answers, send-to-first-server, or to every server, send-to-all-servers",
+			},
+			"debug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Log debug messages of TACACS+ authentication, enabled or disabled",
+			},
+		},
+	}
+}
+
+func resourceBigipSysAuthTacacsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Creating TACACS+ authentication %s", sysAuthName)
+	config := sysSettingsPatch(d, sysAuthTacacsProperties, true)
+	config["name"] = sysAuthName
+	if err := client.CreateAuthTacacs(config); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating TACACS+ authentication %s: %v", sysAuthName, err))
+	}
+	d.SetId(sysAuthName)
+	return resourceBigipSysAuthTacacsRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthTacacsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading TACACS+ authentication %s", sysAuthName)
+	tacacs, err := client.GetAuthTacacs(sysAuthName)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] TACACS+ authentication %s not found, removing from state", sysAuthName)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving TACACS+ authentication %s: %v", sysAuthName, err))
+	}
+
+	// the BIG-IP only uses system-auth, whatever id was imported, normalise it
+	d.SetId(sysAuthName)
+
+	// secret is write only and kept as configured
+	_ = d.Set("servers", tacacs.Servers)
+	_ = d.Set("encryption", tacacs.Encryption)
+	_ = d.Set("service", tacacs.Service)
+	_ = d.Set("protocol", tacacs.Protocol)
+	_ = d.Set("authentication", tacacs.Authentication)
+	_ = d.Set("accounting", tacacs.Accounting)
+	_ = d.Set("debug", tacacs.Debug)
+	return nil
+}
+
+func resourceBigipSysAuthTacacsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating TACACS+ authentication %s", sysAuthName)
+	if config := sysSettingsPatch(d, sysAuthTacacsProperties, false); len(config) > 0 {
+		if err := client.ModifyAuthTacacs(sysAuthName, config); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating TACACS+ authentication %s: %v", sysAuthName, err))
+		}
+	}
+	return resourceBigipSysAuthTacacsRead(ctx, d, meta)
+}
+
+func resourceBigipSysAuthTacacsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Deleting TACACS+ authentication %s", sysAuthName)
+	if err := client.DeleteAuthTacacs(sysAuthName); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting TACACS+ authentication %s: %v", sysAuthName, err))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// the authentication source is left local, so the test does not change how the BIG-IP is logged in to
+const testAccBigipSysAuthTacacsConfig = `
+resource "bigip_sys_auth_tacacs" "test" {
+  servers  = ["192.0.2.31"]
+  secret   = "tf-acc-secret"
+  service  = "ppp"
+  protocol = "ip"
+}
+
+resource "bigip_sys_auth_remote_role" "test" {
+  name       = "tf-acc-remote-role"
+  line_order = 1001
+  attribute  = "F5-LTM-User-Info-1=tf-acc-admins"
+  role       = "operator"
+  console    = "disabled"
+}
+`
+
+func TestAccBigipSysAuthTacacsCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSysAuthDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipSysAuthTacacsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_auth_tacacs.test", "servers.0", "192.0.2.31"),
+					resource.TestCheckResourceAttr("bigip_sys_auth_tacacs.test", "service", "ppp"),
+					resource.TestCheckResourceAttr("bigip_sys_auth_remote_role.test", "role", "operator"),
+					resource.TestCheckResourceAttr("bigip_sys_auth_remote_role.test", "user_partition", "All"),
+				),
+			},
+			{
+				ResourceName:      "bigip_sys_auth_remote_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:            "bigip_sys_auth_tacacs.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+		},
+	})
+}
+
+func testCheckSysAuthDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "bigip_sys_auth_tacacs":
+			if _, err := client.GetAuthTacacs(rs.Primary.ID); err == nil {
+				return fmt.Errorf("TACACS+ authentication %s not destroyed", rs.Primary.ID)
+			}
+		case "bigip_sys_auth_remote_role":
+			if _, err := client.GetRoleInfo(rs.Primary.ID); err == nil {
+				return fmt.Errorf("remote role %s not destroyed", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_sys_auth_ldap, bigip_sys_auth_radius, bigip_sys_auth_tacacs,
+// bigip_sys_auth_source and bigip_sys_auth_remote_role against a fake BIG-IP -
+// no F5 BIG-IP connection required
+
+// fakeSysAuthServer stores auth objects per collection, e.g. "ldap" or
+// "remote-role/role-info", and the source and remote-user singletons. Like the
+// BIG-IP it returns secrets encrypted.
+type fakeSysAuthServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	objects    map[string]map[string]map[string]interface{}
+	source     map[string]interface{}
+	remoteUser map[string]interface{}
+	requests   []string
+}
+
+var fakeAuthSecrets = []string{"bindPw", "secret"}
+
+func newFakeSysAuthServer(t *testing.T) *fakeSysAuthServer {
+	f := &fakeSysAuthServer{
+		objects:    make(map[string]map[string]map[string]interface{}),
+		source:     map[string]interface{}{"type": "local", "fallback": "false"},
+		remoteUser: map[string]interface{}{"defaultRole": "no-access", "defaultPartition": "all", "remoteConsoleAccess": "disabled"},
+	}
+	encrypt := func(object map[string]interface{}) map[string]interface{} {
+		out := make(map[string]interface{})
+		for k, v := range object {
+			out[k] = v
+		}
+		for _, k := range fakeAuthSecrets {
+			if _, ok := out[k]; ok {
+				out[k] = "$M$encrypted"
+			}
+		}
+		return out
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/auth/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/auth/")
+		switch path {
+		case "source", "remote-user":
+			settings := f.source
+			if path == "remote-user" {
+				settings = f.remoteUser
+			}
+			for k, v := range body {
+				settings[k] = v
+			}
+			_ = json.NewEncoder(w).Encode(settings)
+			return
+		case "ldap", "radius", "radius-server", "tacacs", "remote-role/role-info":
+			if f.objects[path] == nil {
+				f.objects[path] = make(map[string]map[string]interface{})
+			}
+			name := strings.ReplaceAll(body["name"].(string), "/", "~")
+			body["fullPath"] = body["name"]
+			f.objects[path][name] = body
+			_ = json.NewEncoder(w).Encode(encrypt(body))
+			return
+		}
+		i := strings.LastIndex(path, "/")
+		collection, name := path[:i], path[i+1:]
+		object, ok := f.objects[collection][name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested object (%s) was not found."}`, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			for k, v := range body {
+				object[k] = v
+			}
+		case "DELETE":
+			delete(f.objects[collection], name)
+		}
+		_ = json.NewEncoder(w).Encode(encrypt(object))
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestResourceBigipSysAuthLdapLifecycle(t *testing.T) {
+	f := newFakeSysAuthServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysAuthLdap().Schema, map[string]interface{}{
+		"servers":        []interface{}{"ldap1.example.com", "ldap2.example.com"},
+		"bind_dn":        "cn=bigip,ou=services,dc=example,dc=com",
+		"bind_password":  "bind-secret",
+		"search_base_dn": "ou=people,dc=example,dc=com",
+		"ssl":            "start-tls",
+	})
+	if diags := resourceBigipSysAuthLdapCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "system-auth", d.Id())
+	ldap := f.objects["ldap"]["system-auth"]
+	assert.Equal(t, "bind-secret", ldap["bindPw"])
+	assert.Equal(t, "start-tls", ldap["ssl"])
+	assert.NotContains(t, ldap, "port", "unconfigured settings are left to the device defaults")
+	assert.Equal(t, "bind-secret", d.Get("bind_password"), "the encrypted password is not read back")
+
+	// drift on the device shows up on refresh
+	f.mu.Lock()
+	ldap["servers"] = []interface{}{"ldap1.example.com"}
+	f.mu.Unlock()
+	if diags := resourceBigipSysAuthLdapRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []interface{}{"ldap1.example.com"}, d.Get("servers"))
+
+	// import normalises the id
+	imported := schema.TestResourceDataRaw(t, resourceBigipSysAuthLdap().Schema, map[string]interface{}{})
+	imported.SetId("/Common/system-auth")
+	if diags := resourceBigipSysAuthLdapRead(context.Background(), imported, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "system-auth", imported.Id())
+	assert.Equal(t, "ou=people,dc=example,dc=com", imported.Get("search_base_dn"))
+	assert.Equal(t, "", imported.Get("bind_password"))
+
+	if diags := resourceBigipSysAuthLdapDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.objects["ldap"])
+	d.SetId("system-auth")
+	if diags := resourceBigipSysAuthLdapRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysAuthRadiusServers(t *testing.T) {
+	f := newFakeSysAuthServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	r := resourceBigipSysAuthRadius()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server": []interface{}{
+			map[string]interface{}{"address": "192.0.2.21", "secret": "primary-secret"},
+			map[string]interface{}{"address": "192.0.2.22", "secret": "secondary-secret", "port": 1645},
+		},
+		"service_type": "authenticate-only",
+	})
+	if diags := resourceBigipSysAuthRadiusCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "system-auth", d.Id())
+	assert.Equal(t, []interface{}{"/Common/system_auth_name1", "/Common/system_auth_name2"}, f.objects["radius"]["system-auth"]["servers"])
+	assert.Equal(t, "secondary-secret", f.objects["radius-server"]["~Common~system_auth_name2"]["secret"])
+	assert.Equal(t, float64(1645), f.objects["radius-server"]["~Common~system_auth_name2"]["port"])
+	assert.Equal(t, "primary-secret", d.Get("server.0.secret"), "the encrypted secret is not read back")
+	assert.Equal(t, 1812, d.Get("server.0.port"))
+
+	// dropping the secondary server removes it once system-auth no longer uses it
+	state := d.State()
+	d, err := schema.InternalMap(r.Schema).Data(state, &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"server.#":         {Old: "2", New: "1"},
+			"server.0.address": {Old: "192.0.2.21", New: "192.0.2.23"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.requests = nil
+	if diags := resourceBigipSysAuthRadiusUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []string{
+		"PATCH /mgmt/tm/auth/radius-server/~Common~system_auth_name1",
+		"PATCH /mgmt/tm/auth/radius/system-auth",
+		"DELETE /mgmt/tm/auth/radius-server/~Common~system_auth_name2",
+		"GET /mgmt/tm/auth/radius/system-auth",
+		"GET /mgmt/tm/auth/radius-server/~Common~system_auth_name1",
+	}, f.requests)
+	assert.Equal(t, "192.0.2.23", f.objects["radius-server"]["~Common~system_auth_name1"]["server"])
+	assert.Equal(t, 1, d.Get("server.#"))
+
+	if diags := resourceBigipSysAuthRadiusDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.objects["radius"])
+	assert.Empty(t, f.objects["radius-server"])
+}
+
+func TestResourceBigipSysAuthTacacsSecret(t *testing.T) {
+	f := newFakeSysAuthServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysAuthTacacs().Schema, map[string]interface{}{
+		"servers":    []interface{}{"192.0.2.31"},
+		"secret":     "tacacs-secret",
+		"service":    "ppp",
+		"protocol":   "ip",
+		"encryption": "enabled",
+	})
+	if diags := resourceBigipSysAuthTacacsCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "tacacs-secret", f.objects["tacacs"]["system-auth"]["secret"])
+	assert.Equal(t, "tacacs-secret", d.Get("secret"))
+	assert.Equal(t, "ppp", d.Get("service"))
+	assert.True(t, resourceBigipSysAuthTacacs().Schema["secret"].Sensitive)
+}
+
+func TestResourceBigipSysAuthSourceLifecycle(t *testing.T) {
+	f := newFakeSysAuthServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysAuthSource().Schema, map[string]interface{}{
+		"type":         "ldap",
+		"fallback":     true,
+		"default_role": "guest",
+	})
+	if diags := resourceBigipSysAuthSourceCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "auth-source", d.Id())
+	assert.Equal(t, map[string]interface{}{"type": "ldap", "fallback": "true"}, f.source)
+	assert.Equal(t, "guest", f.remoteUser["defaultRole"])
+	assert.Equal(t, "all", d.Get("default_partition"))
+
+	if diags := resourceBigipSysAuthSourceDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, map[string]interface{}{"type": "local", "fallback": "false"}, f.source)
+	assert.Equal(t, "guest", f.remoteUser["defaultRole"], "the remote user defaults are left in place")
+}
+
+func TestResourceBigipSysAuthRemoteRoleLifecycle(t *testing.T) {
+	f := newFakeSysAuthServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysAuthRemoteRole().Schema, map[string]interface{}{
+		"name":       "bigip-admins",
+		"line_order": 10,
+		"attribute":  "memberOf=cn=bigip-admins,ou=groups,dc=example,dc=com",
+		"role":       "administrator",
+		"console":    "tmsh",
+	})
+	if diags := resourceBigipSysAuthRemoteRoleCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "bigip-admins", d.Id())
+	role := f.objects["remote-role/role-info"]["bigip-admins"]
+	assert.Equal(t, float64(10), role["lineOrder"])
+	assert.Equal(t, "All", role["userPartition"])
+	assert.Equal(t, "disabled", role["deny"])
+
+	if diags := resourceBigipSysAuthRemoteRoleDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.objects["remote-role/role-info"])
+	d.SetId("bigip-admins")
+	if diags := resourceBigipSysAuthRemoteRoleRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipSysDb() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysDbCreate,
+		ReadContext:   resourceBigipSysDbRead,
+		UpdateContext: resourceBigipSysDbUpdate,
+		DeleteContext: resourceBigipSysDbDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the db variable, e.g. ui.advisory.enabled",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Value of the db variable",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// the BIG-IP normalizes the case of enumerated values, e.g. Enable to enable
+					return strings.EqualFold(old, new)
+				},
+			},
+			"default_value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Default value of the db variable, the value is reset to it on destroy",
+			},
+			"value_range": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Values accepted by the db variable",
+			},
+		},
+	}
+}
+
+func resourceBigipSysDbCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Setting db variable %s", name)
+	if err := client.ModifySysDb(name, d.Get("value").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting db variable %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSysDbRead(ctx, d, meta)
+}
+
+func resourceBigipSysDbRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading db variable %s", name)
+	db, err := client.GetSysDb(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] db variable %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving db variable %s: %v", name, err))
+	}
+	_ = d.Set("name", db.Name)
+	_ = d.Set("value", db.Value)
+	_ = d.Set("default_value", db.DefaultValue)
+	_ = d.Set("value_range", db.ValueRange)
+	return nil
+}
+
+func resourceBigipSysDbUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Updating db variable %s", name)
+	if err := client.ModifySysDb(name, d.Get("value").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating db variable %s: %v", name, err))
+	}
+	return resourceBigipSysDbRead(ctx, d, meta)
+}
+
+// resourceBigipSysDbDelete resets the db variable to its default value, db
+// variables always exist on the device.
+func resourceBigipSysDbDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	db, err := client.GetSysDb(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] db variable %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving db variable %s: %v", name, err))
+	}
+	log.Printf("[INFO] Resetting db variable %s to its default value %q", name, db.DefaultValue)
+	if err := client.ModifySysDb(name, db.DefaultValue); err != nil {
+		return diag.FromErr(fmt.Errorf("error resetting db variable %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
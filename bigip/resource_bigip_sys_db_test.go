@@ -0,0 +1,64 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipSysDbConfig = `
+resource "bigip_sys_db" "test" {
+  name  = "ui.advisory.text"
+  value = "tf-acc-test"
+}
+`
+
+func TestAccBigipSysDbCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSysDbReset,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipSysDbConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_db.test", "value", "tf-acc-test"),
+					resource.TestCheckResourceAttrSet("bigip_sys_db.test", "value_range"),
+				),
+			},
+			{
+				ResourceName:      "bigip_sys_db.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckSysDbReset(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_sys_db" {
+			continue
+		}
+		db, err := client.GetSysDb(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if db.Value != db.DefaultValue {
+			return fmt.Errorf("db variable %s not reset to its default value: %s", rs.Primary.ID, db.Value)
+		}
+	}
+	return nil
+}
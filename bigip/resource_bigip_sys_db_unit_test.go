@@ -0,0 +1,255 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_sys_db, bigip_sys_global_settings, bigip_sys_sshd and bigip_sys_httpd against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeSysSettingsServer serves sys db variables, sys global-settings, sshd
+// and httpd and records every PATCH it receives.
+type fakeSysSettingsServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	db       map[string]*bigip.SysDb
+	settings map[string]interface{}
+	sshd     map[string]interface{}
+	httpd    map[string]interface{}
+	patches  []map[string]interface{}
+}
+
+func newFakeSysSettingsServer(t *testing.T) *fakeSysSettingsServer {
+	f := &fakeSysSettingsServer{
+		db: map[string]*bigip.SysDb{
+			"ui.advisory.enabled": {Name: "ui.advisory.enabled", Value: "false", DefaultValue: "false", ValueRange: "true false"},
+			"provision.extramb":   {Name: "provision.extramb", Value: "0", DefaultValue: "0", ValueRange: "integer min:0 max:8192"},
+		},
+		settings: map[string]interface{}{
+			"kind":                     "tm:sys:global-settings:global-settingsstate",
+			"hostname":                 "bigip1.example.com",
+			"guiSetup":                 "enabled",
+			"guiSecurityBanner":        "enabled",
+			"guiSecurityBannerText":    "Welcome",
+			"consoleInactivityTimeout": 0,
+			"lcdDisplay":               "enabled",
+		},
+		sshd: map[string]interface{}{
+			"allow":             []interface{}{"10.0.0.0/8"},
+			"inactivityTimeout": 600,
+			"port":              22,
+		},
+		httpd: map[string]interface{}{
+			"allow":              []interface{}{"All"},
+			"authPamIdleTimeout": 1200,
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/sys/db/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/db/")
+		db, ok := f.db[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested db variable (%s) was not found."}`, name)
+			return
+		}
+		if r.Method == "PATCH" {
+			var patch map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&patch)
+			f.patches = append(f.patches, patch)
+			// enumerated values are stored in lower case
+			db.Value = strings.ToLower(patch["value"].(string))
+		}
+		_ = json.NewEncoder(w).Encode(db)
+	})
+	singleton := func(properties map[string]interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if r.Method == "PATCH" {
+				var patch map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&patch)
+				f.patches = append(f.patches, patch)
+				for k, v := range patch {
+					properties[k] = v
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(properties)
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/sys/global-settings", singleton(f.settings))
+	mux.HandleFunc("/mgmt/tm/sys/sshd", singleton(f.sshd))
+	mux.HandleFunc("/mgmt/tm/sys/httpd", singleton(f.httpd))
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestResourceBigipSysDbLifecycle(t *testing.T) {
+	f := newFakeSysSettingsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysDb().Schema, map[string]interface{}{
+		"name":  "provision.extramb",
+		"value": "2048",
+	})
+	if diags := resourceBigipSysDbCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "provision.extramb", d.Id())
+	assert.Equal(t, []map[string]interface{}{{"value": "2048"}}, f.patches)
+	assert.Equal(t, "2048", d.Get("value"))
+	assert.Equal(t, "0", d.Get("default_value"))
+	assert.Equal(t, "integer min:0 max:8192", d.Get("value_range"))
+
+	// drift on the device shows up on refresh
+	f.mu.Lock()
+	f.db["provision.extramb"].Value = "1024"
+	f.mu.Unlock()
+	if diags := resourceBigipSysDbRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "1024", d.Get("value"))
+
+	// destroy resets the default value
+	if diags := resourceBigipSysDbDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "0", f.db["provision.extramb"].Value)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysDbValueCase(t *testing.T) {
+	assert.True(t, resourceBigipSysDb().Schema["value"].DiffSuppressFunc("value", "enable", "Enable", nil))
+	assert.False(t, resourceBigipSysDb().Schema["value"].DiffSuppressFunc("value", "enable", "disable", nil))
+}
+
+func TestResourceBigipSysDbImportMissing(t *testing.T) {
+	f := newFakeSysSettingsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysDb().Schema, map[string]interface{}{})
+	d.SetId("no.such.key")
+	if diags := resourceBigipSysDbRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+
+	d = schema.TestResourceDataRaw(t, resourceBigipSysDb().Schema, map[string]interface{}{"name": "no.such.key", "value": "1"})
+	diags := resourceBigipSysDbCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for an unknown db variable")
+	}
+	assert.Contains(t, diags[0].Summary, "was not found")
+
+	// a variable gone from the device, e.g. after an upgrade, is destroyed without error
+	d.SetId("no.such.key")
+	if diags := resourceBigipSysDbDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysGlobalSettingsRestore(t *testing.T) {
+	f := newFakeSysSettingsServer(t)
+	client := testFakeBigipClient(f.Server)
+	f.settings["consoleInactivityTimeout"] = 300
+	r := resourceBigipSysGlobalSettings()
+
+	config := map[string]interface{}{
+		"hostname":                   "bigip-prod-1.example.com",
+		"gui_setup":                  "disabled",
+		"console_inactivity_timeout": 0,
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, "sys-global-settings", d.Id())
+	assert.Equal(t, []map[string]interface{}{{"hostname": "bigip-prod-1.example.com", "guiSetup": "disabled", "consoleInactivityTimeout": float64(0)}}, f.patches, "only configured settings are changed, a timeout of 0 included")
+	assert.Equal(t, map[string]interface{}{
+		"hostname":                   "bigip1.example.com",
+		"gui_setup":                  "enabled",
+		"console_inactivity_timeout": "300",
+	}, d.Get("previous_values"), "only configured settings are recorded")
+	assert.Equal(t, "Welcome", d.Get("gui_security_banner_text"), "unconfigured settings are read back")
+
+	// an update changes only what changed, and records the previous value of a setting added to the configuration
+	config["lcd_display"] = "disabled"
+	d = testResourceApply(t, r, d.State(), config, client)
+	assert.Equal(t, map[string]interface{}{"lcdDisplay": "disabled"}, f.patches[len(f.patches)-1])
+	assert.Equal(t, "enabled", d.Get("previous_values.lcd_display"))
+	assert.Equal(t, "bigip1.example.com", d.Get("previous_values.hostname"), "the value before the first change is kept")
+
+	// a setting outside the configuration changed on the device is left alone on destroy
+	f.settings["guiSecurityBannerText"] = "Changed"
+	if diags := resourceBigipSysGlobalSettingsDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, map[string]interface{}{"hostname": "bigip1.example.com", "guiSetup": "enabled", "consoleInactivityTimeout": float64(300), "lcdDisplay": "enabled"}, f.patches[len(f.patches)-1])
+	assert.Equal(t, "bigip1.example.com", f.settings["hostname"])
+	assert.Equal(t, "Changed", f.settings["guiSecurityBannerText"])
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysGlobalSettingsNoRestore(t *testing.T) {
+	f := newFakeSysSettingsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysGlobalSettings().Schema, map[string]interface{}{
+		"lcd_display":        "disabled",
+		"restore_on_destroy": false,
+	})
+	if diags := resourceBigipSysGlobalSettingsCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags := resourceBigipSysGlobalSettingsDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Len(t, f.patches, 1)
+	assert.Equal(t, "disabled", f.settings["lcdDisplay"])
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysSshdZeroValues(t *testing.T) {
+	f := newFakeSysSettingsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	// zero values in the configuration are sent on create
+	d := testResourceApply(t, resourceBigipSysSshd(), nil, map[string]interface{}{
+		"allow":              []interface{}{},
+		"inactivity_timeout": 0,
+	}, client)
+	assert.Equal(t, []map[string]interface{}{{"allow": []interface{}{}, "inactivityTimeout": float64(0)}}, f.patches)
+	assert.Equal(t, 0, d.Get("inactivity_timeout"))
+	assert.Equal(t, 0, d.Get("allow.#"))
+	assert.Equal(t, 22, d.Get("port"))
+}
+
+func TestResourceBigipSysHttpdZeroValues(t *testing.T) {
+	f := newFakeSysSettingsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := testResourceApply(t, resourceBigipSysHttpd(), nil, map[string]interface{}{
+		"allow": []interface{}{},
+	}, client)
+	assert.Equal(t, []map[string]interface{}{{"allow": []interface{}{}}}, f.patches)
+	assert.Equal(t, 0, d.Get("allow.#"))
+	assert.Equal(t, 1200, d.Get("auth_pam_idle_timeout"))
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const sysGlobalSettingsID = "sys-global-settings"
+
+// sysGlobalSettings maps the attributes of bigip_sys_global_settings to the
+// properties of sys global-settings.
+var sysGlobalSettings = []struct {
+	attr, key   string
+	integer     bool
+	description string
+}{
+	{attr: "hostname", key: "hostname", description: "Fully qualified host name of the BIG-IP"},
+	{attr: "gui_setup", key: "guiSetup", description: "Run the setup utility on the next login to the Configuration utility, enabled or disabled"},
+	{attr: "gui_security_banner", key: "guiSecurityBanner", description: "Show the security banner on the login page of the Configuration utility, enabled or disabled"},
+	{attr: "gui_security_banner_text", key: "guiSecurityBannerText", description: "Text of the security banner on the login page of the Configuration utility"},
+	{attr: "gui_audit", key: "guiAudit", description: "Log changes made through the Configuration utility, enabled or disabled"},
+	{attr: "console_inactivity_timeout", key: "consoleInactivityTimeout", integer: true, description: "Seconds of inactivity before a console session is logged out, 0 disables the timeout"},
+	{attr: "mgmt_dhcp", key: "mgmtDhcp", description: "DHCP on the management interface, e.g. enabled or disabled"},
+	{attr: "lcd_display", key: "lcdDisplay", description: "Show system information on the LCD, enabled or disabled"},
+	{attr: "net_reboot", key: "netReboot", description: "Boot from the network on the next reboot, enabled or disabled"},
+	{attr: "quiet_boot", key: "quietBoot", description: "Hide boot messages on the console, enabled or disabled"},
+	{attr: "username_prompt", key: "usernamePrompt", description: "Prompt for the user name on the login page of the Configuration utility"},
+	{attr: "password_prompt", key: "passwordPrompt", description: "Prompt for the password on the login page of the Configuration utility"},
+}
+
+var sysGlobalSettingsToggles = map[string]bool{
+	"gui_setup":           true,
+	"gui_security_banner": true,
+	"gui_audit":           true,
+	"lcd_display":         true,
+	"net_reboot":          true,
+	"quiet_boot":          true,
+}
+
+func resourceBigipSysGlobalSettings() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"restore_on_destroy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Restore the settings in the configuration to the values they had before Terraform changed them when the resource is destroyed",
+		},
+		"previous_values": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Values of the settings in the configuration before Terraform changed them",
+		},
+	}
+	for _, setting := range sysGlobalSettings {
+		attr := &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: setting.description,
+		}
+		if setting.integer {
+			attr.Type = schema.TypeInt
+			attr.ValidateFunc = validation.IntAtLeast(0)
+		}
+		if sysGlobalSettingsToggles[setting.attr] {
+			attr.ValidateFunc = validation.StringInSlice([]string{"enabled", "disabled"}, false)
+		}
+		s[setting.attr] = attr
+	}
+	return &schema.Resource{
+		CreateContext: resourceBigipSysGlobalSettingsCreate,
+		ReadContext:   resourceBigipSysGlobalSettingsRead,
+		UpdateContext: resourceBigipSysGlobalSettingsUpdate,
+		DeleteContext: resourceBigipSysGlobalSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: s,
+	}
+}
+
+func resourceBigipSysGlobalSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Configuring sys global-settings")
+	current, err := client.GetSysGlobalSettings()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving sys global-settings: %v", err))
+	}
+	previous := make(map[string]interface{})
+	patch := make(map[string]interface{})
+	for _, setting := range sysGlobalSettings {
+		// only the settings in the configuration are changed, and restored on destroy
+		if sysGlobalSettingConfigured(d, setting.attr) {
+			previous[setting.attr] = sysGlobalSettingString(current[setting.key])
+			patch[setting.key] = d.Get(setting.attr)
+		}
+	}
+	if len(patch) > 0 {
+		if err := client.ModifySysGlobalSettings(patch); err != nil {
+			return diag.FromErr(fmt.Errorf("error modifying sys global-settings: %v", err))
+		}
+	}
+	d.SetId(sysGlobalSettingsID)
+	_ = d.Set("previous_values", previous)
+	return resourceBigipSysGlobalSettingsRead(ctx, d, meta)
+}
+
+func resourceBigipSysGlobalSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading sys global-settings")
+	current, err := client.GetSysGlobalSettings()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving sys global-settings: %v", err))
+	}
+
+	d.SetId(sysGlobalSettingsID)
+
+	for _, setting := range sysGlobalSettings {
+		if setting.integer {
+			v, _ := current[setting.key].(float64)
+			_ = d.Set(setting.attr, int(v))
+			continue
+		}
+		_ = d.Set(setting.attr, sysGlobalSettingString(current[setting.key]))
+	}
+	return nil
+}
+
+func resourceBigipSysGlobalSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating sys global-settings")
+	previous := d.Get("previous_values").(map[string]interface{})
+	patch := make(map[string]interface{})
+	for _, setting := range sysGlobalSettings {
+		if !d.HasChange(setting.attr) {
+			continue
+		}
+		patch[setting.key] = d.Get(setting.attr)
+		// the value before the first change by Terraform is the one restored on destroy
+		if _, ok := previous[setting.attr]; !ok {
+			old, _ := d.GetChange(setting.attr)
+			previous[setting.attr] = sysGlobalSettingString(old)
+		}
+	}
+	if len(patch) > 0 {
+		if err := client.ModifySysGlobalSettings(patch); err != nil {
+			return diag.FromErr(fmt.Errorf("error modifying sys global-settings: %v", err))
+		}
+	}
+	_ = d.Set("previous_values", previous)
+	return resourceBigipSysGlobalSettingsRead(ctx, d, meta)
+}
+
+// resourceBigipSysGlobalSettingsDelete restores the settings recorded in
+// previous_values that no longer have their previous value. The settings
+// Terraform never changed, imported ones included, are left unchanged.
+func resourceBigipSysGlobalSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	previous := d.Get("previous_values").(map[string]interface{})
+	if !d.Get("restore_on_destroy").(bool) || len(previous) == 0 {
+		log.Printf("[INFO] Removing sys global-settings from state, device configuration is left unchanged")
+		d.SetId("")
+		return nil
+	}
+	current, err := client.GetSysGlobalSettings()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving sys global-settings: %v", err))
+	}
+	patch := make(map[string]interface{})
+	for _, setting := range sysGlobalSettings {
+		value, ok := previous[setting.attr].(string)
+		if !ok || value == sysGlobalSettingString(current[setting.key]) {
+			continue
+		}
+		if setting.integer {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			patch[setting.key] = n
+			continue
+		}
+		patch[setting.key] = value
+	}
+	if len(patch) > 0 {
+		log.Printf("[INFO] Restoring sys global-settings %v", patch)
+		if err := client.ModifySysGlobalSettings(patch); err != nil {
+			return diag.FromErr(fmt.Errorf("error restoring sys global-settings: %v", err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// sysGlobalSettingConfigured tells if attr is set in the configuration, a
+// console_inactivity_timeout of 0 included.
+func sysGlobalSettingConfigured(d *schema.ResourceData, attr string) bool {
+	if raw := d.GetRawConfig(); ctyValIsSet(raw) {
+		return !raw.GetAttr(attr).IsNull()
+	}
+	_, ok := d.GetOk(attr)
+	return ok
+}
+
+// sysGlobalSettingString formats a property of sys global-settings as a string.
+func sysGlobalSettingString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
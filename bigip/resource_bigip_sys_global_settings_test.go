@@ -0,0 +1,39 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccBigipSysGlobalSettingsConfig = `
+resource "bigip_sys_global_settings" "test" {
+  gui_security_banner      = "enabled"
+  gui_security_banner_text = "tf-acc-test"
+}
+`
+
+func TestAccBigipSysGlobalSettingsCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipSysGlobalSettingsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_global_settings.test", "gui_security_banner_text", "tf-acc-test"),
+					resource.TestCheckResourceAttrSet("bigip_sys_global_settings.test", "hostname"),
+					resource.TestCheckResourceAttrSet("bigip_sys_global_settings.test", "previous_values.gui_security_banner_text"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const sysHttpdID = "httpd"
+
+// sysHttpdProperties maps the attributes of bigip_sys_httpd to the properties of sys httpd.
+var sysHttpdProperties = map[string]string{
+	"allow":                      "allow",
+	"auth_name":                  "authName",
+	"auth_pam_idle_timeout":      "authPamIdleTimeout",
+	"auth_pam_dashboard_timeout": "authPamDashboardTimeout",
+	"max_clients":                "maxClients",
+	"redirect_http_to_https":     "redirectHttpToHttps",
+	"ssl_ciphersuite":            "sslCiphersuite",
+	"ssl_protocol":               "sslProtocol",
+	"ssl_port":                   "sslPort",
+	"log_level":                  "logLevel",
+}
+
+func resourceBigipSysHttpd() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysHttpdCreate,
+		ReadContext:   resourceBigipSysHttpdRead,
+		UpdateContext: resourceBigipSysHttpdUpdate,
+		DeleteContext: resourceBigipSysHttpdDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"allow": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Addresses and networks allowed to connect to the Configuration utility and the REST API, e.g. 10.0.0.0/255.0.0.0, or All",
+			},
+			"auth_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Realm shown when the web server asks for credentials",
+			},
+			"auth_pam_idle_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds of inactivity before a session of the Configuration utility is logged out",
+			},
+			"auth_pam_dashboard_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"on", "off"}, false),
+				Description:  "Apply the idle timeout to sessions showing the dashboard, on or off",
+			},
+			"max_clients": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of concurrent connections to the web server",
+			},
+			"redirect_http_to_https": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Redirect HTTP requests to HTTPS, enabled or disabled",
+			},
+			"ssl_ciphersuite": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "OpenSSL cipher string of the ciphers offered by the web server",
+			},
+			"ssl_protocol": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "TLS protocols accepted by the web server, e.g. all -SSLv2 -SSLv3 -TLSv1 -TLSv1.1",
+			},
+			"ssl_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port the web server listens on for HTTPS",
+			},
+			"log_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"alert", "crit", "debug", "emerg", "error", "info", "notice", "warn"}, false),
+				Description:  "Log level of the web server",
+			},
+		},
+	}
+}
+
+func resourceBigipSysHttpdCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Configuring sys httpd")
+	if patch := sysSettingsPatch(d, sysHttpdProperties, true); len(patch) > 0 {
+		if err := client.ModifyHttpd(patch); err != nil {
+			return diag.FromErr(fmt.Errorf("error modifying sys httpd: %v", err))
+		}
+	}
+	d.SetId(sysHttpdID)
+	return resourceBigipSysHttpdRead(ctx, d, meta)
+}
+
+func resourceBigipSysHttpdRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading sys httpd")
+	httpd, err := client.GetHttpd()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving sys httpd: %v", err))
+	}
+
+	d.SetId(sysHttpdID)
+
+	_ = d.Set("allow", httpd.Allow)
+	_ = d.Set("auth_name", httpd.AuthName)
+	_ = d.Set("auth_pam_idle_timeout", httpd.AuthPamIdleTimeout)
+	_ = d.Set("auth_pam_dashboard_timeout", httpd.AuthPamDashboardTimeout)
+	_ = d.Set("max_clients", httpd.MaxClients)
+	_ = d.Set("redirect_http_to_https", httpd.RedirectHttpToHttps)
+	_ = d.Set("ssl_ciphersuite", httpd.SslCiphersuite)
+	_ = d.Set("ssl_protocol", httpd.SslProtocol)
+	_ = d.Set("ssl_port", httpd.SslPort)
+	_ = d.Set("log_level", httpd.LogLevel)
+	return nil
+}
+
+func resourceBigipSysHttpdUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating sys httpd")
+	if patch := sysSettingsPatch(d, sysHttpdProperties, false); len(patch) > 0 {
+		if err := client.ModifyHttpd(patch); err != nil {
+			return diag.FromErr(fmt.Errorf("error modifying sys httpd: %v", err))
+		}
+	}
+	return resourceBigipSysHttpdRead(ctx, d, meta)
+}
+
+func resourceBigipSysHttpdDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// httpd always exists on the device; destroying the resource only stops
+	// Terraform from managing it and leaves the current values in place.
+	log.Printf("[INFO] Removing sys httpd from state, device configuration is left unchanged")
+	d.SetId("")
+	return nil
+}
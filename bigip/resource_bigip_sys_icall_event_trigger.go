@@ -0,0 +1,180 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// icallUserAlertConf holds the alertd rules of the event triggers, alertd
+// runs their command when a syslog message matches.
+const icallUserAlertConf = "/config/user_alert.conf"
+
+// icallUserAlertMutex serializes the updates of user_alert.conf by the event triggers of a run.
+var icallUserAlertMutex sync.Mutex
+
+var icallEventTriggerRule = regexp.MustCompile(`^alert (\S+) "(.*)" \{\n\s*exec command="tmsh generate sys icall event (\S+)"\n\}$`)
+
+func resourceBigipSysIcallEventTrigger() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysIcallEventTriggerCreate,
+		ReadContext:   resourceBigipSysIcallEventTriggerRead,
+		UpdateContext: resourceBigipSysIcallEventTriggerUpdate,
+		DeleteContext: resourceBigipSysIcallEventTriggerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_]+$`), "must contain only letters, digits and underscores"),
+				Description:  "Name of the alertd rule generating the event",
+			},
+			"match": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^"\n]+$`), "must not contain double quotes or new lines"),
+				Description:  "Regular expression matched against the syslog messages, e.g. Pool /Common/web_pool member .* monitor status down",
+			},
+			"event_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_./-]+$`), "must contain only letters, digits and _ . / -"),
+				Description:  "Name of the iCall event generated on a match, for the subscriptions of triggered handlers",
+			},
+		},
+	}
+}
+
+func resourceBigipSysIcallEventTriggerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating iCall event trigger %s", name)
+	if err := icallSetEventTrigger(client, name, icallEventTriggerBlock(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating iCall event trigger %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSysIcallEventTriggerRead(ctx, d, meta)
+}
+
+func resourceBigipSysIcallEventTriggerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading iCall event trigger %s", name)
+	conf, err := icallUserAlerts(client)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving iCall event trigger %s: %v", name, err))
+	}
+	_, block, _ := icallFindEventTrigger(conf, name)
+	rule := icallEventTriggerRule.FindStringSubmatch(block)
+	if rule == nil {
+		log.Printf("[WARN] iCall event trigger %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("name", rule[1])
+	_ = d.Set("match", rule[2])
+	_ = d.Set("event_name", rule[3])
+	return nil
+}
+
+func resourceBigipSysIcallEventTriggerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Updating iCall event trigger %s", name)
+	if err := icallSetEventTrigger(client, name, icallEventTriggerBlock(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating iCall event trigger %s: %v", name, err))
+	}
+	return resourceBigipSysIcallEventTriggerRead(ctx, d, meta)
+}
+
+func resourceBigipSysIcallEventTriggerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting iCall event trigger %s", name)
+	if err := icallSetEventTrigger(client, name, ""); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting iCall event trigger %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+func icallEventTriggerBlock(d *schema.ResourceData) string {
+	return fmt.Sprintf("alert %s \"%s\" {\n   exec command=\"tmsh generate sys icall event %s\"\n}",
+		d.Get("name").(string), d.Get("match").(string), d.Get("event_name").(string))
+}
+
+func icallEventTriggerMarker(name string) string {
+	return "# bigip_sys_icall_event_trigger " + name
+}
+
+// icallFindEventTrigger returns the start and end offsets in conf of the
+// marker and rule of the named event trigger, and the rule.
+func icallFindEventTrigger(conf, name string) (int, string, int) {
+	marker := icallEventTriggerMarker(name) + "\n"
+	start := strings.Index(conf, marker)
+	if start < 0 || (start > 0 && conf[start-1] != '\n') {
+		return -1, "", -1
+	}
+	rule := start + len(marker)
+	end := strings.Index(conf[rule:], "\n}")
+	if end < 0 {
+		return -1, "", -1
+	}
+	end = rule + end + len("\n}")
+	return start, conf[rule:end], end
+}
+
+// icallSetEventTrigger replaces the rule of the named event trigger in
+// user_alert.conf with block, or removes it for an empty block, and restarts
+// alertd for it to load the rules.
+func icallSetEventTrigger(client *bigip.BigIP, name, block string) error {
+	icallUserAlertMutex.Lock()
+	defer icallUserAlertMutex.Unlock()
+	conf, err := icallUserAlerts(client)
+	if err != nil {
+		return err
+	}
+	if start, _, end := icallFindEventTrigger(conf, name); start >= 0 {
+		conf = conf[:start] + strings.TrimPrefix(conf[end:], "\n")
+	}
+	if block != "" {
+		if conf != "" && !strings.HasSuffix(conf, "\n") {
+			conf += "\n"
+		}
+		conf += icallEventTriggerMarker(name) + "\n" + block + "\n"
+	}
+	_, err = client.RunCommand(&bigip.BigipCommand{
+		Command:     "run",
+		UtilCmdArgs: fmt.Sprintf("-c 'echo %s | base64 -d > %s && bigstart restart alertd'", base64.StdEncoding.EncodeToString([]byte(conf)), icallUserAlertConf),
+	})
+	return err
+}
+
+func icallUserAlerts(client *bigip.BigIP) (string, error) {
+	out, err := client.RunCommand(&bigip.BigipCommand{
+		Command:     "run",
+		UtilCmdArgs: fmt.Sprintf("-c 'cat %s'", icallUserAlertConf),
+	})
+	if err != nil {
+		return "", err
+	}
+	return out.CommandResult, nil
+}
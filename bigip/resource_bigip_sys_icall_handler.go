@@ -0,0 +1,258 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var icallHandlerTypes = []string{"periodic", "triggered", "perpetual"}
+
+func resourceBigipSysIcallHandler() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysIcallHandlerCreate,
+		ReadContext:   resourceBigipSysIcallHandlerRead,
+		UpdateContext: resourceBigipSysIcallHandlerUpdate,
+		DeleteContext: resourceBigipSysIcallHandlerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceBigipSysIcallHandlerCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Name of the iCall handler, in the pattern /partition/name",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(icallHandlerTypes, false),
+				Description:  "Type of the handler: periodic runs the script every interval, triggered runs it on the events it subscribes to and perpetual keeps it running",
+			},
+			"script": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full path of the iCall script run by the handler",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description",
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validation.StringInSlice([]string{"active", "inactive"}, false),
+				Description:  "Starts the handler when active, stops it when inactive",
+			},
+			"arguments": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arguments passed to the script",
+			},
+			"interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Seconds between two runs of a periodic handler",
+			},
+			"first_occurrence": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Time of the first run of a periodic handler, in the format YYYY-MM-DD:HH:MM:SS",
+			},
+			"last_occurrence": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Time after which a periodic handler stops running, in the format YYYY-MM-DD:HH:MM:SS",
+			},
+			"subscription": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Events a triggered handler runs the script on",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the subscription",
+						},
+						"event_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the iCall event, e.g. the event_name of a bigip_sys_icall_event_trigger",
+						},
+						"filters": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Values the context of the event must match",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBigipSysIcallHandlerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	kind := d.Get("type").(string)
+	log.Printf("[INFO] Creating %s iCall handler %s", kind, name)
+	handler := getIcallHandlerConfig(d)
+	handler.Name = name
+	if err := client.CreateIcallHandler(kind, handler); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating iCall handler %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSysIcallHandlerRead(ctx, d, meta)
+}
+
+func resourceBigipSysIcallHandlerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	kinds := icallHandlerTypes
+	if kind := d.Get("type").(string); kind != "" {
+		kinds = []string{kind}
+	}
+	// an imported handler can be of any type
+	for _, kind := range kinds {
+		log.Printf("[INFO] Reading %s iCall handler %s", kind, name)
+		handler, err := client.GetIcallHandler(kind, name)
+		if err != nil && strings.Contains(err.Error(), "not found") {
+			continue
+		}
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving iCall handler %s: %v", name, err))
+		}
+		_ = d.Set("name", name)
+		_ = d.Set("type", kind)
+		_ = d.Set("script", handler.Script)
+		_ = d.Set("description", handler.Description)
+		_ = d.Set("status", handler.Status)
+		arguments := make(map[string]interface{}, len(handler.Arguments))
+		for _, argument := range handler.Arguments {
+			arguments[argument.Name] = argument.Value
+		}
+		_ = d.Set("arguments", arguments)
+		_ = d.Set("interval", handler.Interval)
+		_ = d.Set("first_occurrence", handler.FirstOccurrence)
+		_ = d.Set("last_occurrence", handler.LastOccurrence)
+		var subscriptions []interface{}
+		for _, subscription := range handler.Subscriptions {
+			filters := make(map[string]interface{}, len(subscription.Filters))
+			for _, filter := range subscription.Filters {
+				filters[filter.Name] = filter.MatchValue
+			}
+			subscriptions = append(subscriptions, map[string]interface{}{
+				"name":       subscription.Name,
+				"event_name": subscription.EventName,
+				"filters":    filters,
+			})
+		}
+		_ = d.Set("subscription", subscriptions)
+		return nil
+	}
+	log.Printf("[WARN] iCall handler %s not found, removing from state", name)
+	d.SetId("")
+	return nil
+}
+
+func resourceBigipSysIcallHandlerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	kind := d.Get("type").(string)
+	log.Printf("[INFO] Updating %s iCall handler %s", kind, name)
+	if err := client.ModifyIcallHandler(kind, name, getIcallHandlerConfig(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating iCall handler %s: %v", name, err))
+	}
+	return resourceBigipSysIcallHandlerRead(ctx, d, meta)
+}
+
+func resourceBigipSysIcallHandlerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting iCall handler %s", name)
+	if err := client.DeleteIcallHandler(d.Get("type").(string), name); err != nil && !strings.Contains(err.Error(), "not found") {
+		return diag.FromErr(fmt.Errorf("error deleting iCall handler %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipSysIcallHandlerCustomizeDiff checks the arguments of the type of the handler are set,
+// and only those.
+func resourceBigipSysIcallHandlerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	kind := d.Get("type").(string)
+	_, interval := d.GetOk("interval")
+	_, subscriptions := d.GetOk("subscription")
+	// first_occurrence and last_occurrence are computed, only the configuration tells if they are set
+	periodic := false
+	if raw := d.GetRawConfig(); !raw.IsNull() && raw.IsKnown() {
+		periodic = !raw.GetAttr("first_occurrence").IsNull() || !raw.GetAttr("last_occurrence").IsNull()
+	}
+	switch {
+	case kind == "periodic" && !interval:
+		return fmt.Errorf("interval is required for a periodic handler")
+	case kind != "periodic" && (interval || periodic):
+		return fmt.Errorf("interval, first_occurrence and last_occurrence only apply to a periodic handler")
+	case kind == "triggered" && !subscriptions:
+		return fmt.Errorf("a triggered handler needs at least one subscription")
+	case kind != "triggered" && subscriptions:
+		return fmt.Errorf("subscription only applies to a triggered handler")
+	}
+	return nil
+}
+
+func getIcallHandlerConfig(d *schema.ResourceData) *bigip.IcallHandler {
+	handler := &bigip.IcallHandler{
+		Script:          d.Get("script").(string),
+		Description:     d.Get("description").(string),
+		Status:          d.Get("status").(string),
+		Interval:        d.Get("interval").(int),
+		FirstOccurrence: d.Get("first_occurrence").(string),
+		LastOccurrence:  d.Get("last_occurrence").(string),
+	}
+	handler.Arguments = icallArguments(d.Get("arguments").(map[string]interface{}))
+	for _, s := range d.Get("subscription").([]interface{}) {
+		s := s.(map[string]interface{})
+		subscription := bigip.IcallSubscription{Name: s["name"].(string), EventName: s["event_name"].(string)}
+		for _, argument := range icallArguments(s["filters"].(map[string]interface{})) {
+			subscription.Filters = append(subscription.Filters, bigip.IcallFilter{Name: argument.Name, MatchValue: argument.Value})
+		}
+		handler.Subscriptions = append(handler.Subscriptions, subscription)
+	}
+	return handler
+}
+
+// icallArguments returns the names and values of m sorted by name.
+func icallArguments(m map[string]interface{}) []bigip.IcallArgument {
+	var arguments []bigip.IcallArgument
+	for name, value := range m {
+		arguments = append(arguments, bigip.IcallArgument{Name: name, Value: value.(string)})
+	}
+	sort.Slice(arguments, func(i, j int) bool { return arguments[i].Name < arguments[j].Name })
+	return arguments
+}
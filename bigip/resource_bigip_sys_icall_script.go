@@ -0,0 +1,112 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBigipSysIcallScript() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysIcallScriptCreate,
+		ReadContext:   resourceBigipSysIcallScriptRead,
+		UpdateContext: resourceBigipSysIcallScriptUpdate,
+		DeleteContext: resourceBigipSysIcallScriptDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateF5NameWithDirectory,
+				Description:  "Name of the iCall script, in the pattern /partition/name",
+			},
+			"definition": {
+				Type:     schema.TypeString,
+				Required: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimSpace(old) == strings.TrimSpace(new)
+				},
+				Description: "TMSH script run by the handlers of the script",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description",
+			},
+		},
+	}
+}
+
+func resourceBigipSysIcallScriptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating iCall script %s", name)
+	script := &bigip.IcallScript{
+		Name:        name,
+		Definition:  d.Get("definition").(string),
+		Description: d.Get("description").(string),
+	}
+	if err := client.CreateIcallScript(script); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating iCall script %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSysIcallScriptRead(ctx, d, meta)
+}
+
+func resourceBigipSysIcallScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading iCall script %s", name)
+	script, err := client.GetIcallScript(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] iCall script %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving iCall script %s: %v", name, err))
+	}
+	_ = d.Set("name", name)
+	_ = d.Set("definition", script.Definition)
+	_ = d.Set("description", script.Description)
+	return nil
+}
+
+func resourceBigipSysIcallScriptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Updating iCall script %s", name)
+	script := &bigip.IcallScript{
+		Definition:  d.Get("definition").(string),
+		Description: d.Get("description").(string),
+	}
+	if err := client.ModifyIcallScript(name, script); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating iCall script %s: %v", name, err))
+	}
+	return resourceBigipSysIcallScriptRead(ctx, d, meta)
+}
+
+func resourceBigipSysIcallScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting iCall script %s", name)
+	if err := client.DeleteIcallScript(name); err != nil && !strings.Contains(err.Error(), "not found") {
+		return diag.FromErr(fmt.Errorf("error deleting iCall script %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipSysIcallConfig = `
+resource "bigip_sys_icall_script" "test" {
+  name       = "/Common/tf-acc-icall"
+  definition = "tmsh::log \"tf-acc-icall ran\""
+}
+
+resource "bigip_sys_icall_handler" "periodic" {
+  name     = "/Common/tf-acc-icall-periodic"
+  type     = "periodic"
+  script   = bigip_sys_icall_script.test.name
+  interval = 3600
+  status   = "%s"
+}
+
+resource "bigip_sys_icall_event_trigger" "test" {
+  name       = "tf_acc_icall"
+  match      = "tf-acc-icall-event"
+  event_name = "tf_acc_icall"
+}
+
+resource "bigip_sys_icall_handler" "triggered" {
+  name   = "/Common/tf-acc-icall-triggered"
+  type   = "triggered"
+  script = bigip_sys_icall_script.test.name
+  subscription {
+    name       = "tf_acc_icall"
+    event_name = bigip_sys_icall_event_trigger.test.event_name
+  }
+}
+`
+
+func TestAccBigipSysIcallCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSysIcallDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBigipSysIcallConfig, "active"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_icall_handler.periodic", "status", "active"),
+					resource.TestCheckResourceAttr("bigip_sys_icall_handler.triggered", "subscription.0.event_name", "tf_acc_icall"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccBigipSysIcallConfig, "inactive"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_icall_handler.periodic", "status", "inactive"),
+				),
+			},
+			{
+				ResourceName:      "bigip_sys_icall_handler.periodic",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckSysIcallDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "bigip_sys_icall_script":
+			if _, err := client.GetIcallScript(rs.Primary.ID); err == nil {
+				return fmt.Errorf("iCall script %s not destroyed", rs.Primary.ID)
+			}
+		case "bigip_sys_icall_handler":
+			if _, err := client.GetIcallHandler(rs.Primary.Attributes["type"], rs.Primary.ID); err == nil {
+				return fmt.Errorf("iCall handler %s not destroyed", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for the iCall resources against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeIcallServer keeps iCall scripts and handlers by path, e.g. "script/~Common~save_ucs"
+// or "handler/periodic/~Common~save_ucs", and the content of user_alert.conf.
+type fakeIcallServer struct {
+	*httptest.Server
+	mu             sync.Mutex
+	objects        map[string]map[string]interface{}
+	userAlerts     string
+	alertdRestarts int
+	requests       []string
+}
+
+var fakeIcallWrite = regexp.MustCompile(`^-c 'echo (\S+) \| base64 -d > /config/user_alert.conf && bigstart restart alertd'$`)
+
+func newFakeIcallServer(t *testing.T) *fakeIcallServer {
+	f := &fakeIcallServer{
+		objects:    make(map[string]map[string]interface{}),
+		userAlerts: "# user_alert.conf\nalert CUSTOM_ALERT \"something\" {\n   snmptrap OID=\".1.3.6.1.4.1.3375.2.4.0.300\"\n}\n",
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/sys/icall/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		path := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/icall/")
+		f.requests = append(f.requests, r.Method+" "+path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			if _, ok := body["status"]; !ok && strings.HasPrefix(path, "handler/") {
+				body["status"] = "active"
+			}
+			f.objects[path+"/"+strings.ReplaceAll(body["name"].(string), "/", "~")] = body
+			_ = json.NewEncoder(w).Encode(body)
+			return
+		}
+		object, ok := f.objects[path]
+		if !ok {
+			fakeBigipNotFound(w, path)
+			return
+		}
+		switch r.Method {
+		case "PUT":
+			body["name"] = object["name"]
+			if _, ok := body["status"]; !ok && strings.HasPrefix(path, "handler/") {
+				body["status"] = "active"
+			}
+			f.objects[path] = body
+			object = body
+		case "DELETE":
+			delete(f.objects, path)
+		}
+		_ = json.NewEncoder(w).Encode(object)
+	})
+	mux.HandleFunc("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		args := body["utilCmdArgs"]
+		w.Header().Set("Content-Type", "application/json")
+		result := ""
+		switch {
+		case args == "-c 'cat /config/user_alert.conf'":
+			result = f.userAlerts
+		case fakeIcallWrite.MatchString(args):
+			conf, err := base64.StdEncoding.DecodeString(fakeIcallWrite.FindStringSubmatch(args)[1])
+			if err != nil {
+				t.Errorf("bad base64 in %s: %v", args, err)
+			}
+			f.userAlerts = string(conf)
+			f.alertdRestarts++
+		default:
+			t.Errorf("unexpected command %s", args)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"command": "run", "utilCmdArgs": args, "commandResult": result})
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestResourceBigipSysIcallScript(t *testing.T) {
+	f := newFakeIcallServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipSysIcallScript()
+
+	config := map[string]interface{}{
+		"name":       "/Common/save_ucs",
+		"definition": "tmsh::save sys ucs /var/local/ucs/daily.ucs\n",
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, "/Common/save_ucs", d.Id())
+	assert.Equal(t, "tmsh::save sys ucs /var/local/ucs/daily.ucs\n", f.objects["script/~Common~save_ucs"]["definition"])
+
+	config["definition"] = "tmsh::save sys ucs /var/local/ucs/[clock format [clock seconds] -format %a].ucs"
+	config["description"] = "daily UCS"
+	d = testResourceApply(t, r, d.State(), config, client)
+	assert.Equal(t, config["definition"], f.objects["script/~Common~save_ucs"]["definition"])
+	assert.Equal(t, "daily UCS", d.Get("description"))
+
+	if diags := resourceBigipSysIcallScriptDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.objects)
+}
+
+func TestResourceBigipSysIcallHandlerPeriodic(t *testing.T) {
+	f := newFakeIcallServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipSysIcallHandler()
+
+	config := map[string]interface{}{
+		"name":             "/Common/save_ucs",
+		"type":             "periodic",
+		"script":           "/Common/save_ucs",
+		"interval":         86400,
+		"first_occurrence": "2026-01-01:02:00:00",
+		"arguments":        map[string]interface{}{"keep": "7", "dir": "/var/local/ucs"},
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, "/Common/save_ucs", d.Id())
+	handler := f.objects["handler/periodic/~Common~save_ucs"]
+	assert.Equal(t, float64(86400), handler["interval"])
+	assert.Equal(t, "active", handler["status"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "dir", "value": "/var/local/ucs"},
+		map[string]interface{}{"name": "keep", "value": "7"},
+	}, handler["arguments"])
+
+	// stop the handler
+	config["status"] = "inactive"
+	d = testResourceApply(t, r, d.State(), config, client)
+	assert.Contains(t, f.requests, "PUT handler/periodic/~Common~save_ucs")
+	handler = f.objects["handler/periodic/~Common~save_ucs"]
+	assert.Equal(t, "inactive", handler["status"])
+	assert.Equal(t, float64(86400), handler["interval"], "the other settings are kept")
+	assert.Equal(t, "inactive", d.Get("status"))
+
+	// import finds the type of the handler
+	imported := r.Data(&terraform.InstanceState{ID: "/Common/save_ucs"})
+	if diags := resourceBigipSysIcallHandlerRead(context.Background(), imported, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "periodic", imported.Get("type"))
+	assert.Equal(t, "2026-01-01:02:00:00", imported.Get("first_occurrence"))
+	assert.Equal(t, "7", imported.Get("arguments.keep"))
+
+	if diags := resourceBigipSysIcallHandlerDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.objects)
+	if diags := resourceBigipSysIcallHandlerRead(context.Background(), imported, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", imported.Id())
+}
+
+func TestResourceBigipSysIcallHandlerTriggered(t *testing.T) {
+	f := newFakeIcallServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipSysIcallHandler()
+
+	config := map[string]interface{}{
+		"name":   "/Common/report_member_down",
+		"type":   "triggered",
+		"script": "/Common/report_member_down",
+		"subscription": []interface{}{map[string]interface{}{
+			"name":       "member_down",
+			"event_name": "member_down",
+			"filters":    map[string]interface{}{"pool": "/Common/web_pool"},
+		}},
+	}
+	d := testResourceApply(t, r, nil, config, client)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name":      "member_down",
+		"eventName": "member_down",
+		"filters":   []interface{}{map[string]interface{}{"name": "pool", "matchValue": "/Common/web_pool"}},
+	}}, f.objects["handler/triggered/~Common~report_member_down"]["subscriptions"])
+	assert.Equal(t, "/Common/web_pool", d.Get("subscription.0.filters.pool"))
+
+	perpetual := testResourceApply(t, r, nil, map[string]interface{}{
+		"name":   "/Common/watch",
+		"type":   "perpetual",
+		"script": "/Common/watch",
+	}, client)
+	assert.Equal(t, "perpetual", perpetual.Get("type"))
+	assert.Contains(t, f.objects, "handler/perpetual/~Common~watch")
+}
+
+func TestResourceBigipSysIcallHandlerValidation(t *testing.T) {
+	r := resourceBigipSysIcallHandler()
+	for _, tc := range []struct {
+		config map[string]interface{}
+		err    string
+	}{
+		{map[string]interface{}{"type": "periodic"}, "interval is required for a periodic handler"},
+		{map[string]interface{}{"type": "perpetual", "interval": 60}, "interval, first_occurrence and last_occurrence only apply to a periodic handler"},
+		{map[string]interface{}{"type": "triggered"}, "a triggered handler needs at least one subscription"},
+		{map[string]interface{}{"type": "periodic", "interval": 60, "subscription": []interface{}{map[string]interface{}{"name": "s", "event_name": "e"}}}, "subscription only applies to a triggered handler"},
+	} {
+		tc.config["name"] = "/Common/test"
+		tc.config["script"] = "/Common/test"
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.config), nil)
+		if assert.Error(t, err, fmt.Sprint(tc.config)) {
+			assert.Contains(t, err.Error(), tc.err)
+		}
+	}
+}
+
+func TestResourceBigipSysIcallEventTrigger(t *testing.T) {
+	f := newFakeIcallServer(t)
+	client := testFakeBigipClient(f.Server)
+	r := resourceBigipSysIcallEventTrigger()
+	original := f.userAlerts
+
+	down := testResourceApply(t, r, nil, map[string]interface{}{
+		"name":       "member_down",
+		"match":      "Pool /Common/web_pool member .* monitor status down",
+		"event_name": "member_down",
+	}, client)
+	up := testResourceApply(t, r, nil, map[string]interface{}{
+		"name":       "member_up",
+		"match":      "Pool /Common/web_pool member .* monitor status up",
+		"event_name": "member_up",
+	}, client)
+	assert.Equal(t, "member_down", down.Id())
+	assert.Equal(t, original+
+		"# bigip_sys_icall_event_trigger member_down\n"+
+		"alert member_down \"Pool /Common/web_pool member .* monitor status down\" {\n   exec command=\"tmsh generate sys icall event member_down\"\n}\n"+
+		"# bigip_sys_icall_event_trigger member_up\n"+
+		"alert member_up \"Pool /Common/web_pool member .* monitor status up\" {\n   exec command=\"tmsh generate sys icall event member_up\"\n}\n",
+		f.userAlerts)
+	assert.Equal(t, 2, f.alertdRestarts)
+
+	down = testResourceApply(t, r, down.State(), map[string]interface{}{
+		"name":       "member_down",
+		"match":      "Pool /Common/web_pool member .* monitor status (down|forced down)",
+		"event_name": "member_down",
+	}, client)
+	assert.Equal(t, "Pool /Common/web_pool member .* monitor status (down|forced down)", down.Get("match"))
+	assert.Contains(t, f.userAlerts, "monitor status up\"", "the other triggers are kept")
+	assert.Equal(t, 1, strings.Count(f.userAlerts, "alert member_down "))
+
+	// a rule removed by hand is recreated
+	if diags := resourceBigipSysIcallEventTriggerDelete(context.Background(), up, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	up = r.Data(&terraform.InstanceState{ID: "member_up"})
+	if diags := resourceBigipSysIcallEventTriggerRead(context.Background(), up, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", up.Id())
+
+	if diags := resourceBigipSysIcallEventTriggerDelete(context.Background(), down, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, original, f.userAlerts, "user_alert.conf is back to what it was")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipSysManagementIp() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysManagementIpCreate,
+		ReadContext:   resourceBigipSysManagementIpRead,
+		UpdateContext: resourceBigipSysManagementIpUpdate,
+		DeleteContext: resourceBigipSysManagementIpDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Address of the management interface with its prefix length, e.g. 192.0.2.10/24",
+				ValidateFunc: validation.IsCIDR,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the address",
+			},
+		},
+	}
+}
+
+func resourceBigipSysManagementIpCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	address := d.Get("address").(string)
+	log.Printf("[INFO] Creating management address %s", address)
+	config := &bigip.ManagementIp{
+		Name:        address,
+		Description: d.Get("description").(string),
+	}
+	if err := client.CreateManagementIp(config); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating management address %s: %v", address, err))
+	}
+	d.SetId(address)
+	return resourceBigipSysManagementIpRead(ctx, d, meta)
+}
+
+func resourceBigipSysManagementIpRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	address := d.Id()
+	log.Printf("[INFO] Reading management address %s", address)
+	ips, err := client.ManagementIps()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving management addresses: %v", err))
+	}
+	for _, ip := range ips {
+		if ip.Name == address {
+			_ = d.Set("address", ip.Name)
+			_ = d.Set("description", ip.Description)
+			return nil
+		}
+	}
+	log.Printf("[WARN] Management address %s not found, removing from state", address)
+	d.SetId("")
+	return nil
+}
+
+func resourceBigipSysManagementIpUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	address := d.Id()
+	log.Printf("[INFO] Updating management address %s", address)
+	if err := client.ModifyManagementIp(address, d.Get("description").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating management address %s: %v", address, err))
+	}
+	return resourceBigipSysManagementIpRead(ctx, d, meta)
+}
+
+func resourceBigipSysManagementIpDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	address := d.Id()
+	log.Printf("[INFO] Deleting management address %s", address)
+	if err := client.DeleteManagementIp(address); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting management address %s: %v", address, err))
+	}
+	d.SetId("")
+	return nil
+}
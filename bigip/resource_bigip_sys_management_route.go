@@ -0,0 +1,129 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigipSysManagementRoute() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysManagementRouteCreate,
+		ReadContext:   resourceBigipSysManagementRouteRead,
+		UpdateContext: resourceBigipSysManagementRouteUpdate,
+		DeleteContext: resourceBigipSysManagementRouteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+				Description:  "Name of the management route, e.g. /Common/mgmt-10",
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Destination network of the route in CIDR notation, or default",
+				ValidateFunc: validation.Any(validation.IsCIDR, validation.StringInSlice([]string{"default", "default-inet6"}, false)),
+			},
+			"gateway": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Gateway address of the route",
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "MTU of the route, 0 uses the MTU of the management interface",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the route",
+			},
+		},
+	}
+}
+
+func resourceBigipSysManagementRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Creating management route %s", name)
+	config := &bigip.ManagementRoute{
+		Name:        name,
+		Network:     d.Get("network").(string),
+		Gateway:     d.Get("gateway").(string),
+		Mtu:         d.Get("mtu").(int),
+		Description: d.Get("description").(string),
+	}
+	if err := client.CreateManagementRoute(config); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating management route %s: %v", name, err))
+	}
+	d.SetId(name)
+	return resourceBigipSysManagementRouteRead(ctx, d, meta)
+}
+
+func resourceBigipSysManagementRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading management route %s", name)
+	route, err := client.GetManagementRoute(name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] Management route %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving management route %s: %v", name, err))
+	}
+	_ = d.Set("name", route.FullPath)
+	_ = d.Set("network", route.Network)
+	_ = d.Set("gateway", route.Gateway)
+	_ = d.Set("mtu", route.Mtu)
+	_ = d.Set("description", route.Description)
+	return nil
+}
+
+func resourceBigipSysManagementRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Updating management route %s", name)
+	config := map[string]interface{}{
+		"gateway":     d.Get("gateway").(string),
+		"mtu":         d.Get("mtu").(int),
+		"description": d.Get("description").(string),
+	}
+	if err := client.ModifyManagementRoute(name, config); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating management route %s: %v", name, err))
+	}
+	return resourceBigipSysManagementRouteRead(ctx, d, meta)
+}
+
+func resourceBigipSysManagementRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting management route %s", name)
+	if err := client.DeleteManagementRoute(name); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting management route %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
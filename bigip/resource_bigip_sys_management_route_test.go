@@ -0,0 +1,68 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccBigipSysManagementRouteConfig = `
+resource "bigip_sys_management_route" "test" {
+  name        = "/Common/tf-acc-mgmt-route"
+  network     = "198.51.100.0/24"
+  gateway     = "%s"
+  description = "tf-acc-test"
+}
+`
+
+// TestAccBigipSysManagementRouteCreate needs MGMT_GATEWAY, the gateway of the management network
+func TestAccBigipSysManagementRouteCreate(t *testing.T) {
+	gateway := os.Getenv("MGMT_GATEWAY")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+			if gateway == "" {
+				t.Skip("MGMT_GATEWAY must be set to run this test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSysManagementRouteDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBigipSysManagementRouteConfig, gateway),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_management_route.test", "network", "198.51.100.0/24"),
+					resource.TestCheckResourceAttr("bigip_sys_management_route.test", "gateway", gateway),
+				),
+			},
+			{
+				ResourceName:      "bigip_sys_management_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckSysManagementRouteDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_sys_management_route" {
+			continue
+		}
+		if _, err := client.GetManagementRoute(rs.Primary.ID); err == nil {
+			return fmt.Errorf("management route %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
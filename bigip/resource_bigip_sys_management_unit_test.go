@@ -0,0 +1,289 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_sys_management_route, bigip_sys_management_ip, bigip_sys_sshd and bigip_sys_httpd
+// against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeSysManagementServer stores management routes and addresses and the
+// sshd and httpd singletons, and records every request that changes them.
+type fakeSysManagementServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	routes   map[string]map[string]interface{}
+	ips      map[string]map[string]interface{}
+	sshd     map[string]interface{}
+	httpd    map[string]interface{}
+	requests []string
+	patches  []map[string]interface{}
+}
+
+func newFakeSysManagementServer(t *testing.T) *fakeSysManagementServer {
+	f := &fakeSysManagementServer{
+		routes: make(map[string]map[string]interface{}),
+		ips:    map[string]map[string]interface{}{"192.0.2.10/24": {"name": "192.0.2.10/24", "fullPath": "192.0.2.10/24"}},
+		sshd: map[string]interface{}{
+			"allow":             []interface{}{"ALL"},
+			"banner":            "disabled",
+			"inactivityTimeout": 0,
+			"include":           "MACs hmac-sha2-256\nCiphers aes128-ctr,aes256-ctr\n",
+			"logLevel":          "info",
+			"login":             "enabled",
+			"port":              22,
+		},
+		httpd: map[string]interface{}{
+			"allow":              []interface{}{"All"},
+			"authPamIdleTimeout": 1200,
+			"maxClients":         10,
+			"sslCiphersuite":     "DEFAULT",
+			"sslProtocol":        "all -SSLv2 -SSLv3",
+			"sslPort":            443,
+		},
+	}
+	record := func(r *http.Request) map[string]interface{} {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		if r.Method == "PATCH" {
+			f.patches = append(f.patches, body)
+		}
+		return body
+	}
+	notFound := func(w http.ResponseWriter, name string) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested object (%s) was not found."}`, name)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/sys/management-route", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		body := record(r)
+		name := body["name"].(string)
+		body["fullPath"] = name
+		f.routes[strings.ReplaceAll(name, "/", "~")] = body
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/management-route/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		body := record(r)
+		w.Header().Set("Content-Type", "application/json")
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/management-route/")
+		route, ok := f.routes[name]
+		if !ok {
+			notFound(w, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			for k, v := range body {
+				route[k] = v
+			}
+		case "DELETE":
+			delete(f.routes, name)
+		}
+		_ = json.NewEncoder(w).Encode(route)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/management-ip", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			body := record(r)
+			f.ips[body["name"].(string)] = body
+			_ = json.NewEncoder(w).Encode(body)
+			return
+		}
+		var items []map[string]interface{}
+		for _, ip := range f.ips {
+			items = append(items, ip)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	})
+	mux.HandleFunc("/mgmt/tm/sys/management-ip/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		body := record(r)
+		w.Header().Set("Content-Type", "application/json")
+		name := strings.ReplaceAll(strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/management-ip/"), "~", "/")
+		ip, ok := f.ips[name]
+		if !ok {
+			notFound(w, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			ip["description"] = body["description"]
+		case "DELETE":
+			delete(f.ips, name)
+		}
+		_ = json.NewEncoder(w).Encode(ip)
+	})
+	singleton := func(path string, settings map[string]interface{}) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if r.Method == "PATCH" {
+				for k, v := range record(r) {
+					settings[k] = v
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(settings)
+		})
+	}
+	singleton("/mgmt/tm/sys/sshd", f.sshd)
+	singleton("/mgmt/tm/sys/httpd", f.httpd)
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestResourceBigipSysManagementRouteLifecycle(t *testing.T) {
+	f := newFakeSysManagementServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysManagementRoute().Schema, map[string]interface{}{
+		"name":    "/Common/mgmt-10",
+		"network": "10.0.0.0/8",
+		"gateway": "192.0.2.1",
+	})
+	if diags := resourceBigipSysManagementRouteCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "/Common/mgmt-10", d.Id())
+	assert.Equal(t, "192.0.2.1", d.Get("gateway"))
+
+	// drift on the device shows up on refresh
+	f.mu.Lock()
+	f.routes["~Common~mgmt-10"]["gateway"] = "192.0.2.254"
+	f.mu.Unlock()
+	if diags := resourceBigipSysManagementRouteRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "192.0.2.254", d.Get("gateway"))
+
+	if diags := resourceBigipSysManagementRouteDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.routes)
+
+	// a route removed outside Terraform is removed from state
+	d.SetId("/Common/mgmt-10")
+	if diags := resourceBigipSysManagementRouteRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysManagementIpLifecycle(t *testing.T) {
+	f := newFakeSysManagementServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysManagementIp().Schema, map[string]interface{}{
+		"address":     "192.0.2.11/24",
+		"description": "secondary",
+	})
+	if diags := resourceBigipSysManagementIpCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "192.0.2.11/24", d.Id())
+	assert.Equal(t, "secondary", d.Get("description"))
+
+	if diags := resourceBigipSysManagementIpDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Contains(t, f.requests, "DELETE /mgmt/tm/sys/management-ip/192.0.2.11~24")
+	assert.NotContains(t, f.ips, "192.0.2.11/24")
+}
+
+func TestResourceBigipSysSshdCiphers(t *testing.T) {
+	f := newFakeSysManagementServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysSshd().Schema, map[string]interface{}{
+		"allow":              []interface{}{"10.0.0.0/255.0.0.0", "192.0.2.0/255.255.255.0"},
+		"inactivity_timeout": 900,
+		"ciphers":            []interface{}{"aes256-gcm@openssh.com", "aes256-ctr"},
+	})
+	if diags := resourceBigipSysSshdCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "sshd", d.Id())
+	assert.Len(t, f.patches, 1)
+	assert.ElementsMatch(t, []interface{}{"10.0.0.0/255.0.0.0", "192.0.2.0/255.255.255.0"}, f.patches[0]["allow"])
+	assert.Equal(t, float64(900), f.patches[0]["inactivityTimeout"])
+	assert.Equal(t, "MACs hmac-sha2-256\nCiphers aes256-gcm@openssh.com,aes256-ctr", f.patches[0]["include"], "the rest of include is kept")
+	assert.NotContains(t, f.patches[0], "port", "unconfigured settings are left alone")
+
+	assert.Equal(t, []interface{}{"aes256-gcm@openssh.com", "aes256-ctr"}, d.Get("ciphers"))
+	assert.Equal(t, "MACs hmac-sha2-256", d.Get("include"))
+	assert.Equal(t, 22, d.Get("port"))
+	assert.Equal(t, 2, d.Get("allow").(*schema.Set).Len())
+
+	// drift on the device shows up on refresh
+	f.mu.Lock()
+	f.sshd["allow"] = []interface{}{"ALL"}
+	f.mu.Unlock()
+	if diags := resourceBigipSysSshdRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []interface{}{"ALL"}, d.Get("allow").(*schema.Set).List())
+
+	if diags := resourceBigipSysSshdDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Len(t, f.patches, 1, "destroy leaves the device unchanged")
+}
+
+func TestSplitSshdInclude(t *testing.T) {
+	include, ciphers := splitSshdInclude("")
+	assert.Equal(t, "", include)
+	assert.Nil(t, ciphers)
+	assert.Equal(t, "Ciphers aes256-ctr", joinSshdInclude("", []string{"aes256-ctr"}))
+	assert.Equal(t, "MaxAuthTries 3", joinSshdInclude("MaxAuthTries 3\n", nil))
+}
+
+func TestResourceBigipSysHttpdLifecycle(t *testing.T) {
+	f := newFakeSysManagementServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysHttpd().Schema, map[string]interface{}{
+		"allow":        []interface{}{"10.0.0.0/255.0.0.0"},
+		"ssl_protocol": "all -SSLv2 -SSLv3 -TLSv1 -TLSv1.1",
+	})
+	if diags := resourceBigipSysHttpdCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "httpd", d.Id())
+	assert.Equal(t, []map[string]interface{}{{"allow": []interface{}{"10.0.0.0/255.0.0.0"}, "sslProtocol": "all -SSLv2 -SSLv3 -TLSv1 -TLSv1.1"}}, f.patches)
+	assert.Equal(t, "DEFAULT", d.Get("ssl_ciphersuite"))
+	assert.Equal(t, 1200, d.Get("auth_pam_idle_timeout"))
+
+	// drift on the device shows up on refresh
+	f.mu.Lock()
+	f.httpd["sslProtocol"] = "all"
+	f.mu.Unlock()
+	if diags := resourceBigipSysHttpdRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "all", d.Get("ssl_protocol"))
+}
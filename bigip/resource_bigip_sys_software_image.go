@@ -0,0 +1,299 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// softwarePollInterval is how often the BIG-IP is polled while an image is registered or installed.
+var softwarePollInterval = 10 * time.Second
+
+// softwareImageUploadRetries is how many times an interrupted image upload is resumed.
+const softwareImageUploadRetries = 3
+
+// isoNameRegex matches a software image file name, e.g. BIGIP-17.1.1-0.0.2.iso
+var isoNameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+\.iso$`)
+
+func resourceBigipSysSoftwareImage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysSoftwareImageCreate,
+		ReadContext:   resourceBigipSysSoftwareImageRead,
+		UpdateContext: resourceBigipSysSoftwareImageUpdate,
+		DeleteContext: resourceBigipSysSoftwareImageDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBigipSysSoftwareImageImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"local_path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Path of the local ISO to upload, e.g. BIGIP-17.1.1-0.0.2.iso",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`\.iso$`), "must be the path of an ISO"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// imported images have no local_path, keep them if the name matches
+					return old == "" && filepath.Base(new) == d.Get("name").(string)
+				},
+			},
+			"md5_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Path of the local MD5 file of the ISO, defaults to local_path with an .md5 suffix",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "" && d.Get("local_path").(string) == ""
+				},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "File name of the image in /shared/images, defaults to the file name of local_path",
+				ValidateFunc: validation.StringMatch(isoNameRegex, "must be a file name of letters, digits, '.', '_' and '-' ending in .iso"),
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				Description:  "Minutes to wait for the BIG-IP to register the image once it is uploaded",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "MD5 checksum of the image on the BIG-IP",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Software version of the image",
+			},
+			"build": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Software build of the image",
+			},
+			"product": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Product of the image, e.g. BIG-IP",
+			},
+			"file_size": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Size of the image as reported by the BIG-IP, e.g. 2568 MB",
+			},
+			"verified": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the BIG-IP verified the image against its MD5 file",
+			},
+		},
+	}
+}
+
+func resourceBigipSysSoftwareImageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	localPath := d.Get("local_path").(string)
+	md5Path := d.Get("md5_path").(string)
+	if md5Path == "" {
+		md5Path = localPath + ".md5"
+	}
+	name := d.Get("name").(string)
+	if name == "" {
+		name = filepath.Base(localPath)
+	}
+	if !isoNameRegex.MatchString(name) {
+		return diag.FromErr(fmt.Errorf("software image name %s must be made of letters, digits, '.', '_' and '-', set name to upload %s", name, localPath))
+	}
+
+	md5File, err := os.ReadFile(md5Path)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading MD5 file of software image %s: %v", name, err))
+	}
+	fields := strings.Fields(string(md5File))
+	if len(fields) == 0 {
+		return diag.FromErr(fmt.Errorf("error reading MD5 file of software image %s: %s is empty", name, md5Path))
+	}
+	expected := strings.ToLower(fields[0])
+	checksum, err := fileMd5(localPath)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading software image %s: %v", name, err))
+	}
+	if checksum != expected {
+		return diag.FromErr(fmt.Errorf("software image %s has checksum %s, which does not match %s in %s", localPath, checksum, expected, md5Path))
+	}
+
+	image, err := client.GetSoftwareImage(name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving software image %s: %v", name, err))
+	}
+	if image != nil {
+		if remote, err := client.SoftwareImageChecksum(name); err == nil && remote == checksum {
+			log.Printf("[INFO] Software image %s is already on the BIG-IP", name)
+			d.SetId(name)
+			_ = d.Set("checksum", checksum)
+			return resourceBigipSysSoftwareImageRead(ctx, d, meta)
+		}
+	}
+
+	log.Printf("[INFO] Uploading software image %s", name)
+	if err := uploadSoftwareImage(client, name, localPath); err != nil {
+		return diag.FromErr(fmt.Errorf("error uploading software image %s: %v", name, err))
+	}
+	if _, err := client.UploadSoftwareImage(bytes.NewReader(md5File), 0, int64(len(md5File)), name+".md5"); err != nil {
+		return diag.FromErr(fmt.Errorf("error uploading MD5 file of software image %s: %v", name, err))
+	}
+	remote, err := client.SoftwareImageChecksum(name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving checksum of software image %s: %v", name, err))
+	}
+	if remote != checksum {
+		return diag.FromErr(fmt.Errorf("error uploading software image %s: checksum %s on the BIG-IP does not match %s", name, remote, checksum))
+	}
+
+	// the BIG-IP lists the image once it has read its metadata
+	timeout := time.Duration(d.Get("timeout").(int)) * time.Minute
+	deadline := time.Now().Add(timeout)
+	for {
+		image, err := client.GetSoftwareImage(name)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving software image %s: %v", name, err))
+		}
+		if image != nil {
+			break
+		}
+		if time.Now().Add(softwarePollInterval).After(deadline) {
+			return diag.FromErr(fmt.Errorf("timed out after %s waiting for the BIG-IP to register software image %s", timeout, name))
+		}
+		time.Sleep(softwarePollInterval)
+	}
+	d.SetId(name)
+	_ = d.Set("checksum", checksum)
+	return resourceBigipSysSoftwareImageRead(ctx, d, meta)
+}
+
+func resourceBigipSysSoftwareImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading software image %s", name)
+	image, err := client.GetSoftwareImage(name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving software image %s: %v", name, err))
+	}
+	if image == nil {
+		log.Printf("[WARN] Software image %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	checksum := d.Get("checksum").(string)
+	if checksum == "" {
+		if checksum, err = client.SoftwareImageChecksum(name); err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving checksum of software image %s: %v", name, err))
+		}
+	}
+	_ = d.Set("name", image.Name)
+	_ = d.Set("checksum", checksum)
+	_ = d.Set("version", image.Version)
+	_ = d.Set("build", image.Build)
+	_ = d.Set("product", image.Product)
+	_ = d.Set("file_size", image.FileSize)
+	_ = d.Set("verified", image.Verified == "yes")
+	return nil
+}
+
+func resourceBigipSysSoftwareImageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only timeout can change in place
+	return resourceBigipSysSoftwareImageRead(ctx, d, meta)
+}
+
+func resourceBigipSysSoftwareImageImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !isoNameRegex.MatchString(d.Id()) {
+		return nil, fmt.Errorf("expected the file name of a software image in /shared/images, e.g. BIGIP-17.1.1-0.0.2.iso, got %s", d.Id())
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceBigipSysSoftwareImageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Deleting software image %s", name)
+	if err := client.DeleteSoftwareImage(name); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting software image %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// uploadSoftwareImage uploads the ISO in chunks. An upload interrupted by an
+// earlier attempt or apply is resumed from the bytes already on the BIG-IP.
+func uploadSoftwareImage(client *bigip.BigIP, name, localPath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	for attempt := 1; ; attempt++ {
+		offset, err := client.SoftwareImageUploadOffset(name)
+		if err != nil {
+			return err
+		}
+		if offset >= size {
+			offset = 0
+		}
+		if offset > 0 {
+			log.Printf("[INFO] Resuming upload of software image %s at byte %d of %d", name, offset, size)
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		_, err = client.UploadSoftwareImage(f, offset, size, name)
+		if err == nil {
+			return nil
+		}
+		if attempt > softwareImageUploadRetries {
+			return err
+		}
+		log.Printf("[WARN] Upload of software image %s interrupted, resuming: %v", name, err)
+	}
+}
+
+func fileMd5(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := md5.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
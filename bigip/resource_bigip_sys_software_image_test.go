@@ -0,0 +1,92 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccBigipSysSoftwareImageCreate uploads the ISO named by SOFTWARE_IMAGE_PATH, next to its .md5 file,
+// and installs it to SOFTWARE_INSTALL_VOLUME when that is set. The BIG-IP is not rebooted.
+func TestAccBigipSysSoftwareImageCreate(t *testing.T) {
+	path := os.Getenv("SOFTWARE_IMAGE_PATH")
+	volume := os.Getenv("SOFTWARE_INSTALL_VOLUME")
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrSet("bigip_sys_software_image.test", "version"),
+		resource.TestCheckResourceAttrSet("bigip_sys_software_image.test", "checksum"),
+	}
+	if volume != "" {
+		checks = append(checks,
+			resource.TestCheckResourceAttr("bigip_sys_software_install.test", "status", "complete"),
+			resource.TestCheckResourceAttr("bigip_sys_software_install.test", "active", "false"),
+		)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+			if path == "" {
+				t.Skip("SOFTWARE_IMAGE_PATH must be set to run this test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSoftwareImageDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipSysSoftwareImageConfig(path, volume),
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
+
+func testAccBigipSysSoftwareImageConfig(path, volume string) string {
+	config := fmt.Sprintf(`
+resource "bigip_sys_software_image" "test" {
+  local_path = "%s"
+}
+`, path)
+	if volume != "" {
+		config += fmt.Sprintf(`
+resource "bigip_sys_software_install" "test" {
+  image  = bigip_sys_software_image.test.name
+  volume = "%s"
+}
+`, volume)
+	}
+	return config
+}
+
+func testCheckSoftwareImageDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "bigip_sys_software_image":
+			image, err := client.GetSoftwareImage(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if image != nil {
+				return fmt.Errorf("software image %s not destroyed", rs.Primary.ID)
+			}
+		case "bigip_sys_software_install":
+			volume, err := client.GetSoftwareVolume(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if volume != nil {
+				return fmt.Errorf("boot volume %s not destroyed", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// volumeNameRegex matches a boot volume name, e.g. HD1.2
+var volumeNameRegex = regexp.MustCompile(`^HD\d+\.\d+$`)
+
+func resourceBigipSysSoftwareInstall() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysSoftwareInstallCreate,
+		ReadContext:   resourceBigipSysSoftwareInstallRead,
+		UpdateContext: resourceBigipSysSoftwareInstallUpdate,
+		DeleteContext: resourceBigipSysSoftwareInstallDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"image": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "File name of the software image to install, e.g. BIGIP-17.1.1-0.0.2.iso",
+				ValidateFunc: validation.StringMatch(isoNameRegex, "must be a file name ending in .iso"),
+			},
+			"volume": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Boot volume to install the image to, e.g. HD1.2",
+				ValidateFunc: validation.StringMatch(volumeNameRegex, "must be a boot volume name such as HD1.2"),
+			},
+			"create_volume": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "Create the boot volume if it does not exist",
+			},
+			"reboot": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reboot the BIG-IP into the boot volume once the image is installed",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				Description:  "Minutes to wait for the install, and for the BIG-IP to become ready after a reboot",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Install status of the boot volume, e.g. complete",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Software version installed on the boot volume",
+			},
+			"build": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Software build installed on the boot volume",
+			},
+			"product": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Product installed on the boot volume",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the BIG-IP is booted from the boot volume",
+			},
+			"active_volume": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Boot volume the BIG-IP is booted from",
+			},
+		},
+	}
+}
+
+func resourceBigipSysSoftwareInstallCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	imageName := d.Get("image").(string)
+	volume := d.Get("volume").(string)
+	image, err := client.GetSoftwareImage(imageName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving software image %s: %v", imageName, err))
+	}
+	if image == nil {
+		return diag.FromErr(fmt.Errorf("software image %s not found on the BIG-IP", imageName))
+	}
+	log.Printf("[INFO] Installing software image %s to %s", imageName, volume)
+	if err := client.InstallSoftwareImage(imageName, volume, d.Get("create_volume").(bool)); err != nil {
+		return diag.FromErr(fmt.Errorf("error installing software image %s to %s: %v", imageName, volume, err))
+	}
+	d.SetId(volume)
+	timeout := time.Duration(d.Get("timeout").(int)) * time.Minute
+	if err := waitForSoftwareInstall(client, image, volume, timeout); err != nil {
+		return diag.FromErr(err)
+	}
+	if d.Get("reboot").(bool) {
+		if err := rebootToVolume(client, volume, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceBigipSysSoftwareInstallRead(ctx, d, meta)
+}
+
+func resourceBigipSysSoftwareInstallRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading boot volume %s", name)
+	volumes, err := client.SoftwareVolumes()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving boot volumes: %v", err))
+	}
+	var volume *bigip.SoftwareVolume
+	activeVolume := ""
+	for i := range volumes {
+		if volumes[i].Name == name {
+			volume = &volumes[i]
+		}
+		if volumes[i].Active {
+			activeVolume = volumes[i].Name
+		}
+	}
+	if volume == nil {
+		log.Printf("[WARN] Boot volume %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	imageName := d.Get("image").(string)
+	images, err := client.SoftwareImages()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving software images: %v", err))
+	}
+	for _, image := range images {
+		if imageName == "" && image.Version == volume.Version && image.Build == volume.Build {
+			// imported volumes are matched to the image they were installed from
+			imageName = image.Name
+		}
+		if image.Name == imageName && volume.Status == "complete" && (image.Version != volume.Version || image.Build != volume.Build) {
+			log.Printf("[WARN] Boot volume %s no longer has software image %s installed, removing from state", name, imageName)
+			d.SetId("")
+			return nil
+		}
+	}
+	_ = d.Set("image", imageName)
+	_ = d.Set("volume", volume.Name)
+	_ = d.Set("status", volume.Status)
+	_ = d.Set("version", volume.Version)
+	_ = d.Set("build", volume.Build)
+	_ = d.Set("product", volume.Product)
+	_ = d.Set("active", volume.Active)
+	_ = d.Set("active_volume", activeVolume)
+	return nil
+}
+
+func resourceBigipSysSoftwareInstallUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	if d.HasChange("reboot") && d.Get("reboot").(bool) && !d.Get("active").(bool) {
+		timeout := time.Duration(d.Get("timeout").(int)) * time.Minute
+		if err := rebootToVolume(client, d.Id(), timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceBigipSysSoftwareInstallRead(ctx, d, meta)
+}
+
+func resourceBigipSysSoftwareInstallDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	volume, err := client.GetSoftwareVolume(name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving boot volume %s: %v", name, err))
+	}
+	if volume != nil && volume.Active {
+		log.Printf("[WARN] Boot volume %s is active and cannot be deleted, removing it from state only", name)
+		d.SetId("")
+		return nil
+	}
+	if volume != nil {
+		log.Printf("[INFO] Deleting boot volume %s", name)
+		if err := client.DeleteSoftwareVolume(name); err != nil {
+			return diag.FromErr(fmt.Errorf("error deleting boot volume %s: %v", name, err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// waitForSoftwareInstall polls the boot volume until it reports the image installed.
+func waitForSoftwareInstall(client *bigip.BigIP, image *bigip.SoftwareImage, volume string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		v, err := client.GetSoftwareVolume(volume)
+		if err != nil {
+			return fmt.Errorf("error retrieving boot volume %s: %v", volume, err)
+		}
+		if v != nil {
+			if strings.HasPrefix(v.Status, "failed") {
+				return fmt.Errorf("error installing software image %s to %s: %s", image.Name, volume, v.Status)
+			}
+			// a reinstalled volume reports the previous install as complete until the install starts
+			if v.Status == "complete" && v.Version == image.Version && v.Build == image.Build {
+				return nil
+			}
+			log.Printf("[INFO] Installing software image %s to %s: %s", image.Name, volume, v.Status)
+		}
+		if time.Now().Add(softwarePollInterval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for software image %s to install to %s", timeout, image.Name, volume)
+		}
+		time.Sleep(softwarePollInterval)
+	}
+}
+
+// rebootToVolume reboots the BIG-IP into the boot volume and waits until it
+// is booted from the volume and ready.
+func rebootToVolume(client *bigip.BigIP, volume string, timeout time.Duration) error {
+	log.Printf("[INFO] Rebooting BIG-IP into boot volume %s", volume)
+	err := client.RebootToVolume(volume)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// the connection usually drops while the BIG-IP reboots
+		log.Printf("[WARN] Connection lost while rebooting into boot volume %s: %v", volume, err)
+	} else if err != nil {
+		return fmt.Errorf("error rebooting into boot volume %s: %v", volume, err)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		// the BIG-IP keeps answering for a while before it goes down
+		v, err := client.GetSoftwareVolume(volume)
+		if err == nil && v != nil && v.Active {
+			break
+		}
+		if time.Now().Add(softwarePollInterval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for the BIG-IP to boot from %s", timeout, volume)
+		}
+		log.Printf("[DEBUG] waiting for the BIG-IP to boot from %s (error: %v)", volume, err)
+		time.Sleep(softwarePollInterval)
+	}
+	if err := client.WaitForSysReady(time.Until(deadline), softwarePollInterval); err != nil {
+		return fmt.Errorf("error rebooting into boot volume %s: %v", volume, err)
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_sys_software_image and bigip_sys_software_install against a fake BIG-IP - no F5 BIG-IP connection required
+
+const testSoftwareImage = "BIGIP-17.1.1-0.0.2.iso"
+
+var testUploadRange = regexp.MustCompile(`^(\d+)-(\d+)/(\d+)$`)
+
+// fakeSoftwareServer stores uploaded images in /shared/images, keeps partial
+// uploads in /shared/images/tmp and installs images to boot volumes, which
+// report "installing" for the given number of polls.
+type fakeSoftwareServer struct {
+	*httptest.Server
+	mu           sync.Mutex
+	images       map[string][]byte
+	tmp          map[string][]byte
+	volumes      map[string]*bigip.SoftwareVolume
+	ranges       []string
+	failChunk    int
+	installs     []map[string]interface{}
+	installPolls int
+	failInstall  bool
+	reboots      []string
+	deleted      []string
+	scripts      [][]string
+}
+
+func newFakeSoftwareServer(t *testing.T) *fakeSoftwareServer {
+	f := &fakeSoftwareServer{
+		images: make(map[string][]byte),
+		tmp:    make(map[string][]byte),
+		volumes: map[string]*bigip.SoftwareVolume{
+			"HD1.1": {Name: "HD1.1", Active: true, Status: "complete", Product: "BIG-IP", Version: "16.1.4", Build: "0.0.47"},
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/cm/autodeploy/software-image-uploads/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/cm/autodeploy/software-image-uploads/")
+		f.ranges = append(f.ranges, r.Header.Get("Content-Range"))
+		if f.failChunk == len(f.ranges) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprint(w, "connection reset")
+			return
+		}
+		m := testUploadRange.FindStringSubmatch(r.Header.Get("Content-Range"))
+		start, _ := strconv.Atoi(m[1])
+		end, _ := strconv.Atoi(m[2])
+		size, _ := strconv.Atoi(m[3])
+		data, _ := io.ReadAll(r.Body)
+		if start != len(f.tmp[name]) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, "chunk at %d does not follow %d bytes", start, len(f.tmp[name]))
+			return
+		}
+		f.tmp[name] = append(f.tmp[name], data...)
+		if end+1 == size {
+			f.images[name] = f.tmp[name]
+			delete(f.tmp, name)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"remainingByteCount":%d,"totalByteCount":%d,"localFilePath":"/shared/images/%s"}`, size-end-1, size, name)
+	})
+	mux.HandleFunc("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request) {
+		var cmd bigip.BigipCommand
+		_ = json.NewDecoder(r.Body).Decode(&cmd)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		script := testBashScript(cmd.UtilCmdArgs)
+		f.scripts = append(f.scripts, script)
+		switch {
+		case len(script) > 3 && script[0] == "stat":
+			name := strings.TrimPrefix(script[3], "/shared/images/tmp/")
+			cmd.CommandResult = fmt.Sprintf("%d\n", len(f.tmp[name]))
+		case len(script) == 2 && script[0] == "md5sum":
+			name := strings.TrimPrefix(script[1], "/shared/images/")
+			sum := md5.Sum(f.images[name])
+			cmd.CommandResult = hex.EncodeToString(sum[:]) + "  /shared/images/" + name + "\n"
+		case len(script) == 3 && script[0] == "rm":
+			delete(f.images, strings.TrimPrefix(script[2], "/shared/images/"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(cmd)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/software/image", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			var cmd map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&cmd)
+			f.installs = append(f.installs, cmd)
+			volume := cmd["volume"].(string)
+			if _, ok := f.volumes[volume]; !ok {
+				f.volumes[volume] = &bigip.SoftwareVolume{Name: volume}
+			}
+			f.volumes[volume].Status = "installing 0.000 pct"
+			_, _ = fmt.Fprint(w, `{}`)
+			return
+		}
+		var items []bigip.SoftwareImage
+		for name, data := range f.images {
+			if strings.HasSuffix(name, ".iso") {
+				items = append(items, bigip.SoftwareImage{Name: name, Version: "17.1.1", Build: "0.0.2", Product: "BIG-IP", FileSize: fmt.Sprintf("%d MB", len(data)), Verified: "yes"})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	})
+	mux.HandleFunc("/mgmt/tm/sys/software/image/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/software/image/")
+		delete(f.images, name)
+		f.deleted = append(f.deleted, name)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/software/volume", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var items []bigip.SoftwareVolume
+		for _, v := range f.volumes {
+			if strings.HasPrefix(v.Status, "installing") {
+				if f.installPolls > 0 {
+					f.installPolls--
+					v.Status = "installing 50.000 pct"
+				} else if f.failInstall {
+					v.Status = "failed (Software install failed)"
+				} else {
+					v.Status, v.Product, v.Version, v.Build = "complete", "BIG-IP", "17.1.1", "0.0.2"
+				}
+			}
+			items = append(items, *v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	})
+	mux.HandleFunc("/mgmt/tm/sys/software/volume/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/software/volume/")
+		delete(f.volumes, name)
+		f.deleted = append(f.deleted, name)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/sys", func(w http.ResponseWriter, r *http.Request) {
+		var cmd map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&cmd)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.reboots = append(f.reboots, cmd["volume"])
+		for name, v := range f.volumes {
+			v.Active = name == cmd["volume"]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/ready", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"entries":{"https://localhost/mgmt/tm/sys/ready/0":{"nestedStats":{"entries":{"configReady":{"description":"yes"},"licenseReady":{"description":"yes"},"provisionReady":{"description":"yes"}}}}}}`)
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// writeTestIso writes a 1.25 MB ISO, uploaded in three chunks, and its MD5 file.
+func writeTestIso(t *testing.T) (string, []byte) {
+	data := bytes.Repeat([]byte("iso-"), 1310720/4)
+	path := filepath.Join(t.TempDir(), testSoftwareImage)
+	assert.NoError(t, os.WriteFile(path, data, 0600))
+	sum := md5.Sum(data)
+	assert.NoError(t, os.WriteFile(path+".md5", []byte(hex.EncodeToString(sum[:])+"  "+testSoftwareImage+"\n"), 0600))
+	return path, data
+}
+
+func TestResourceBigipSysSoftwareImageUploadResumes(t *testing.T) {
+	withFastPolling(t, &softwarePollInterval)
+	f := newFakeSoftwareServer(t)
+	path, data := writeTestIso(t)
+	// the first chunk is left over from an interrupted apply
+	f.tmp[testSoftwareImage] = append([]byte{}, data[:524288]...)
+	// the upload is interrupted again on its second request
+	f.failChunk = 2
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysSoftwareImage().Schema, map[string]interface{}{"local_path": path})
+	if diags := resourceBigipSysSoftwareImageCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, testSoftwareImage, d.Id())
+	assert.Equal(t, []string{
+		"524288-1048575/1310720",
+		"1048576-1310719/1310720",
+		"1048576-1310719/1310720",
+		"0-56/57",
+	}, f.ranges)
+	assert.Equal(t, data, f.images[testSoftwareImage])
+	assert.Contains(t, f.images, testSoftwareImage+".md5")
+	sum := md5.Sum(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), d.Get("checksum"))
+	assert.Equal(t, testSoftwareImage, d.Get("name"))
+	assert.Equal(t, "17.1.1", d.Get("version"))
+	assert.Equal(t, "0.0.2", d.Get("build"))
+	assert.Equal(t, true, d.Get("verified"))
+
+	if diags := resourceBigipSysSoftwareImageDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.images)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysSoftwareImageAlreadyUploaded(t *testing.T) {
+	f := newFakeSoftwareServer(t)
+	path, data := writeTestIso(t)
+	f.images[testSoftwareImage] = data
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysSoftwareImage().Schema, map[string]interface{}{"local_path": path})
+	if diags := resourceBigipSysSoftwareImageCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.ranges, "an image already on the BIG-IP is not uploaded again")
+	assert.Equal(t, testSoftwareImage, d.Id())
+}
+
+func TestResourceBigipSysSoftwareImageMd5Mismatch(t *testing.T) {
+	f := newFakeSoftwareServer(t)
+	path, _ := writeTestIso(t)
+	assert.NoError(t, os.WriteFile(path+".md5", []byte("00000000000000000000000000000000  "+testSoftwareImage), 0600))
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysSoftwareImage().Schema, map[string]interface{}{"local_path": path})
+	diags := resourceBigipSysSoftwareImageCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for an ISO that does not match its MD5 file")
+	}
+	assert.Contains(t, diags[0].Summary, "does not match")
+	assert.Empty(t, f.ranges, "nothing is uploaded")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysSoftwareInstallAndReboot(t *testing.T) {
+	withFastPolling(t, &softwarePollInterval)
+	f := newFakeSoftwareServer(t)
+	f.images[testSoftwareImage] = []byte("iso")
+	f.installPolls = 3
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysSoftwareInstall().Schema, map[string]interface{}{
+		"image":  testSoftwareImage,
+		"volume": "HD1.2",
+	})
+	if diags := resourceBigipSysSoftwareInstallCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "HD1.2", d.Id())
+	assert.Equal(t, map[string]interface{}{"command": "install", "name": testSoftwareImage, "volume": "HD1.2", "options": []interface{}{map[string]interface{}{"create-volume": true}}}, f.installs[0])
+	assert.Equal(t, 0, f.installPolls)
+	assert.Equal(t, "complete", d.Get("status"))
+	assert.Equal(t, "17.1.1", d.Get("version"))
+	assert.Equal(t, false, d.Get("active"))
+	assert.Equal(t, "HD1.1", d.Get("active_volume"))
+	assert.Empty(t, f.reboots)
+
+	// enabling reboot boots into the volume
+	d = schema.TestResourceDataRaw(t, resourceBigipSysSoftwareInstall().Schema, map[string]interface{}{
+		"image":  testSoftwareImage,
+		"volume": "HD1.2",
+		"reboot": true,
+	})
+	d.SetId("HD1.2")
+	if diags := resourceBigipSysSoftwareInstallUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []string{"HD1.2"}, f.reboots)
+	assert.Equal(t, true, d.Get("active"))
+	assert.Equal(t, "HD1.2", d.Get("active_volume"))
+
+	// the active volume cannot be deleted
+	if diags := resourceBigipSysSoftwareInstallDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Empty(t, f.deleted)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysSoftwareInstallFails(t *testing.T) {
+	withFastPolling(t, &softwarePollInterval)
+	f := newFakeSoftwareServer(t)
+	f.images[testSoftwareImage] = []byte("iso")
+	f.failInstall = true
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysSoftwareInstall().Schema, map[string]interface{}{
+		"image":  testSoftwareImage,
+		"volume": "HD1.2",
+	})
+	diags := resourceBigipSysSoftwareInstallCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a failed install")
+	}
+	assert.Contains(t, diags[0].Summary, "Software install failed")
+}
+
+func TestResourceBigipSysSoftwareInstallImportAndDelete(t *testing.T) {
+	f := newFakeSoftwareServer(t)
+	f.images[testSoftwareImage] = []byte("iso")
+	f.volumes["HD1.2"] = &bigip.SoftwareVolume{Name: "HD1.2", Status: "complete", Product: "BIG-IP", Version: "17.1.1", Build: "0.0.2"}
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysSoftwareInstall().Schema, map[string]interface{}{})
+	d.SetId("HD1.2")
+	if diags := resourceBigipSysSoftwareInstallRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, testSoftwareImage, d.Get("image"))
+	assert.Equal(t, "HD1.2", d.Get("volume"))
+	assert.Equal(t, "HD1.1", d.Get("active_volume"))
+
+	// a volume reinstalled with another version is removed from state
+	f.volumes["HD1.2"].Version = "17.5.0"
+	if diags := resourceBigipSysSoftwareInstallRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Id())
+
+	d.SetId("HD1.2")
+	if diags := resourceBigipSysSoftwareInstallDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, []string{"HD1.2"}, f.deleted)
+	assert.NotContains(t, f.volumes, "HD1.2")
+}
+
+func TestResourceBigipSysSoftwareImageName(t *testing.T) {
+	r := resourceBigipSysSoftwareImage()
+	for _, name := range []string{"x; rm -rf --no-preserve-root /.iso", "$(reboot).iso", "BIGIP 17.iso"} {
+		_, errs := r.Schema["name"].ValidateFunc(name, "name")
+		assert.NotEmpty(t, errs, name)
+		_, err := resourceBigipSysSoftwareImageImport(context.Background(), r.Data(&terraform.InstanceState{ID: name}), nil)
+		assert.Error(t, err, name)
+	}
+
+	// a local_path whose file name is not a valid image name is not uploaded
+	f := newFakeSoftwareServer(t)
+	client := testFakeBigipClient(f.Server)
+	d := r.Data(nil)
+	_ = d.Set("local_path", filepath.Join(t.TempDir(), "BIGIP 17'.iso"))
+	diags := resourceBigipSysSoftwareImageCreate(context.Background(), d, client)
+	assert.True(t, diags.HasError())
+	assert.Empty(t, f.ranges)
+
+	// go-bigip passes the name as a single word of the commands
+	_ = client.DeleteSoftwareImage("x'; reboot; echo '.iso")
+	_, _ = client.SoftwareImageChecksum("x'; reboot; echo '.iso")
+	assert.Contains(t, f.scripts, []string{"md5sum", "/shared/images/x'; reboot; echo '.iso"})
+}
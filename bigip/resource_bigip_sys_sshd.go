@@ -0,0 +1,248 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const sysSshdID = "sshd"
+
+// sysSshdProperties maps the attributes of bigip_sys_sshd to the properties of sys sshd.
+var sysSshdProperties = map[string]string{
+	"allow":              "allow",
+	"banner":             "banner",
+	"banner_text":        "bannerText",
+	"inactivity_timeout": "inactivityTimeout",
+	"log_level":          "logLevel",
+	"login":              "login",
+	"port":               "port",
+}
+
+func resourceBigipSysSshd() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysSshdCreate,
+		ReadContext:   resourceBigipSysSshdRead,
+		UpdateContext: resourceBigipSysSshdUpdate,
+		DeleteContext: resourceBigipSysSshdDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"allow": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Addresses and networks allowed to connect to SSH, e.g. 10.0.0.0/255.0.0.0, or ALL",
+			},
+			"banner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Show the banner before the login prompt, enabled or disabled",
+			},
+			"banner_text": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Text of the banner shown before the login prompt",
+			},
+			"inactivity_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds of inactivity before an SSH session is closed, 0 disables the timeout",
+			},
+			"log_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"debug", "debug1", "debug2", "debug3", "error", "fatal", "info", "quiet", "verbose"}, false),
+				Description:  "Log level of the SSH daemon",
+			},
+			"login": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "Allow logins over SSH, enabled or disabled",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port the SSH daemon listens on",
+			},
+			"ciphers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ciphers offered by the SSH daemon, written to the Ciphers line of include",
+			},
+			"include": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Additional sshd_config lines, other than the Ciphers line",
+			},
+		},
+	}
+}
+
+func resourceBigipSysSshdCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Configuring sys sshd")
+	patch, err := sysSshdPatch(client, d, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(patch) > 0 {
+		if err := client.ModifySshd(patch); err != nil {
+			return diag.FromErr(fmt.Errorf("error modifying sys sshd: %v", err))
+		}
+	}
+	d.SetId(sysSshdID)
+	return resourceBigipSysSshdRead(ctx, d, meta)
+}
+
+func resourceBigipSysSshdRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading sys sshd")
+	sshd, err := client.GetSshd()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving sys sshd: %v", err))
+	}
+
+	d.SetId(sysSshdID)
+
+	include, ciphers := splitSshdInclude(sshd.Include)
+	_ = d.Set("allow", sshd.Allow)
+	_ = d.Set("banner", sshd.Banner)
+	_ = d.Set("banner_text", sshd.BannerText)
+	_ = d.Set("inactivity_timeout", sshd.InactivityTimeout)
+	_ = d.Set("log_level", sshd.LogLevel)
+	_ = d.Set("login", sshd.Login)
+	_ = d.Set("port", sshd.Port)
+	_ = d.Set("ciphers", ciphers)
+	_ = d.Set("include", include)
+	return nil
+}
+
+func resourceBigipSysSshdUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating sys sshd")
+	patch, err := sysSshdPatch(client, d, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(patch) > 0 {
+		if err := client.ModifySshd(patch); err != nil {
+			return diag.FromErr(fmt.Errorf("error modifying sys sshd: %v", err))
+		}
+	}
+	return resourceBigipSysSshdRead(ctx, d, meta)
+}
+
+func resourceBigipSysSshdDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// sshd always exists on the device; destroying the resource only stops
+	// Terraform from managing it and leaves the current values in place.
+	log.Printf("[INFO] Removing sys sshd from state, device configuration is left unchanged")
+	d.SetId("")
+	return nil
+}
+
+// sysSshdPatch returns the properties to patch. The Ciphers line and the rest
+// of include are one property on the device, the part that is not managed is
+// kept as it is.
+func sysSshdPatch(client *bigip.BigIP, d *schema.ResourceData, create bool) (map[string]interface{}, error) {
+	patch := sysSettingsPatch(d, sysSshdProperties, create)
+	includeSet := sysSettingChanged(d, "include", create)
+	ciphersSet := sysSettingChanged(d, "ciphers", create)
+	if !includeSet && !ciphersSet {
+		return patch, nil
+	}
+	sshd, err := client.GetSshd()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving sys sshd: %v", err)
+	}
+	include, ciphers := splitSshdInclude(sshd.Include)
+	if includeSet {
+		include = d.Get("include").(string)
+	}
+	if ciphersSet {
+		ciphers = listToStringSlice(d.Get("ciphers").([]interface{}))
+	}
+	patch["include"] = joinSshdInclude(include, ciphers)
+	return patch, nil
+}
+
+// splitSshdInclude separates the Ciphers line from the other lines of include.
+func splitSshdInclude(include string) (string, []string) {
+	var lines []string
+	var ciphers []string
+	for _, line := range strings.Split(include, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.EqualFold(fields[0], "Ciphers") {
+			ciphers = strings.Split(fields[1], ",")
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), ciphers
+}
+
+func joinSshdInclude(include string, ciphers []string) string {
+	lines := []string{}
+	if strings.TrimSpace(include) != "" {
+		lines = append(lines, strings.TrimRight(include, "\n"))
+	}
+	if len(ciphers) > 0 {
+		lines = append(lines, "Ciphers "+strings.Join(ciphers, ","))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sysSettingsPatch returns the properties of a singleton to patch: on create
+// the attributes in the configuration, on update the attributes that changed.
+func sysSettingsPatch(d *schema.ResourceData, properties map[string]string, create bool) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for attr, key := range properties {
+		if !sysSettingChanged(d, attr, create) {
+			continue
+		}
+		v := d.Get(attr)
+		if set, ok := v.(*schema.Set); ok {
+			v = setToStringSlice(set)
+		}
+		patch[key] = v
+	}
+	return patch
+}
+
+// sysSettingChanged tells if attr is to be patched: on create when it is in
+// the configuration, zero values included, on update when it changed.
+func sysSettingChanged(d *schema.ResourceData, attr string, create bool) bool {
+	if create {
+		return sysGlobalSettingConfigured(d, attr)
+	}
+	return d.HasChange(attr)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// the allow lists keep ALL so the test does not lock itself out
+const testAccBigipSysSshdHttpdConfig = `
+resource "bigip_sys_sshd" "test" {
+  allow              = ["ALL"]
+  banner             = "enabled"
+  banner_text        = "tf-acc-test"
+  inactivity_timeout = 1800
+}
+
+resource "bigip_sys_httpd" "test" {
+  allow                 = ["All"]
+  auth_pam_idle_timeout = 1800
+}
+`
+
+func TestAccBigipSysSshdHttpdCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipSysSshdHttpdConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_sshd.test", "banner_text", "tf-acc-test"),
+					resource.TestCheckResourceAttr("bigip_sys_sshd.test", "inactivity_timeout", "1800"),
+					resource.TestCheckResourceAttrSet("bigip_sys_sshd.test", "port"),
+					resource.TestCheckResourceAttr("bigip_sys_httpd.test", "auth_pam_idle_timeout", "1800"),
+					resource.TestCheckResourceAttrSet("bigip_sys_httpd.test", "ssl_protocol"),
+				),
+			},
+		},
+	})
+}
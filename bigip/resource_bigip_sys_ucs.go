@@ -0,0 +1,270 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ucsNameRegex matches a UCS archive file name, e.g. pre-upgrade.ucs
+var ucsNameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+\.ucs$`)
+
+// ucsPollInterval is how often the BIG-IP is polled while a UCS archive is restored.
+var ucsPollInterval = 10 * time.Second
+
+func resourceBigipSysUcs() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysUcsCreate,
+		ReadContext:   resourceBigipSysUcsRead,
+		UpdateContext: resourceBigipSysUcsUpdate,
+		DeleteContext: resourceBigipSysUcsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBigipSysUcsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "File name of the UCS archive in /var/local/ucs, e.g. pre-upgrade.ucs",
+				ValidateFunc: validation.StringMatch(ucsNameRegex, "must be a file name of letters, digits, '.', '_' and '-' ending in .ucs"),
+			},
+			"passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Passphrase used to encrypt the UCS archive and to decrypt it on restore",
+			},
+			"local_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Local file the UCS archive is downloaded to. The download is verified against the checksum of the archive on the BIG-IP",
+			},
+			"restore_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Restore the UCS archive on the BIG-IP before it is deleted on destroy",
+			},
+			"restore_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Changing this value restores the UCS archive on the BIG-IP",
+			},
+			"no_license": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Keep the current license of the BIG-IP when the UCS archive is restored",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      20,
+				Description:  "Minutes to wait for the BIG-IP to become ready after the UCS archive is restored",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 checksum of the UCS archive on the BIG-IP",
+			},
+			"file_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the UCS archive in bytes",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date the UCS archive was created",
+			},
+			"encrypted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the UCS archive is encrypted",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "BIG-IP version the UCS archive was taken on",
+			},
+		},
+	}
+}
+
+func resourceBigipSysUcsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Saving UCS archive %s", name)
+	if err := client.SaveUCS(name, d.Get("passphrase").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error saving UCS archive %s: %v", name, err))
+	}
+	d.SetId(name)
+	checksum, err := client.UCSChecksum(name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving checksum of UCS archive %s: %v", name, err))
+	}
+	_ = d.Set("checksum", checksum)
+	if localPath := d.Get("local_path").(string); localPath != "" {
+		if err := downloadUcs(client, name, localPath, checksum); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceBigipSysUcsRead(ctx, d, meta)
+}
+
+func resourceBigipSysUcsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Printf("[INFO] Reading UCS archive %s", name)
+	ucs, err := client.GetUCS(name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving UCS archive %s: %v", name, err))
+	}
+	if ucs == nil {
+		log.Printf("[WARN] UCS archive %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	checksum := d.Get("checksum").(string)
+	if checksum == "" || ucs.Created != d.Get("created").(string) {
+		if checksum, err = client.UCSChecksum(name); err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving checksum of UCS archive %s: %v", name, err))
+		}
+	}
+	_ = d.Set("name", ucs.Name)
+	_ = d.Set("checksum", checksum)
+	_ = d.Set("file_size", ucs.FileSize)
+	_ = d.Set("created", ucs.Created)
+	_ = d.Set("encrypted", ucs.Encrypted)
+	_ = d.Set("version", ucs.Version)
+
+	// a missing or modified download is downloaded again
+	if localPath := d.Get("local_path").(string); localPath != "" {
+		if sum, err := fileSha256(localPath); err != nil || sum != checksum {
+			log.Printf("[WARN] Download %s of UCS archive %s is missing or does not match its checksum", localPath, name)
+			_ = d.Set("local_path", "")
+		}
+	}
+	return nil
+}
+
+func resourceBigipSysUcsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !ucsNameRegex.MatchString(d.Id()) {
+		return nil, fmt.Errorf("expected the file name of a UCS archive in /var/local/ucs, e.g. pre-upgrade.ucs, got %s", d.Id())
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceBigipSysUcsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	if d.HasChange("local_path") {
+		if localPath := d.Get("local_path").(string); localPath != "" {
+			if err := downloadUcs(client, name, localPath, d.Get("checksum").(string)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	if d.HasChange("restore_trigger") {
+		if err := restoreUcs(client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceBigipSysUcsRead(ctx, d, meta)
+}
+
+func resourceBigipSysUcsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	if d.Get("restore_on_destroy").(bool) {
+		if err := restoreUcs(client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("[INFO] Deleting UCS archive %s", name)
+	if err := client.DeleteUCS(name); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting UCS archive %s: %v", name, err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// restoreUcs loads the UCS archive and waits for the BIG-IP to become ready.
+func restoreUcs(client *bigip.BigIP, d *schema.ResourceData) error {
+	name := d.Id()
+	log.Printf("[INFO] Restoring UCS archive %s", name)
+	err := client.LoadUCS(name, d.Get("passphrase").(string), d.Get("no_license").(bool))
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// services restart while the archive is loaded, the connection may drop
+		log.Printf("[WARN] Connection lost while restoring UCS archive %s: %v", name, err)
+	} else if err != nil {
+		return fmt.Errorf("error restoring UCS archive %s: %v", name, err)
+	}
+	timeout := time.Duration(d.Get("timeout").(int)) * time.Minute
+	if err := client.WaitForSysReady(timeout, ucsPollInterval); err != nil {
+		return fmt.Errorf("error restoring UCS archive %s: %v", name, err)
+	}
+	return nil
+}
+
+// downloadUcs downloads the UCS archive to localPath and verifies it against checksum.
+func downloadUcs(client *bigip.BigIP, name, localPath, checksum string) error {
+	log.Printf("[INFO] Downloading UCS archive %s to %s", name, localPath)
+	if err := os.MkdirAll(filepath.Dir(localPath), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(localPath), filepath.Base(localPath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	_, err = client.DownloadUCS(name, io.MultiWriter(tmp, hash))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("error downloading UCS archive %s: %v", name, err)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum {
+		return fmt.Errorf("error downloading UCS archive %s: checksum %s does not match %s on the BIG-IP", name, sum, checksum)
+	}
+	return os.Rename(tmp.Name(), localPath)
+}
+
+func fileSha256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
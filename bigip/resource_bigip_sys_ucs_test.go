@@ -0,0 +1,71 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccBigipSysUcsConfig(localPath string) string {
+	return fmt.Sprintf(`
+resource "bigip_sys_ucs" "test" {
+  name       = "tf-acc-test.ucs"
+  passphrase = "tf-acc-passphrase"
+  local_path = %q
+}
+
+data "bigip_sys_ucs_archives" "test" {
+  depends_on = [bigip_sys_ucs.test]
+}
+`, localPath)
+}
+
+func TestAccBigipSysUcsCreate(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "tf-acc-test.ucs")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSysUcsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipSysUcsConfig(localPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_ucs.test", "name", "tf-acc-test.ucs"),
+					resource.TestCheckResourceAttr("bigip_sys_ucs.test", "local_path", localPath),
+					resource.TestCheckResourceAttr("bigip_sys_ucs.test", "encrypted", "true"),
+					resource.TestCheckResourceAttrSet("bigip_sys_ucs.test", "checksum"),
+					resource.TestCheckResourceAttrSet("data.bigip_sys_ucs_archives.test", "archives.#"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckSysUcsDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_sys_ucs" {
+			continue
+		}
+		ucs, err := client.GetUCS(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if ucs != nil {
+			return fmt.Errorf("UCS archive %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
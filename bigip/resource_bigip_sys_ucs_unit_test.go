@@ -0,0 +1,270 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_sys_ucs and bigip_sys_ucs_archives against a fake BIG-IP - no F5 BIG-IP connection required
+
+// fakeUcsServer stores UCS archives in memory and serves them through the
+// chunked ucs-downloads endpoint.
+type fakeUcsServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	archives map[string][]byte
+	commands []map[string]interface{}
+	scripts  [][]string
+	ranges   []string
+	corrupt  bool
+}
+
+var testContentRange = regexp.MustCompile(`^(\d+)-(\d+)/\d+$`)
+
+func newFakeUcsServer(t *testing.T) *fakeUcsServer {
+	f := &fakeUcsServer{archives: make(map[string][]byte)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/sys/ucs", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			var cmd map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&cmd)
+			f.commands = append(f.commands, cmd)
+			name := cmd["name"].(string)
+			switch cmd["command"] {
+			case "save":
+				// a 2.5 MB archive is downloaded in three chunks
+				f.archives[name] = append([]byte(name), bytes.Repeat([]byte{0}, 2621440-len(name))...)
+			case "load":
+				if _, ok := f.archives[name]; !ok {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = fmt.Fprintf(w, `{"code":400,"message":"%s: No such file or directory"}`, name)
+					return
+				}
+			}
+			_, _ = fmt.Fprint(w, `{}`)
+			return
+		}
+		var items []map[string]interface{}
+		for name, data := range f.archives {
+			items = append(items, map[string]interface{}{"apiRawValues": map[string]string{
+				"filename":          "/var/local/ucs/" + name,
+				"file_size":         fmt.Sprintf("%d (in bytes)", len(data)),
+				"file_created_date": "2024-05-01T10:00:00Z",
+				"encrypted":         "yes",
+				"version":           "17.1.0",
+				"hostname":          "bigip1.example.com",
+			}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "tm:sys:ucs:ucscollectionstate", "items": items})
+	})
+	mux.HandleFunc("/mgmt/tm/sys/ucs/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.archives, strings.TrimPrefix(r.URL.Path, "/mgmt/tm/sys/ucs/"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request) {
+		var cmd bigip.BigipCommand
+		_ = json.NewDecoder(r.Body).Decode(&cmd)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.scripts = append(f.scripts, testBashScript(cmd.UtilCmdArgs))
+		name := strings.TrimPrefix(testBashScript(cmd.UtilCmdArgs)[1], "/var/local/ucs/")
+		sum := sha256.Sum256(f.archives[name])
+		cmd.CommandResult = hex.EncodeToString(sum[:]) + "  /var/local/ucs/" + name + "\n"
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(cmd)
+	})
+	mux.HandleFunc("/mgmt/shared/file-transfer/ucs-downloads/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		data := f.archives[strings.TrimPrefix(r.URL.Path, "/mgmt/shared/file-transfer/ucs-downloads/")]
+		m := testContentRange.FindStringSubmatch(r.Header.Get("Content-Range"))
+		f.ranges = append(f.ranges, r.Header.Get("Content-Range"))
+		start, _ := strconv.Atoi(m[1])
+		end, _ := strconv.Atoi(m[2])
+		if end >= len(data) {
+			end = len(data) - 1
+		}
+		chunk := append([]byte{}, data[start:end+1]...)
+		if f.corrupt {
+			chunk[0] ^= 0xff
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Range", fmt.Sprintf("%d-%d/%d", start, end, len(data)))
+		_, _ = w.Write(chunk)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/ready", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"entries":{"https://localhost/mgmt/tm/sys/ready/0":{"nestedStats":{"entries":{"configReady":{"description":"yes"},"licenseReady":{"description":"yes"},"provisionReady":{"description":"yes"}}}}}}`)
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestResourceBigipSysUcsCreateDownloads(t *testing.T) {
+	f := newFakeUcsServer(t)
+	client := testFakeBigipClient(f.Server)
+	localPath := filepath.Join(t.TempDir(), "backups", "pre-upgrade.ucs")
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysUcs().Schema, map[string]interface{}{
+		"name":       "pre-upgrade.ucs",
+		"passphrase": "s3cret",
+		"local_path": localPath,
+	})
+	if diags := resourceBigipSysUcsCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "pre-upgrade.ucs", d.Id())
+	assert.Equal(t, map[string]interface{}{"command": "save", "name": "pre-upgrade.ucs", "options": []interface{}{map[string]interface{}{"passphrase": "s3cret"}}}, f.commands[0])
+
+	data, err := os.ReadFile(localPath)
+	assert.NoError(t, err)
+	assert.Equal(t, f.archives["pre-upgrade.ucs"], data)
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), d.Get("checksum"))
+	assert.Equal(t, len(data), d.Get("file_size"))
+	assert.Equal(t, true, d.Get("encrypted"))
+	assert.Equal(t, "17.1.0", d.Get("version"))
+	assert.Equal(t, []string{"0-1048575/0", "1048576-2097151/2621440", "2097152-2621439/2621440"}, f.ranges)
+	assert.Equal(t, localPath, d.Get("local_path"))
+
+	// a modified download is reported as a change
+	assert.NoError(t, os.WriteFile(localPath, []byte("tampered"), 0600))
+	if diags := resourceBigipSysUcsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "", d.Get("local_path"))
+}
+
+func TestResourceBigipSysUcsChecksumMismatch(t *testing.T) {
+	f := newFakeUcsServer(t)
+	f.corrupt = true
+	client := testFakeBigipClient(f.Server)
+	dir := t.TempDir()
+	localPath := filepath.Join(dir, "pre-upgrade.ucs")
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysUcs().Schema, map[string]interface{}{
+		"name":       "pre-upgrade.ucs",
+		"local_path": localPath,
+	})
+	diags := resourceBigipSysUcsCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a corrupted download")
+	}
+	assert.Contains(t, diags[0].Summary, "does not match")
+	_, err := os.Stat(localPath)
+	assert.True(t, os.IsNotExist(err))
+	files, _ := os.ReadDir(dir)
+	assert.Empty(t, files, "the partial download is removed")
+}
+
+func TestResourceBigipSysUcsRestore(t *testing.T) {
+	withFastPolling(t, &ucsPollInterval)
+	f := newFakeUcsServer(t)
+	f.archives["pre-upgrade.ucs"] = []byte("ucs")
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysUcs().Schema, map[string]interface{}{
+		"name":               "pre-upgrade.ucs",
+		"passphrase":         "s3cret",
+		"no_license":         true,
+		"restore_trigger":    "rollback-1",
+		"restore_on_destroy": true,
+	})
+	d.SetId("pre-upgrade.ucs")
+	if diags := resourceBigipSysUcsUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, map[string]interface{}{"command": "load", "name": "pre-upgrade.ucs", "options": []interface{}{
+		map[string]interface{}{"passphrase": "s3cret"},
+		map[string]interface{}{"no-license": ""},
+	}}, f.commands[0])
+
+	if diags := resourceBigipSysUcsDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "load", f.commands[1]["command"])
+	assert.Empty(t, f.archives)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipSysUcsRestoreError(t *testing.T) {
+	withFastPolling(t, &ucsPollInterval)
+	f := newFakeUcsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysUcs().Schema, map[string]interface{}{
+		"name":            "missing.ucs",
+		"restore_trigger": "1",
+	})
+	d.SetId("missing.ucs")
+	diags := resourceBigipSysUcsUpdate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error restoring a missing archive")
+	}
+	assert.Contains(t, diags[0].Summary, "No such file or directory")
+}
+
+func TestResourceBigipSysUcsName(t *testing.T) {
+	validate := resourceBigipSysUcs().Schema["name"].ValidateFunc
+	for _, name := range []string{"x'; reboot; echo '.ucs", "$(reboot).ucs", "pre upgrade.ucs", "../pre-upgrade.ucs", "pre-upgrade"} {
+		_, errs := validate(name, "name")
+		assert.NotEmpty(t, errs, name)
+		d := resourceBigipSysUcs().Data(&terraform.InstanceState{ID: name})
+		_, err := resourceBigipSysUcsImport(context.Background(), d, nil)
+		assert.Error(t, err, name)
+	}
+	_, errs := validate("pre-upgrade_17.1.0.ucs", "name")
+	assert.Empty(t, errs)
+
+	// go-bigip passes the name as a single word of the command
+	f := newFakeUcsServer(t)
+	client := testFakeBigipClient(f.Server)
+	_, _ = client.UCSChecksum("x'; reboot; echo '.ucs")
+	assert.Equal(t, [][]string{{"sha256sum", "/var/local/ucs/x'; reboot; echo '.ucs"}}, f.scripts)
+}
+
+func TestDataSourceBigipSysUcsArchives(t *testing.T) {
+	f := newFakeUcsServer(t)
+	f.archives["a.ucs"] = []byte("12345")
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipSysUcsArchives().Schema, map[string]interface{}{})
+	if diags := dataSourceBigipSysUcsArchivesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, 1, d.Get("archives.#"))
+	assert.Equal(t, "a.ucs", d.Get("archives.0.name"))
+	assert.Equal(t, 5, d.Get("archives.0.file_size"))
+	assert.Equal(t, "bigip1.example.com", d.Get("archives.0.hostname"))
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+
+	bigip "github.com/f5devcentral/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+)
+
+// tsResourceID is the id of bigip_ts, Telemetry Streaming holds a single declaration per device.
+const tsResourceID = "telemetry"
+
+// stripTSMetadata removes Telemetry Streaming metadata fields from a parsed JSON declaration.
+func stripTSMetadata(jsonRef map[string]interface{}) {
+	delete(jsonRef, "$schema")
+	delete(jsonRef, "schemaVersion")
+	for key, value := range jsonRef {
+		if rec, ok := value.(map[string]interface{}); ok && rec["class"] == "Controls" {
+			delete(jsonRef, key)
+		}
+	}
+}
+
+// tsPruneDeclaration reduces the declaration returned by Telemetry Streaming
+// to the properties set in the configured declaration, dropping the defaults
+// Telemetry Streaming fills in. Top level components missing from the
+// configured declaration are kept, so components added outside of terraform
+// show up as drift. Secrets encrypted by Telemetry Streaming keep their
+// configured value.
+func tsPruneDeclaration(remote, config map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range remote {
+		if want, ok := config[key]; ok {
+			result[key] = tsPruneValue(value, want)
+			continue
+		}
+		if rec, ok := value.(map[string]interface{}); ok && rec["class"] != nil && rec["class"] != "Controls" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func tsPruneValue(remote, config interface{}) interface{} {
+	switch want := config.(type) {
+	case map[string]interface{}:
+		got, ok := remote.(map[string]interface{})
+		if !ok {
+			return remote
+		}
+		if got["protected"] == "SecureVault" {
+			// secrets come back encrypted and cannot be compared
+			return config
+		}
+		result := make(map[string]interface{})
+		for key := range want {
+			if v, ok := got[key]; ok {
+				result[key] = tsPruneValue(v, want[key])
+			}
+		}
+		return result
+	case []interface{}:
+		got, ok := remote.([]interface{})
+		if !ok || len(got) != len(want) {
+			return remote
+		}
+		result := make([]interface{}, len(got))
+		for i := range got {
+			result[i] = tsPruneValue(got[i], want[i])
+		}
+		return result
+	}
+	return remote
+}
+
+func resourceBigipTs() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipTsCreate,
+		ReadContext:   resourceBigipTsRead,
+		UpdateContext: resourceBigipTsUpdate,
+		DeleteContext: resourceBigipTsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ts_json": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Telemetry Streaming declaration as a JSON string",
+				StateFunc: func(v interface{}) string {
+					jsonString, _ := structure.NormalizeJsonString(v)
+					return jsonString
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					oldJsonref := make(map[string]interface{})
+					newJsonref := make(map[string]interface{})
+					_ = json.Unmarshal([]byte(old), &oldJsonref)
+					_ = json.Unmarshal([]byte(new), &newJsonref)
+					delete(oldJsonref, "$schema")
+					delete(newJsonref, "$schema")
+					if reflect.DeepEqual(oldJsonref, newJsonref) {
+						return true
+					}
+					if !d.Get("ignore_metadata").(bool) {
+						return false
+					}
+					stripTSMetadata(oldJsonref)
+					stripTSMetadata(newJsonref)
+					return reflect.DeepEqual(oldJsonref, newJsonref)
+				},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					jsonRef := make(map[string]interface{})
+					if err := json.Unmarshal([]byte(v.(string)), &jsonRef); err != nil {
+						errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+						return
+					}
+					if jsonRef["class"] != "Telemetry" {
+						errors = append(errors, fmt.Errorf("JSON must have Telemetry class"))
+					}
+					return
+				},
+			},
+			"ignore_metadata": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set to true to ignore Telemetry Streaming metadata fields ($schema, schemaVersion and Controls) when comparing declarations",
+			},
+		},
+	}
+}
+
+func resourceBigipTsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Creating Telemetry Streaming declaration")
+	if err := postTsDeclaration(client, d); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Telemetry Streaming declaration: %v", err))
+	}
+	d.SetId(tsResourceID)
+	return resourceBigipTsRead(ctx, d, meta)
+}
+
+func resourceBigipTsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading Telemetry Streaming declaration")
+	remote, err := client.GetTelemetry()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving Telemetry Streaming declaration: %v", err))
+	}
+	if err := setTsDeclaration(d, remote); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceBigipTsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating Telemetry Streaming declaration")
+	if err := postTsDeclaration(client, d); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Telemetry Streaming declaration: %v", err))
+	}
+	return resourceBigipTsRead(ctx, d, meta)
+}
+
+func resourceBigipTsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Deleting Telemetry Streaming declaration")
+	if err := client.DeleteTelemetry(); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Telemetry Streaming declaration: %v", err))
+	}
+	d.SetId("")
+	return nil
+}
+
+func postTsDeclaration(client *bigip.BigIP, d *schema.ResourceData) error {
+	tsJson := d.Get("ts_json").(string)
+	declaration, err := client.PostTelemetry(tsJson)
+	if err != nil {
+		return err
+	}
+	return setTsDeclaration(d, declaration)
+}
+
+// setTsDeclaration stores the declaration reported by Telemetry Streaming,
+// pruned to the properties of the declaration already in state.
+func setTsDeclaration(d *schema.ResourceData, remote map[string]interface{}) error {
+	declaration := remote
+	current := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(d.Get("ts_json").(string)), &current); err == nil && len(current) > 0 {
+		declaration = tsPruneDeclaration(remote, current)
+	}
+	out, err := json.Marshal(declaration)
+	if err != nil {
+		return err
+	}
+	_ = d.Set("ts_json", string(out))
+	return nil
+}
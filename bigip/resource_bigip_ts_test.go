@@ -0,0 +1,57 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccBigipTsConfig = `
+resource "bigip_ts" "test" {
+  ts_json = jsonencode({
+    class = "Telemetry"
+    My_System = {
+      class = "Telemetry_System"
+      systemPoller = {
+        interval = %d
+      }
+    }
+    My_Pull_Consumer = {
+      class        = "Telemetry_Pull_Consumer"
+      type         = "default"
+      systemPoller = ["My_System"]
+    }
+  })
+}
+`
+
+func TestAccBigipTsCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBigipTsConfig, 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("bigip_ts.test", "ts_json", regexp.MustCompile(`"interval":60`)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccBigipTsConfig, 120),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("bigip_ts.test", "ts_json", regexp.MustCompile(`"interval":120`)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unit tests for bigip_ts and bigip_ts_pull_consumer against a fake Telemetry Streaming endpoint - no F5 BIG-IP connection required
+
+const testTsDeclaration = `{"class":"Telemetry","schemaVersion":"1.33.0","My_System":{"class":"Telemetry_System","systemPoller":{"interval":60}},"My_Consumer":{"class":"Telemetry_Consumer","type":"Splunk","host":"192.0.2.1","passphrase":{"cipherText":"apikey"}}}`
+
+// fakeTsServer stores the last declaration the way Telemetry Streaming
+// does, with defaults filled in and passphrases encrypted.
+type fakeTsServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	declaration map[string]interface{}
+	posts       []string
+}
+
+func newFakeTsServer(t *testing.T) *fakeTsServer {
+	f := &fakeTsServer{declaration: map[string]interface{}{"class": "Telemetry"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/shared/telemetry/declare", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			var decl map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&decl)
+			out, _ := json.Marshal(decl)
+			f.posts = append(f.posts, string(out))
+			if _, ok := decl["Bad"]; ok {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = fmt.Fprint(w, `{"code":422,"message":"Unprocessable entity","errors":["/Bad: should NOT have additional properties"]}`)
+				return
+			}
+			f.declaration = expandTsDeclaration(decl)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"message": "success", "declaration": f.declaration})
+	})
+	mux.HandleFunc("/mgmt/shared/telemetry/pullconsumer/My_Pull", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `[{"system":{"hostname":"bigip1.example.com"}}]`)
+	})
+	mux.HandleFunc("/mgmt/shared/telemetry/namespace/ns1/pullconsumer/My_Prometheus", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = fmt.Fprint(w, "# HELP f5_counters_bitsIn\nf5_counters_bitsIn 42\n")
+	})
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func expandTsDeclaration(decl map[string]interface{}) map[string]interface{} {
+	for _, v := range decl {
+		if component, ok := v.(map[string]interface{}); ok {
+			component["enable"] = true
+			component["trace"] = false
+			if p, ok := component["passphrase"].(map[string]interface{}); ok {
+				p["class"] = "Secret"
+				p["protected"] = "SecureVault"
+				p["cipherText"] = "$M$encrypted"
+			}
+		}
+	}
+	decl["controls"] = map[string]interface{}{"class": "Controls", "logLevel": "info", "debug": false}
+	return decl
+}
+
+func tsJsonSuppressed(t *testing.T, old, new string, ignoreMetadata bool) bool {
+	d := schema.TestResourceDataRaw(t, resourceBigipTs().Schema, map[string]interface{}{
+		"ts_json":         new,
+		"ignore_metadata": ignoreMetadata,
+	})
+	return resourceBigipTs().Schema["ts_json"].DiffSuppressFunc("ts_json", old, new, d)
+}
+
+func TestTsPruneDeclaration(t *testing.T) {
+	var remote, config map[string]interface{}
+	_ = json.Unmarshal([]byte(`{"class":"Telemetry","controls":{"class":"Controls","logLevel":"info"},
+		"A":{"class":"Telemetry_System","enable":true,"systemPoller":[{"interval":60,"enable":true}]},
+		"Added":{"class":"Telemetry_Listener","port":6514},"schemaVersion":"1.33.0"}`), &remote)
+	_ = json.Unmarshal([]byte(`{"class":"Telemetry","A":{"class":"Telemetry_System","systemPoller":[{"interval":60}]}}`), &config)
+	out, _ := json.Marshal(tsPruneDeclaration(remote, config))
+	assert.JSONEq(t, `{"class":"Telemetry","A":{"class":"Telemetry_System","systemPoller":[{"interval":60}]},"Added":{"class":"Telemetry_Listener","port":6514}}`, string(out))
+}
+
+func TestResourceBigipTsDiffSuppress(t *testing.T) {
+	config := `{"class":"Telemetry","schemaVersion":"1.33.0","A":{"class":"Telemetry_System"}}`
+	assert.True(t, tsJsonSuppressed(t, `{"$schema":"x","class":"Telemetry","schemaVersion":"1.33.0","A":{"class":"Telemetry_System"}}`, config, false))
+	assert.False(t, tsJsonSuppressed(t, `{"class":"Telemetry","schemaVersion":"1.32.0","A":{"class":"Telemetry_System"}}`, config, false))
+	assert.True(t, tsJsonSuppressed(t, `{"class":"Telemetry","schemaVersion":"1.32.0","A":{"class":"Telemetry_System"}}`, config, true))
+	assert.True(t, tsJsonSuppressed(t, `{"class":"Telemetry","c":{"class":"Controls","logLevel":"debug"},"A":{"class":"Telemetry_System"}}`, config, true))
+	assert.False(t, tsJsonSuppressed(t, `{"class":"Telemetry","A":{"class":"Telemetry_Listener"}}`, config, true))
+}
+
+func TestResourceBigipTsLifecycle(t *testing.T) {
+	f := newFakeTsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipTs().Schema, map[string]interface{}{"ts_json": testTsDeclaration})
+	if diags := resourceBigipTsCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "telemetry", d.Id())
+	// neither the defaults filled in by Telemetry Streaming nor the encrypted passphrase are a change
+	assert.True(t, tsJsonSuppressed(t, d.Get("ts_json").(string), testTsDeclaration, false))
+	var state map[string]interface{}
+	_ = json.Unmarshal([]byte(d.Get("ts_json").(string)), &state)
+	assert.NotContains(t, state, "controls")
+	assert.NotContains(t, state["My_System"], "enable")
+	assert.Equal(t, "192.0.2.1", state["My_Consumer"].(map[string]interface{})["host"])
+
+	// drift: a property changed and a component added on the device
+	f.mu.Lock()
+	f.declaration["My_Consumer"].(map[string]interface{})["host"] = "192.0.2.99"
+	f.declaration["Other"] = map[string]interface{}{"class": "Telemetry_Listener", "port": 6514}
+	f.mu.Unlock()
+	if diags := resourceBigipTsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	_ = json.Unmarshal([]byte(d.Get("ts_json").(string)), &state)
+	assert.Equal(t, "192.0.2.99", state["My_Consumer"].(map[string]interface{})["host"])
+	assert.Contains(t, state, "Other")
+
+	if diags := resourceBigipTsDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, `{"class":"Telemetry"}`, f.posts[len(f.posts)-1])
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigipTsNoDriftAfterCreate(t *testing.T) {
+	f := newFakeTsServer(t)
+	client := testFakeBigipClient(f.Server)
+	tsJson := `{"class":"Telemetry","My_System":{"class":"Telemetry_System","systemPoller":{"interval":60}}}`
+
+	d := schema.TestResourceDataRaw(t, resourceBigipTs().Schema, map[string]interface{}{"ts_json": tsJson})
+	if diags := resourceBigipTsCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.True(t, tsJsonSuppressed(t, d.Get("ts_json").(string), tsJson, false))
+}
+
+func TestResourceBigipTsCreateError(t *testing.T) {
+	f := newFakeTsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, resourceBigipTs().Schema, map[string]interface{}{"ts_json": `{"class":"Telemetry","Bad":{}}`})
+	diags := resourceBigipTsCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a rejected declaration")
+	}
+	assert.Contains(t, diags[0].Summary, "should NOT have additional properties")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestDataSourceBigipTsPullConsumer(t *testing.T) {
+	f := newFakeTsServer(t)
+	client := testFakeBigipClient(f.Server)
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipTsPullConsumer().Schema, map[string]interface{}{"name": "My_Pull"})
+	if diags := dataSourceBigipTsPullConsumerRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "My_Pull", d.Id())
+	assert.JSONEq(t, `[{"system":{"hostname":"bigip1.example.com"}}]`, d.Get("output").(string))
+
+	d = schema.TestResourceDataRaw(t, dataSourceBigipTsPullConsumer().Schema, map[string]interface{}{"name": "My_Prometheus", "namespace": "ns1"})
+	if diags := dataSourceBigipTsPullConsumerRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	assert.Equal(t, "ns1/My_Prometheus", d.Id())
+	assert.Contains(t, d.Get("output").(string), "f5_counters_bitsIn 42")
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -191,6 +192,33 @@ func validateAssignmentType(value interface{}, field string) (ws []string, error
 	return
 }
 
+// validateTsigAlgorithm checks a TSIG key algorithm against the names BIG-IP
+// accepts. The RFC 8945 spelling (hmac-sha256) is rejected with a hint, as it
+// is the most common mistake when copying keys from BIND configuration.
+func validateTsigAlgorithm(value interface{}, field string) (ws []string, errors []error) {
+	v, ok := value.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("Unknown type %v in validateTsigAlgorithm", reflect.TypeOf(value)))
+		return
+	}
+	valid := []string{"hmacmd5", "hmacsha1", "hmacsha256"}
+	for _, a := range valid {
+		if v == a {
+			return
+		}
+	}
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSuffix(v, "."), "-", ""))
+	normalized = strings.TrimSuffix(normalized, ".sigalg.reg.int")
+	for _, a := range valid {
+		if normalized == a {
+			errors = append(errors, fmt.Errorf("%q: BIG-IP names this algorithm %q, got: %s", field, a, v))
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%q must be one of %v, got: %s", field, valid, v))
+	return
+}
+
 func getDeviceUri(str string) []string {
 	re := regexp.MustCompile(`^(?:(?:(https?|s?ftp):)\/\/)([^:\/\s]+)(?::(\d*))?`)
 	if len(re.FindStringSubmatch(str)) > 0 {
@@ -70,3 +70,23 @@ func TestValidateEnabledDisabledString(t *testing.T) {
 		assert.Equal(t, ec, len(errs), "%s did not throw %d errors", d, ec)
 	}
 }
+
+func TestValidateTsigAlgorithm(t *testing.T) {
+	data := map[string]int{
+		"hmacmd5":                   0,
+		"hmacsha1":                  0,
+		"hmacsha256":                0,
+		"hmac-sha256":               1,
+		"HMAC-MD5.SIG-ALG.REG.INT.": 1,
+		"hmac-sha512":               1,
+		"":                          1,
+	}
+
+	for d, ec := range data {
+		_, errs := validateTsigAlgorithm(d, "testField")
+		assert.Equal(t, ec, len(errs), "%s did not throw %d errors", d, ec)
+	}
+
+	_, errs := validateTsigAlgorithm("hmac-sha256", "algorithm")
+	assert.Contains(t, errs[0].Error(), `"hmacsha256"`)
+}
@@ -0,0 +1,17 @@
+# DNS monitor checking resolver health
+resource "bigip_gtm_monitor_dns" "resolver" {
+  name            = "/Common/resolver_health"
+  destination     = "*:53"
+  qname           = "www.example.com"
+  qtype           = "a"
+  accept_rcode    = "no-error"
+  answer_contains = "query-type"
+}
+
+# Gateway ICMP monitor for link checks
+resource "bigip_gtm_monitor_gateway_icmp" "uplink" {
+  name           = "/Common/uplink_icmp"
+  interval       = 10
+  timeout        = 31
+  probe_attempts = 5
+}
@@ -0,0 +1,21 @@
+/*
+Copyright 2019 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+// Package schemas bundles the JSON schemas used to validate ATC declarations
+// offline, before they are sent to a BIG-IP.
+package schemas
+
+import _ "embed"
+
+// DO is the F5 Declarative Onboarding declaration schema.
+//
+//go:embed doschema.json
+var DO []byte
+
+// AS3 is the F5 Application Services 3 declaration schema.
+//
+//go:embed as3schema.json
+var AS3 []byte